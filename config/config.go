@@ -3,6 +3,8 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -10,6 +12,24 @@ import (
 type Config struct {
 	AppName string
 	Port    string
+
+	PrinterTimeout time.Duration // batas waktu koneksi dan kirim ke printer struk jaringan
+
+	// Notifikasi pelanggan
+	NotifyDefaultChannel string // channel untuk template bawaan: whatsapp, sms, email; kosong = hanya template toko
+	NotifyFake           bool   // kirim ke provider palsu (log) untuk development
+	NotifyInterval       time.Duration
+	NotifyMaxAttempts    int
+	WhatsAppAPIURL       string
+	WhatsAppAPIToken     string
+	SMSAPIURL            string
+	SMSAPIKey            string
+	SMSSender            string
+	SMTPHost             string
+	SMTPPort             string
+	SMTPUsername         string
+	SMTPPassword         string
+	SMTPFrom             string
 }
 
 var Cfg Config
@@ -23,6 +43,23 @@ func LoadConfig() {
 	Cfg = Config{
 		AppName: getEnv("APP_NAME", "SumunarPOS-CORE"),
 		Port:    getEnv("APP_PORT", "1323"),
+
+		PrinterTimeout: getDuration("PRINTER_TIMEOUT", 5*time.Second),
+
+		NotifyDefaultChannel: getEnv("NOTIFY_DEFAULT_CHANNEL", ""),
+		NotifyFake:           getEnv("NOTIFY_FAKE", "false") == "true",
+		NotifyInterval:       getDuration("NOTIFY_INTERVAL", 10*time.Second),
+		NotifyMaxAttempts:    getInt("NOTIFY_MAX_ATTEMPTS", 5),
+		WhatsAppAPIURL:       getEnv("WHATSAPP_API_URL", ""),
+		WhatsAppAPIToken:     getEnv("WHATSAPP_API_TOKEN", ""),
+		SMSAPIURL:            getEnv("SMS_API_URL", ""),
+		SMSAPIKey:            getEnv("SMS_API_KEY", ""),
+		SMSSender:            getEnv("SMS_SENDER", ""),
+		SMTPHost:             getEnv("SMTP_HOST", ""),
+		SMTPPort:             getEnv("SMTP_PORT", "587"),
+		SMTPUsername:         getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:         getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:             getEnv("SMTP_FROM", ""),
 	}
 }
 
@@ -32,3 +69,29 @@ func getEnv(key, defaultVal string) string {
 	}
 	return defaultVal
 }
+
+func getDuration(key string, defaultVal time.Duration) time.Duration {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultVal
+	}
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		log.Printf("⚠️  invalid %s %q, using %s", key, val, defaultVal)
+		return defaultVal
+	}
+	return d
+}
+
+func getInt(key string, defaultVal int) int {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultVal
+	}
+	n, err := strconv.Atoi(val)
+	if err != nil {
+		log.Printf("⚠️  invalid %s %q, using %d", key, val, defaultVal)
+		return defaultVal
+	}
+	return n
+}
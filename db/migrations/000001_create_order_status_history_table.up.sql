@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS order_status_history (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL DEFAULT '',
+    to_status VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    changed_by UUID NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_history_order_id ON order_status_history (order_id, changed_at);
+
+-- normalize statuses written by the old request validator
+UPDATE orders SET status = 'pending' WHERE status = 'PENDING';
+UPDATE orders SET status = 'processed' WHERE status = 'ONGOING';
+UPDATE orders SET status = 'done' WHERE status = 'COMPLETED';
+UPDATE orders SET status = 'cancelled' WHERE status = 'CANCELLED';
+
+ALTER TABLE orders
+    ADD CONSTRAINT chk_orders_status CHECK (status IN ('pending', 'processed', 'done', 'taken', 'cancelled'));
@@ -0,0 +1,5 @@
+UPDATE orders o SET
+    paid_amount = COALESCE((SELECT SUM(p.amount) FROM order_payments p WHERE p.order_id = o.id), 0),
+    change = COALESCE((SELECT SUM(p.amount) FROM order_payments p WHERE p.order_id = o.id), 0) - o.total_price;
+
+DROP TABLE IF EXISTS order_payments;
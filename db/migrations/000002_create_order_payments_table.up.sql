@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS order_payments (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    amount NUMERIC(14, 2) NOT NULL CHECK (amount > 0),
+    change NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    method VARCHAR(30) NOT NULL,
+    reference TEXT NOT NULL DEFAULT '',
+    received_by UUID NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_payments_order_id ON order_payments (order_id, received_at);
+
+-- carry the single paid_amount of existing orders over as their first payment
+INSERT INTO order_payments (id, order_id, amount, change, method, reference, received_by, received_at)
+SELECT gen_random_uuid(), id, paid_amount, GREATEST(paid_amount - total_price, 0), 'cash', '', created_by, created_at
+FROM orders
+WHERE paid_amount > 0;
+
+UPDATE orders SET
+    paid_amount = LEAST(paid_amount, total_price),
+    change = GREATEST(paid_amount - total_price, 0);
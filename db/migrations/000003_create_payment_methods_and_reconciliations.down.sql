@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS reconciliation_lines;
+DROP TABLE IF EXISTS reconciliations;
+
+ALTER TABLE order_payments DROP COLUMN IF EXISTS payment_method_id;
+
+DROP TABLE IF EXISTS payment_methods;
@@ -0,0 +1,52 @@
+CREATE TABLE IF NOT EXISTS payment_methods (
+    id UUID PRIMARY KEY,
+    store_id UUID NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    code VARCHAR(30) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('cash', 'bank_transfer', 'ewallet', 'qris')),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_by UUID,
+    CONSTRAINT uq_payment_methods_store_code UNIQUE (store_id, code)
+);
+
+-- every existing store starts with a cash drawer
+INSERT INTO payment_methods (id, store_id, code, name, type)
+SELECT gen_random_uuid(), id, 'cash', 'Tunai', 'cash' FROM stores;
+
+ALTER TABLE order_payments ADD COLUMN payment_method_id UUID REFERENCES payment_methods(id);
+
+UPDATE order_payments p SET payment_method_id = pm.id
+FROM orders o, payment_methods pm
+WHERE o.id = p.order_id AND pm.store_id = o.store_id AND pm.code = p.method;
+
+ALTER TABLE order_payments ALTER COLUMN payment_method_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_order_payments_method_received ON order_payments (payment_method_id, received_at);
+
+CREATE TABLE IF NOT EXISTS reconciliations (
+    id UUID PRIMARY KEY,
+    store_id UUID NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    business_date DATE NOT NULL,
+    total_expected NUMERIC(14, 2) NOT NULL,
+    total_actual NUMERIC(14, 2) NOT NULL,
+    total_variance NUMERIC(14, 2) NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID NOT NULL,
+    CONSTRAINT uq_reconciliations_store_date UNIQUE (store_id, business_date)
+);
+
+CREATE TABLE IF NOT EXISTS reconciliation_lines (
+    id UUID PRIMARY KEY,
+    reconciliation_id UUID NOT NULL REFERENCES reconciliations(id) ON DELETE CASCADE,
+    payment_method_id UUID NOT NULL REFERENCES payment_methods(id),
+    method VARCHAR(30) NOT NULL,
+    method_name VARCHAR(100) NOT NULL,
+    payment_count INT NOT NULL DEFAULT 0,
+    expected NUMERIC(14, 2) NOT NULL,
+    actual NUMERIC(14, 2) NOT NULL,
+    variance NUMERIC(14, 2) NOT NULL
+);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS store_settings (
+    store_id UUID PRIMARY KEY REFERENCES stores(id) ON DELETE CASCADE,
+    qris_payload TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_by UUID
+);
@@ -0,0 +1,7 @@
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS uq_orders_store_invoice_number;
+
+DROP TABLE IF EXISTS invoice_sequences;
+
+ALTER TABLE store_settings
+    DROP COLUMN IF EXISTS invoice_reset,
+    DROP COLUMN IF EXISTS invoice_format;
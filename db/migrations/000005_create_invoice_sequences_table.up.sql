@@ -0,0 +1,27 @@
+ALTER TABLE store_settings
+    ADD COLUMN invoice_format VARCHAR(100) NOT NULL DEFAULT 'INV-{yyMMdd}-{seq:3}',
+    ADD COLUMN invoice_reset VARCHAR(10) NOT NULL DEFAULT 'daily' CHECK (invoice_reset IN ('daily', 'monthly', 'never'));
+
+CREATE TABLE IF NOT EXISTS invoice_sequences (
+    store_id UUID NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    period_key VARCHAR(8) NOT NULL,
+    last_value INT NOT NULL,
+    PRIMARY KEY (store_id, period_key)
+);
+
+-- continue after the numbers already handed out by the old COUNT(*) based generator; days are WIB
+-- (the default store time zone), not the session's, so the keys match PeriodKey
+INSERT INTO invoice_sequences (store_id, period_key, last_value)
+SELECT store_id, to_char(created_at AT TIME ZONE 'Asia/Jakarta', 'YYYYMMDD'), COUNT(*)
+FROM orders
+GROUP BY store_id, to_char(created_at AT TIME ZONE 'Asia/Jakarta', 'YYYYMMDD');
+
+-- the old generator could hand out the same number twice; keep the first, suffix the rest
+UPDATE orders o SET invoice_number = o.invoice_number || '-' || d.rn
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY store_id, invoice_number ORDER BY created_at, id) AS rn
+    FROM orders
+) d
+WHERE o.id = d.id AND d.rn > 1;
+
+ALTER TABLE orders ADD CONSTRAINT uq_orders_store_invoice_number UNIQUE (store_id, invoice_number);
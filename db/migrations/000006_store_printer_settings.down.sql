@@ -0,0 +1,3 @@
+ALTER TABLE store_settings
+    DROP COLUMN IF EXISTS receipt_paper,
+    DROP COLUMN IF EXISTS printer_address;
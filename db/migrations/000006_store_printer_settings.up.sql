@@ -0,0 +1,3 @@
+ALTER TABLE store_settings
+    ADD COLUMN printer_address VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN receipt_paper VARCHAR(2) NOT NULL DEFAULT '58' CHECK (receipt_paper IN ('58', '80'));
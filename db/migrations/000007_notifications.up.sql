@@ -0,0 +1,35 @@
+ALTER TABLE customers ADD COLUMN email VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS notification_templates (
+    id UUID PRIMARY KEY,
+    store_id UUID NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    event VARCHAR(50) NOT NULL,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('whatsapp', 'sms', 'email')),
+    subject VARCHAR(255) NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_by UUID,
+    UNIQUE (store_id, event, channel)
+);
+
+-- outbox: rows are written in the same transaction as the order change and sent by the dispatcher
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY,
+    store_id UUID NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    event VARCHAR(50) NOT NULL,
+    channel VARCHAR(20) NOT NULL,
+    recipient VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed')),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_due ON notifications (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_notifications_order_id ON notifications (order_id);
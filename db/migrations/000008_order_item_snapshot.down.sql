@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS order_item_price_overrides;
+
+ALTER TABLE order_items
+    DROP COLUMN IF EXISTS price_overridden,
+    DROP COLUMN IF EXISTS unit_price,
+    DROP COLUMN IF EXISTS unit,
+    DROP COLUMN IF EXISTS service_name,
+    DROP COLUMN IF EXISTS product_name;
@@ -0,0 +1,33 @@
+-- snapshot katalog pada saat order dibuat, supaya perubahan harga tidak mengubah order lama
+ALTER TABLE order_items
+    ADD COLUMN product_name VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN service_name VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN unit VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN unit_price NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN price_overridden BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- backfill from the current catalog; unit_price is derived from the stored line total
+UPDATE order_items oi SET
+    product_name = COALESCE(p.name, ''),
+    service_name = COALESCE(st.name, ''),
+    unit = COALESCE(ps.unit, ''),
+    unit_price = CASE WHEN oi.quantity > 0 THEN ROUND(oi.total_price / oi.quantity, 2) ELSE COALESCE(ps.price, 0) END
+FROM product_service ps
+LEFT JOIN products p ON p.id = ps.product_id
+LEFT JOIN service_types st ON st.id = ps.service_type_id
+WHERE ps.id = oi.product_service_id;
+
+-- no FK to order_items: items are deleted and re-inserted when an order is edited
+CREATE TABLE IF NOT EXISTS order_item_price_overrides (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    order_item_id UUID NOT NULL,
+    product_service_id UUID NOT NULL,
+    catalog_price NUMERIC(14, 2) NOT NULL,
+    override_price NUMERIC(14, 2) NOT NULL,
+    reason TEXT NOT NULL,
+    overridden_by UUID NOT NULL,
+    overridden_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_item_price_overrides_order_id ON order_item_price_overrides (order_id, overridden_at);
@@ -0,0 +1,7 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS rounding_adjustment;
+
+ALTER TABLE store_settings
+    DROP COLUMN IF EXISTS cash_rounding_mode,
+    DROP COLUMN IF EXISTS cash_rounding;
+
+-- the NUMERIC(14,2) column types are kept
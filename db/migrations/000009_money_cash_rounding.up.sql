@@ -0,0 +1,14 @@
+-- semua nominal disimpan sebagai NUMERIC(14,2) supaya cocok dengan money.Amount (sen)
+ALTER TABLE product_service ALTER COLUMN price TYPE NUMERIC(14, 2);
+ALTER TABLE order_items ALTER COLUMN total_price TYPE NUMERIC(14, 2);
+ALTER TABLE orders
+    ALTER COLUMN total_price TYPE NUMERIC(14, 2),
+    ALTER COLUMN paid_amount TYPE NUMERIC(14, 2),
+    ALTER COLUMN change TYPE NUMERIC(14, 2);
+
+-- pembulatan kas per toko; selisihnya disimpan di order sebagai baris tersendiri
+ALTER TABLE store_settings
+    ADD COLUMN cash_rounding NUMERIC(14, 2) NOT NULL DEFAULT 0 CHECK (cash_rounding >= 0),
+    ADD COLUMN cash_rounding_mode VARCHAR(10) NOT NULL DEFAULT 'half_up' CHECK (cash_rounding_mode IN ('half_up', 'down', 'up'));
+
+ALTER TABLE orders ADD COLUMN rounding_adjustment NUMERIC(14, 2) NOT NULL DEFAULT 0;
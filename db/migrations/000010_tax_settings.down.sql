@@ -0,0 +1,14 @@
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS tax_amount,
+    DROP COLUMN IF EXISTS tax_base,
+    DROP COLUMN IF EXISTS tax_inclusive,
+    DROP COLUMN IF EXISTS tax_rate,
+    DROP COLUMN IF EXISTS discount_amount,
+    DROP COLUMN IF EXISTS subtotal;
+
+ALTER TABLE order_items DROP COLUMN IF EXISTS tax_exempt;
+
+ALTER TABLE store_settings
+    DROP COLUMN IF EXISTS tax_exempt_service_types,
+    DROP COLUMN IF EXISTS tax_inclusive,
+    DROP COLUMN IF EXISTS tax_rate;
@@ -0,0 +1,27 @@
+-- PPN per toko; toko non-PKP cukup tax_rate = 0
+ALTER TABLE store_settings
+    ADD COLUMN tax_rate NUMERIC(5, 2) NOT NULL DEFAULT 0 CHECK (tax_rate >= 0 AND tax_rate <= 100),
+    ADD COLUMN tax_inclusive BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN tax_exempt_service_types TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE order_items ADD COLUMN tax_exempt BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- rincian total disimpan di order supaya struk dan laporan tidak menghitung ulang
+ALTER TABLE orders
+    ADD COLUMN subtotal NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN discount_amount NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN tax_rate NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN tax_inclusive BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN tax_base NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN tax_amount NUMERIC(14, 2) NOT NULL DEFAULT 0;
+
+-- existing orders had no tax: subtotal from the items, discount from the percentage
+UPDATE orders o SET
+    subtotal = s.subtotal,
+    discount_amount = ROUND(s.subtotal * o.discount / 100, 2)
+FROM (
+    SELECT order_id, SUM(total_price) AS subtotal
+    FROM order_items
+    GROUP BY order_id
+) s
+WHERE s.order_id = o.id;
@@ -0,0 +1,15 @@
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS discount_approved_by,
+    DROP COLUMN IF EXISTS promo_discount,
+    DROP COLUMN IF EXISTS promo_code,
+    DROP COLUMN IF EXISTS promo_id,
+    DROP COLUMN IF EXISTS discount_fixed;
+
+ALTER TABLE order_items
+    DROP COLUMN IF EXISTS discount_amount,
+    DROP COLUMN IF EXISTS discount,
+    DROP COLUMN IF EXISTS service_type_id;
+
+ALTER TABLE store_settings DROP COLUMN IF EXISTS discount_limits;
+
+DROP TABLE IF EXISTS promos;
@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS promos (
+    id UUID PRIMARY KEY,
+    store_id UUID NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    code VARCHAR(50) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    type VARCHAR(20) NOT NULL CHECK (type IN ('percentage', 'fixed')),
+    percent NUMERIC(5, 2) NOT NULL DEFAULT 0 CHECK (percent >= 0 AND percent <= 100),
+    amount NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    max_discount NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    min_spend NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    service_type_ids TEXT[] NOT NULL DEFAULT '{}',
+    starts_at TIMESTAMPTZ,
+    ends_at TIMESTAMPTZ,
+    usage_limit INT NOT NULL DEFAULT 0,
+    usage_count INT NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_by UUID,
+    CONSTRAINT uq_promos_store_code UNIQUE (store_id, code)
+);
+
+-- batas diskon manual per role, misal {"worker": 10}; role yang tidak ada di sini tanpa batas
+ALTER TABLE store_settings ADD COLUMN discount_limits JSONB NOT NULL DEFAULT '{}';
+
+ALTER TABLE order_items
+    ADD COLUMN service_type_id UUID,
+    ADD COLUMN discount NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN discount_amount NUMERIC(14, 2) NOT NULL DEFAULT 0;
+
+UPDATE order_items oi SET service_type_id = ps.service_type_id
+FROM product_service ps
+WHERE ps.id = oi.product_service_id;
+
+ALTER TABLE orders
+    ADD COLUMN discount_fixed NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN promo_id UUID REFERENCES promos(id) ON DELETE SET NULL,
+    ADD COLUMN promo_code VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN promo_discount NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN discount_approved_by UUID;
@@ -0,0 +1,22 @@
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS points_awarded,
+    DROP COLUMN IF EXISTS points_earned,
+    DROP COLUMN IF EXISTS points_discount,
+    DROP COLUMN IF EXISTS points_redeemed,
+    DROP COLUMN IF EXISTS tier_discount_amount,
+    DROP COLUMN IF EXISTS tier_discount,
+    DROP COLUMN IF EXISTS tier_name;
+
+DROP TABLE IF EXISTS customer_points;
+
+ALTER TABLE customers
+    DROP COLUMN IF EXISTS tier,
+    DROP COLUMN IF EXISTS lifetime_spend,
+    DROP COLUMN IF EXISTS points_balance;
+
+ALTER TABLE store_settings
+    DROP COLUMN IF EXISTS loyalty_tiers,
+    DROP COLUMN IF EXISTS points_expiry_days,
+    DROP COLUMN IF EXISTS point_value,
+    DROP COLUMN IF EXISTS points_earn_spend,
+    DROP COLUMN IF EXISTS loyalty_enabled;
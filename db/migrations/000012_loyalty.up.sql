@@ -0,0 +1,38 @@
+-- aturan loyalti per toko; tier disimpan sebagai [{"name": "silver", "min_spend": 1000000, "discount": 5}, ...]
+ALTER TABLE store_settings
+    ADD COLUMN loyalty_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN points_earn_spend NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN point_value NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN points_expiry_days INT NOT NULL DEFAULT 0,
+    ADD COLUMN loyalty_tiers JSONB NOT NULL DEFAULT '[]';
+
+ALTER TABLE customers
+    ADD COLUMN points_balance INT NOT NULL DEFAULT 0 CHECK (points_balance >= 0),
+    ADD COLUMN lifetime_spend NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN tier VARCHAR(30) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS customer_points (
+    id UUID PRIMARY KEY,
+    customer_id UUID NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
+    store_id UUID NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('earn', 'redeem', 'expire', 'reversal')),
+    points INT NOT NULL,
+    remaining INT NOT NULL DEFAULT 0, -- sisa poin yang belum terpakai/kedaluwarsa, hanya untuk mutasi positif
+    expires_at TIMESTAMPTZ,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID
+);
+
+CREATE INDEX IF NOT EXISTS idx_customer_points_customer ON customer_points (customer_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_customer_points_open ON customer_points (customer_id, expires_at) WHERE remaining > 0;
+
+ALTER TABLE orders
+    ADD COLUMN tier_name VARCHAR(30) NOT NULL DEFAULT '',
+    ADD COLUMN tier_discount NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN tier_discount_amount NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN points_redeemed INT NOT NULL DEFAULT 0,
+    ADD COLUMN points_discount NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN points_earned INT NOT NULL DEFAULT 0,
+    ADD COLUMN points_awarded BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,12 @@
+ALTER TABLE order_payments DROP COLUMN IF EXISTS wallet_id;
+
+DROP TABLE IF EXISTS prepaid_entries;
+DROP TABLE IF EXISTS prepaid_wallets;
+DROP TABLE IF EXISTS prepaid_packages;
+
+-- pembayaran lama tetap menunjuk ke metode prepaid, jadi metodenya dijadikan tipe cash nonaktif
+UPDATE payment_methods SET type = 'cash', is_active = FALSE WHERE type = 'prepaid';
+
+ALTER TABLE payment_methods DROP CONSTRAINT IF EXISTS payment_methods_type_check;
+ALTER TABLE payment_methods ADD CONSTRAINT payment_methods_type_check
+    CHECK (type IN ('cash', 'bank_transfer', 'ewallet', 'qris'));
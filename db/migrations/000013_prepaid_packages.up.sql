@@ -0,0 +1,73 @@
+CREATE TABLE IF NOT EXISTS prepaid_packages (
+    id UUID PRIMARY KEY,
+    store_id UUID NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('quota', 'balance')),
+    unit VARCHAR(20) NOT NULL DEFAULT '',
+    quota NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    balance NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    price NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    validity_days INT NOT NULL DEFAULT 0, -- 0 = tidak kedaluwarsa
+    product_service_ids TEXT[] NOT NULL DEFAULT '{}', -- kosong = semua product service
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_by UUID
+);
+
+-- wallet menyalin syarat paket saat dibeli
+CREATE TABLE IF NOT EXISTS prepaid_wallets (
+    id UUID PRIMARY KEY,
+    customer_id UUID NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
+    store_id UUID NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    package_id UUID REFERENCES prepaid_packages(id) ON DELETE SET NULL,
+    package_name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('quota', 'balance')),
+    unit VARCHAR(20) NOT NULL DEFAULT '',
+    product_service_ids TEXT[] NOT NULL DEFAULT '{}',
+    quota NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    quota_remaining NUMERIC(10, 3) NOT NULL DEFAULT 0 CHECK (quota_remaining >= 0),
+    balance NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    balance_remaining NUMERIC(14, 2) NOT NULL DEFAULT 0 CHECK (balance_remaining >= 0),
+    price NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    payment_method_id UUID NOT NULL REFERENCES payment_methods(id),
+    method VARCHAR(30) NOT NULL,
+    reference VARCHAR(100) NOT NULL DEFAULT '',
+    purchased_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ,
+    created_by UUID
+);
+
+CREATE INDEX IF NOT EXISTS idx_prepaid_wallets_customer ON prepaid_wallets (customer_id, purchased_at);
+CREATE INDEX IF NOT EXISTS idx_prepaid_wallets_store_purchased ON prepaid_wallets (store_id, purchased_at);
+
+CREATE TABLE IF NOT EXISTS prepaid_entries (
+    id UUID PRIMARY KEY,
+    wallet_id UUID NOT NULL REFERENCES prepaid_wallets(id) ON DELETE CASCADE,
+    customer_id UUID NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
+    store_id UUID NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    payment_id UUID,
+    product_service_id UUID,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('purchase', 'usage', 'refund', 'expire')),
+    quantity NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    amount NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID
+);
+
+CREATE INDEX IF NOT EXISTS idx_prepaid_entries_customer ON prepaid_entries (customer_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_prepaid_entries_order ON prepaid_entries (order_id);
+
+-- pembayaran dari wallet memakai metode "prepaid" milik toko, tidak ikut rekonsiliasi kas
+ALTER TABLE payment_methods DROP CONSTRAINT IF EXISTS payment_methods_type_check;
+ALTER TABLE payment_methods ADD CONSTRAINT payment_methods_type_check
+    CHECK (type IN ('cash', 'bank_transfer', 'ewallet', 'qris', 'prepaid'));
+
+INSERT INTO payment_methods (id, store_id, code, name, type)
+SELECT gen_random_uuid(), id, 'prepaid', 'Paket Prabayar', 'prepaid' FROM stores
+ON CONFLICT (store_id, code) DO NOTHING;
+
+ALTER TABLE order_payments ADD COLUMN wallet_id UUID REFERENCES prepaid_wallets(id) ON DELETE SET NULL;
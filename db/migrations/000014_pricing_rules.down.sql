@@ -0,0 +1,7 @@
+ALTER TABLE order_items DROP COLUMN IF EXISTS charged_quantity;
+
+ALTER TABLE product_service
+    DROP COLUMN IF EXISTS price_tiers,
+    DROP COLUMN IF EXISTS quantity_step,
+    DROP COLUMN IF EXISTS min_charge,
+    DROP COLUMN IF EXISTS min_quantity;
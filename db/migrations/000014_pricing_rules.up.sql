@@ -0,0 +1,11 @@
+-- aturan harga per product service; tier disimpan sebagai [{"min_quantity": 10, "price": 6000}, ...]
+ALTER TABLE product_service
+    ADD COLUMN min_quantity NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN min_charge NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN quantity_step NUMERIC(10, 3) NOT NULL DEFAULT 0,
+    ADD COLUMN price_tiers JSONB NOT NULL DEFAULT '[]';
+
+-- kuantitas yang ditagih setelah pembulatan dan minimum; item lama ditagih sesuai kuantitasnya
+ALTER TABLE order_items ADD COLUMN charged_quantity NUMERIC(10, 3);
+UPDATE order_items SET charged_quantity = quantity;
+ALTER TABLE order_items ALTER COLUMN charged_quantity SET NOT NULL;
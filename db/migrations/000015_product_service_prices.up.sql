@@ -0,0 +1,19 @@
+-- versi harga per product service; store_id NULL = berlaku di semua toko
+CREATE TABLE IF NOT EXISTS product_service_prices (
+    id UUID PRIMARY KEY,
+    product_service_id UUID NOT NULL REFERENCES product_service(id) ON DELETE CASCADE,
+    store_id UUID REFERENCES stores(id) ON DELETE CASCADE,
+    price NUMERIC(14, 2) NOT NULL,
+    effective_from TIMESTAMPTZ NOT NULL,
+    cancelled_at TIMESTAMPTZ,
+    cancelled_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_service_prices_lookup
+    ON product_service_prices (product_service_id, store_id, effective_from DESC);
+
+-- harga katalog saat ini menjadi versi pertama riwayat harga
+INSERT INTO product_service_prices (id, product_service_id, price, effective_from, created_at)
+SELECT gen_random_uuid(), id, price, created_at, created_at FROM product_service;
@@ -0,0 +1,4 @@
+ALTER TABLE product_service_prices DROP COLUMN IF EXISTS adjustment_id;
+
+DROP TABLE IF EXISTS price_adjustment_lines;
+DROP TABLE IF EXISTS price_adjustments;
@@ -0,0 +1,29 @@
+-- audit penyesuaian harga massal; harga barunya ada di product_service_prices
+CREATE TABLE IF NOT EXISTS price_adjustments (
+    id UUID PRIMARY KEY,
+    store_id UUID REFERENCES stores(id) ON DELETE CASCADE,
+    service_type_id UUID,
+    product_id UUID,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('percent', 'fixed')),
+    percent NUMERIC(7, 2) NOT NULL DEFAULT 0,
+    amount NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    round_to NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    rounding_mode VARCHAR(10) NOT NULL DEFAULT 'half_up',
+    effective_from TIMESTAMPTZ NOT NULL,
+    item_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID
+);
+
+CREATE TABLE IF NOT EXISTS price_adjustment_lines (
+    id UUID PRIMARY KEY,
+    adjustment_id UUID NOT NULL REFERENCES price_adjustments(id) ON DELETE CASCADE,
+    product_service_id UUID NOT NULL REFERENCES product_service(id) ON DELETE CASCADE,
+    product_name VARCHAR(100) NOT NULL DEFAULT '',
+    service_type_name VARCHAR(100) NOT NULL DEFAULT '',
+    unit VARCHAR(20) NOT NULL DEFAULT '',
+    old_price NUMERIC(14, 2) NOT NULL,
+    new_price NUMERIC(14, 2) NOT NULL
+);
+
+ALTER TABLE product_service_prices ADD COLUMN adjustment_id UUID REFERENCES price_adjustments(id) ON DELETE SET NULL;
@@ -0,0 +1,6 @@
+-- salinan jenis layanan per toko tidak digabung kembali
+DROP INDEX IF EXISTS idx_product_service_store;
+DROP INDEX IF EXISTS idx_service_types_store;
+
+ALTER TABLE product_service DROP COLUMN IF EXISTS store_id;
+ALTER TABLE service_types DROP COLUMN IF EXISTS store_id;
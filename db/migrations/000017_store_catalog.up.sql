@@ -0,0 +1,63 @@
+-- katalog per toko: jenis layanan dan product service punya store_id.
+-- product service ikut toko produknya.
+ALTER TABLE service_types ADD COLUMN store_id UUID REFERENCES stores(id) ON DELETE CASCADE;
+ALTER TABLE product_service ADD COLUMN store_id UUID REFERENCES stores(id) ON DELETE CASCADE;
+
+UPDATE product_service ps SET store_id = p.store_id
+FROM products p
+WHERE p.id = ps.product_id;
+
+-- jenis layanan lama yang global disalin ke setiap toko. Toko pertama memakai baris aslinya,
+-- toko lain mendapat salinan dan semua referensi di toko itu dipindah ke salinannya.
+CREATE TEMP TABLE service_type_map AS
+SELECT st.id AS old_id, s.id AS store_id,
+    CASE WHEN s.id = first.id THEN st.id ELSE gen_random_uuid() END AS new_id
+FROM service_types st
+CROSS JOIN stores s
+CROSS JOIN (SELECT id FROM stores ORDER BY created_at, id LIMIT 1) first;
+
+INSERT INTO service_types (id, name, store_id, is_active, created_at, created_by, updated_at, updated_by)
+SELECT m.new_id, st.name, m.store_id, st.is_active, st.created_at, st.created_by, st.updated_at, st.updated_by
+FROM service_type_map m
+JOIN service_types st ON st.id = m.old_id
+WHERE m.new_id <> m.old_id;
+
+UPDATE service_types st SET store_id = m.store_id
+FROM service_type_map m
+WHERE m.old_id = st.id AND m.new_id = st.id;
+
+UPDATE product_service ps SET service_type_id = m.new_id
+FROM service_type_map m
+WHERE m.old_id = ps.service_type_id AND m.store_id = ps.store_id;
+
+UPDATE order_items oi SET service_type_id = m.new_id
+FROM orders o, service_type_map m
+WHERE o.id = oi.order_id AND m.old_id = oi.service_type_id AND m.store_id = o.store_id;
+
+UPDATE promos p SET service_type_ids = ARRAY(
+    SELECT COALESCE(m.new_id::text, t.id)
+    FROM unnest(p.service_type_ids) AS t(id)
+    LEFT JOIN service_type_map m ON m.old_id::text = t.id AND m.store_id = p.store_id
+)
+WHERE cardinality(p.service_type_ids) > 0;
+
+UPDATE store_settings ss SET tax_exempt_service_types = ARRAY(
+    SELECT COALESCE(m.new_id::text, t.id)
+    FROM unnest(ss.tax_exempt_service_types) AS t(id)
+    LEFT JOIN service_type_map m ON m.old_id::text = t.id AND m.store_id = ss.store_id
+)
+WHERE cardinality(ss.tax_exempt_service_types) > 0;
+
+DROP TABLE service_type_map;
+
+ALTER TABLE service_types ALTER COLUMN store_id SET NOT NULL;
+ALTER TABLE product_service ALTER COLUMN store_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_service_types_store ON service_types (store_id);
+CREATE INDEX IF NOT EXISTS idx_product_service_store ON product_service (store_id);
+
+-- katalog yang dibuat lewat API sebelumnya tersimpan tidak aktif karena is_active tidak pernah diisi;
+-- belum ada cara menonaktifkan lewat API, jadi semuanya memang aktif.
+UPDATE products SET is_active = TRUE WHERE NOT is_active;
+UPDATE service_types SET is_active = TRUE WHERE NOT is_active;
+UPDATE product_service SET is_active = TRUE WHERE NOT is_active;
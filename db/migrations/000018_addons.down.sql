@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS order_item_addons;
+
+ALTER TABLE order_items DROP COLUMN IF EXISTS addon_amount;
+
+DROP TABLE IF EXISTS addons;
@@ -0,0 +1,34 @@
+-- katalog add-on per toko, misal parfum premium, hanger, plastik, atau perawatan noda
+CREATE TABLE IF NOT EXISTS addons (
+    id UUID PRIMARY KEY,
+    store_id UUID NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    service_type_id UUID REFERENCES service_types(id) ON DELETE CASCADE, -- NULL = semua jenis layanan
+    name VARCHAR(100) NOT NULL,
+    price_type VARCHAR(20) NOT NULL CHECK (price_type IN ('fixed', 'per_unit')),
+    price NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_by UUID
+);
+
+CREATE INDEX IF NOT EXISTS idx_addons_store ON addons (store_id);
+
+ALTER TABLE order_items ADD COLUMN addon_amount NUMERIC(14, 2) NOT NULL DEFAULT 0;
+
+-- snapshot add-on per item; ikut terhapus saat item order ditulis ulang
+CREATE TABLE IF NOT EXISTS order_item_addons (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    order_item_id UUID NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    addon_id UUID REFERENCES addons(id) ON DELETE SET NULL,
+    name VARCHAR(100) NOT NULL,
+    price_type VARCHAR(20) NOT NULL,
+    unit_price NUMERIC(14, 2) NOT NULL,
+    quantity NUMERIC(10, 3) NOT NULL,
+    amount NUMERIC(14, 2) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_item_addons_item ON order_item_addons (order_item_id);
+CREATE INDEX IF NOT EXISTS idx_order_item_addons_order ON order_item_addons (order_id);
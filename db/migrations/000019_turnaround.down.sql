@@ -0,0 +1,13 @@
+ALTER TABLE order_items
+    DROP COLUMN IF EXISTS turnaround_hours,
+    DROP COLUMN IF EXISTS express_surcharge,
+    DROP COLUMN IF EXISTS express_rate,
+    DROP COLUMN IF EXISTS express_tier;
+
+ALTER TABLE store_settings
+    DROP COLUMN IF EXISTS holidays,
+    DROP COLUMN IF EXISTS opening_hours;
+
+ALTER TABLE service_types
+    DROP COLUMN IF EXISTS express_tiers,
+    DROP COLUMN IF EXISTS turnaround_hours;
@@ -0,0 +1,15 @@
+-- lama pengerjaan standar dan tier express per jenis layanan, misal [{"name": "kilat", "hours": 6, "surcharge": 50}]
+ALTER TABLE service_types
+    ADD COLUMN turnaround_hours INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN express_tiers JSONB NOT NULL DEFAULT '[]';
+
+-- jam buka per hari ([{"weekday": 1, "open": "08:00", "close": "20:00"}]) dan tanggal libur toko
+ALTER TABLE store_settings
+    ADD COLUMN opening_hours JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN holidays TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE order_items
+    ADD COLUMN express_tier VARCHAR(30) NOT NULL DEFAULT '',
+    ADD COLUMN express_rate NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN express_surcharge NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN turnaround_hours INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,8 @@
+ALTER TABLE store_settings
+    DROP COLUMN IF EXISTS pickup_lead_hours,
+    DROP COLUMN IF EXISTS receipt_footer,
+    DROP COLUMN IF EXISTS receipt_header,
+    DROP COLUMN IF EXISTS decimal_separator,
+    DROP COLUMN IF EXISTS thousands_separator,
+    DROP COLUMN IF EXISTS currency_symbol,
+    DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,9 @@
+-- zona waktu, format mata uang, teks struk dan lead time ambil per toko
+ALTER TABLE store_settings
+    ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Jakarta',
+    ADD COLUMN currency_symbol VARCHAR(5) NOT NULL DEFAULT 'Rp',
+    ADD COLUMN thousands_separator VARCHAR(1) NOT NULL DEFAULT '.',
+    ADD COLUMN decimal_separator VARCHAR(1) NOT NULL DEFAULT ',',
+    ADD COLUMN receipt_header TEXT NOT NULL DEFAULT '',
+    ADD COLUMN receipt_footer TEXT NOT NULL DEFAULT '',
+    ADD COLUMN pickup_lead_hours INTEGER NOT NULL DEFAULT 0;
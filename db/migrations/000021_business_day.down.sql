@@ -0,0 +1 @@
+ALTER TABLE store_settings DROP COLUMN IF EXISTS day_cutoff;
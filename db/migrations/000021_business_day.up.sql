@@ -0,0 +1,2 @@
+-- jam pergantian hari bisnis toko, dipakai untuk nomor invoice, laporan harian dan rekonsiliasi
+ALTER TABLE store_settings ADD COLUMN day_cutoff VARCHAR(5) NOT NULL DEFAULT '00:00';
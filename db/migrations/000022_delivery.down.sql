@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS delivery_jobs;
+
+ALTER TABLE store_settings DROP COLUMN IF EXISTS delivery_rates;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS delivery_fee;
@@ -0,0 +1,32 @@
+-- ongkos antar-jemput dijumlahkan dari job order dan masuk ke total order
+ALTER TABLE orders ADD COLUMN delivery_fee NUMERIC(14, 2) NOT NULL DEFAULT 0;
+
+-- tabel ongkos per jarak, misal [{"max_distance": 3, "fee": 10000}, {"max_distance": 7, "fee": 15000}]
+ALTER TABLE store_settings ADD COLUMN delivery_rates JSONB NOT NULL DEFAULT '[]';
+
+-- job antar-jemput: jemput cucian dari pelanggan atau antar kembali, dikerjakan kurir toko
+CREATE TABLE IF NOT EXISTS delivery_jobs (
+    id UUID PRIMARY KEY,
+    store_id UUID NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('pickup', 'delivery')),
+    address TEXT NOT NULL,
+    distance_km NUMERIC(8, 2) NOT NULL DEFAULT 0,
+    window_start TIMESTAMPTZ NOT NULL,
+    window_end TIMESTAMPTZ NOT NULL,
+    fee NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    courier_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled',
+    notes TEXT NOT NULL DEFAULT '',
+    fail_reason TEXT NOT NULL DEFAULT '',
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by UUID,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_by UUID,
+    CHECK (window_end > window_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_delivery_jobs_order ON delivery_jobs (order_id);
+CREATE INDEX IF NOT EXISTS idx_delivery_jobs_store_window ON delivery_jobs (store_id, window_start);
+CREATE INDEX IF NOT EXISTS idx_delivery_jobs_courier_window ON delivery_jobs (courier_id, window_start);
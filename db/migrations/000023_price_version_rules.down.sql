@@ -0,0 +1,9 @@
+ALTER TABLE price_adjustment_lines
+    DROP COLUMN IF EXISTS new_price_tiers,
+    DROP COLUMN IF EXISTS old_price_tiers,
+    DROP COLUMN IF EXISTS new_min_charge,
+    DROP COLUMN IF EXISTS old_min_charge;
+
+ALTER TABLE product_service_prices
+    DROP COLUMN IF EXISTS price_tiers,
+    DROP COLUMN IF EXISTS min_charge;
@@ -0,0 +1,10 @@
+-- versi harga bisa membawa tagihan minimum dan tier sendiri (dari penyesuaian massal); NULL = ikut katalog
+ALTER TABLE product_service_prices
+    ADD COLUMN min_charge NUMERIC(14, 2),
+    ADD COLUMN price_tiers JSONB;
+
+ALTER TABLE price_adjustment_lines
+    ADD COLUMN old_min_charge NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN new_min_charge NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN old_price_tiers JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN new_price_tiers JSONB NOT NULL DEFAULT '[]';
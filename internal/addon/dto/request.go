@@ -0,0 +1,26 @@
+package dto
+
+import "sumunar-pos-core/pkg/money"
+
+type AddonRequest struct {
+	StoreID       string       `json:"store_id" validate:"required"`
+	ServiceTypeID string       `json:"service_type_id" validate:"omitempty,uuid"` // kosong = semua jenis layanan
+	Name          string       `json:"name" validate:"required,max=100"`
+	PriceType     string       `json:"price_type" validate:"required,oneof=fixed per_unit"`
+	Price         money.Amount `json:"price" validate:"gte=0"`
+	IsActive      *bool        `json:"is_active"`
+}
+
+// SalesRequest is the query string of the add-on sales report. Dates are YYYY-MM-DD, both inclusive.
+type SalesRequest struct {
+	StoreID string `query:"store_id" validate:"required"`
+	From    string `query:"from" validate:"required"`
+	To      string `query:"to" validate:"required"`
+}
+
+// ListRequest is the query string of the list endpoint.
+type ListRequest struct {
+	StoreID string `query:"store_id" validate:"omitempty,uuid"`
+	Limit   int    `query:"limit"`
+	Offset  int    `query:"offset"`
+}
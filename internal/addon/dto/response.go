@@ -0,0 +1,29 @@
+package dto
+
+import "sumunar-pos-core/pkg/money"
+
+type AddonResponse struct {
+	ID            string       `json:"id"`
+	StoreID       string       `json:"store_id"`
+	ServiceTypeID string       `json:"service_type_id,omitempty"`
+	Name          string       `json:"name"`
+	PriceType     string       `json:"price_type"`
+	Price         money.Amount `json:"price"`
+	IsActive      bool         `json:"is_active"`
+}
+
+type SalesResponse struct {
+	StoreID string              `json:"store_id"`
+	From    string              `json:"from"`
+	To      string              `json:"to"`
+	Total   money.Amount        `json:"total"`
+	Lines   []SalesLineResponse `json:"lines"`
+}
+
+type SalesLineResponse struct {
+	AddonID    string       `json:"addon_id"`
+	Name       string       `json:"name"`
+	Quantity   float64      `json:"quantity"`
+	Amount     money.Amount `json:"amount"`
+	OrderCount int          `json:"order_count"`
+}
@@ -0,0 +1,9 @@
+package addon
+
+import "errors"
+
+var (
+	ErrAddonNotFound       = errors.New("add-on not found")
+	ErrServiceTypeNotFound = errors.New("service type not found in this store")
+	ErrInvalidPeriod       = errors.New("from and to must be YYYY-MM-DD dates, from not after to")
+)
@@ -0,0 +1,135 @@
+package addon
+
+import (
+	"errors"
+	"net/http"
+
+	"sumunar-pos-core/internal/addon/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service AddonService
+}
+
+func NewHandler(service AddonService) *Handler {
+	return &Handler{service}
+}
+
+func (h *Handler) Create(c echo.Context) error {
+	var req dto.AddonRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Get("user_id").(string)
+	a, err := h.service.Create(c.Request().Context(), &req, userID)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, ToAddonResponse(a))
+}
+
+func (h *Handler) FindByID(c echo.Context) error {
+	a, err := h.service.FindByID(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToAddonResponse(a))
+}
+
+func (h *Handler) FindAll(c echo.Context) error {
+	var req dto.ListRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	storeID, limit, offset := req.StoreID, req.Limit, req.Offset
+	if limit <= 0 {
+		limit = 50
+	}
+
+	addons, total, err := h.service.FindAll(c.Request().Context(), storeID, limit, offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data":   ToAddonListResponse(addons),
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+func (h *Handler) Update(c echo.Context) error {
+	var req dto.AddonRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Get("user_id").(string)
+	a, err := h.service.Update(c.Request().Context(), c.Param("id"), &req, userID)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToAddonResponse(a))
+}
+
+func (h *Handler) Delete(c echo.Context) error {
+	if err := h.service.Delete(c.Request().Context(), c.Param("id")); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Sales godoc
+// @Summary Add-on sales report
+// @Tags addons
+// @Produce json
+// @Param store_id query string true "Store ID"
+// @Param from query string true "YYYY-MM-DD"
+// @Param to query string true "YYYY-MM-DD"
+// @Success 200 {object} dto.SalesResponse
+// @Security BearerAuth
+// @Router /addons/sales [get]
+func (h *Handler) Sales(c echo.Context) error {
+	var req dto.SalesRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	res, err := h.service.Sales(c.Request().Context(), &req)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrAddonNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrServiceTypeNotFound), errors.Is(err, ErrInvalidPeriod):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,78 @@
+package addon
+
+import (
+	"time"
+
+	"sumunar-pos-core/internal/addon/dto"
+	"sumunar-pos-core/internal/base"
+
+	"github.com/google/uuid"
+)
+
+func ToAddonModel(req *dto.AddonRequest, createdBy string) *Addon {
+	now := time.Now()
+	a := &Addon{
+		ID:      uuid.New().String(),
+		StoreID: req.StoreID,
+		BaseModel: base.BaseModel{
+			IsActive:  true,
+			CreatedAt: now,
+			CreatedBy: createdBy,
+			UpdatedAt: now,
+			UpdatedBy: createdBy,
+		},
+	}
+	applyRequest(a, req)
+	return a
+}
+
+// applyRequest copies the editable fields of req onto a; store_id is not touched.
+func applyRequest(a *Addon, req *dto.AddonRequest) {
+	a.ServiceTypeID = req.ServiceTypeID
+	a.Name = req.Name
+	a.PriceType = req.PriceType
+	a.Price = req.Price
+	if req.IsActive != nil {
+		a.IsActive = *req.IsActive
+	}
+}
+
+func ToAddonResponse(a *Addon) *dto.AddonResponse {
+	return &dto.AddonResponse{
+		ID:            a.ID,
+		StoreID:       a.StoreID,
+		ServiceTypeID: a.ServiceTypeID,
+		Name:          a.Name,
+		PriceType:     a.PriceType,
+		Price:         a.Price,
+		IsActive:      a.IsActive,
+	}
+}
+
+func ToAddonListResponse(addons []*Addon) []*dto.AddonResponse {
+	res := make([]*dto.AddonResponse, 0, len(addons))
+	for _, a := range addons {
+		res = append(res, ToAddonResponse(a))
+	}
+	return res
+}
+
+func ToSalesResponse(req *dto.SalesRequest, lines []*SalesLine) *dto.SalesResponse {
+	res := &dto.SalesResponse{
+		StoreID: req.StoreID,
+		From:    req.From,
+		To:      req.To,
+		Lines:   make([]dto.SalesLineResponse, 0, len(lines)),
+	}
+	for _, l := range lines {
+		res.Total += l.Amount
+		res.Lines = append(res.Lines, dto.SalesLineResponse{
+			AddonID:    l.AddonID,
+			Name:       l.Name,
+			Quantity:   l.Quantity,
+			Amount:     l.Amount,
+			OrderCount: l.OrderCount,
+		})
+	}
+	return res
+}
@@ -0,0 +1,48 @@
+package addon
+
+import (
+	"sumunar-pos-core/internal/base"
+	"sumunar-pos-core/pkg/money"
+)
+
+// Cara hitung harga add-on.
+const (
+	PriceFixed   = "fixed"    // harga x jumlah add-on, misal 3 hanger
+	PricePerUnit = "per_unit" // harga x kuantitas yang ditagih item, misal parfum premium per kg
+)
+
+// Addon is an extra a customer can ask for on an order item, e.g. premium perfume or stain treatment.
+type Addon struct {
+	ID            string
+	StoreID       string
+	ServiceTypeID string // kosong = bisa untuk semua jenis layanan
+	Name          string
+	PriceType     string // fixed, per_unit
+	Price         money.Amount
+	base.BaseModel
+}
+
+// AppliesTo reports whether the add-on can be put on an item of the service type.
+func (a *Addon) AppliesTo(serviceTypeID string) bool {
+	return a.ServiceTypeID == "" || a.ServiceTypeID == serviceTypeID
+}
+
+// Charge returns the billed quantity and amount on an item with the given charged quantity.
+// quantity is the requested count of a fixed add-on, 0 means one.
+func (a *Addon) Charge(quantity, itemQuantity float64) (float64, money.Amount) {
+	if a.PriceType == PricePerUnit {
+		quantity = itemQuantity
+	} else if quantity <= 0 {
+		quantity = 1
+	}
+	return quantity, a.Price.Mul(quantity, money.RoundHalfUp)
+}
+
+// SalesLine is one add-on in the sales report, from the snapshots on non-cancelled orders.
+type SalesLine struct {
+	AddonID    string
+	Name       string
+	Quantity   float64
+	Amount     money.Amount
+	OrderCount int
+}
@@ -0,0 +1,161 @@
+package addon
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"sumunar-pos-core/pkg/db"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type AddonRepository interface {
+	Create(ctx context.Context, a *Addon) error
+	FindByID(ctx context.Context, id string) (*Addon, error)
+	FindAll(ctx context.Context, storeID string, limit, offset int) ([]*Addon, int, error)
+	Update(ctx context.Context, a *Addon) error
+	Delete(ctx context.Context, id string) error
+	Sales(ctx context.Context, storeID string, from, to time.Time) ([]*SalesLine, error)
+}
+
+type addonRepo struct {
+	db db.DBTX
+}
+
+func NewAddonRepository(db db.DBTX) AddonRepository {
+	return &addonRepo{db}
+}
+
+const addonColumns = `id, store_id, COALESCE(service_type_id::text, ''), name, price_type, price,
+	is_active, created_at, COALESCE(created_by::text, ''), updated_at, COALESCE(updated_by::text, '')`
+
+func (r *addonRepo) Create(ctx context.Context, a *Addon) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO addons (id, store_id, service_type_id, name, price_type, price, is_active, created_at, created_by, updated_at, updated_by)
+		VALUES ($1, $2, NULLIF($3, '')::uuid, $4, $5, $6, $7, $8, NULLIF($9, '')::uuid, $8, NULLIF($9, '')::uuid)
+	`,
+		a.ID,
+		a.StoreID,
+		a.ServiceTypeID,
+		a.Name,
+		a.PriceType,
+		a.Price,
+		a.IsActive,
+		a.CreatedAt,
+		a.CreatedBy,
+	)
+	return err
+}
+
+func scanAddon(row pgx.Row) (*Addon, error) {
+	var a Addon
+	err := row.Scan(
+		&a.ID,
+		&a.StoreID,
+		&a.ServiceTypeID,
+		&a.Name,
+		&a.PriceType,
+		&a.Price,
+		&a.IsActive,
+		&a.CreatedAt,
+		&a.CreatedBy,
+		&a.UpdatedAt,
+		&a.UpdatedBy,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAddonNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *addonRepo) FindByID(ctx context.Context, id string) (*Addon, error) {
+	return scanAddon(r.db.QueryRow(ctx, `SELECT `+addonColumns+` FROM addons WHERE id = $1`, id))
+}
+
+// FindAll lists add-ons, optionally limited to one store when storeID is not empty.
+func (r *addonRepo) FindAll(ctx context.Context, storeID string, limit, offset int) ([]*Addon, int, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+addonColumns+`
+		FROM addons
+		WHERE ($1::uuid IS NULL OR store_id = $1)
+		ORDER BY name
+		LIMIT $2 OFFSET $3
+	`, db.NullID(storeID), limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	addons := []*Addon{}
+	for rows.Next() {
+		a, err := scanAddon(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		addons = append(addons, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	err = r.db.QueryRow(ctx, `SELECT COUNT(*) FROM addons WHERE ($1::uuid IS NULL OR store_id = $1)`, db.NullID(storeID)).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+	return addons, total, nil
+}
+
+func (r *addonRepo) Update(ctx context.Context, a *Addon) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE addons SET service_type_id = NULLIF($1, '')::uuid, name = $2, price_type = $3, price = $4, is_active = $5,
+		updated_at = $6, updated_by = NULLIF($7, '')::uuid
+		WHERE id = $8
+	`,
+		a.ServiceTypeID,
+		a.Name,
+		a.PriceType,
+		a.Price,
+		a.IsActive,
+		a.UpdatedAt,
+		a.UpdatedBy,
+		a.ID,
+	)
+	return err
+}
+
+// Delete removes the add-on from the catalog; orders keep their snapshot.
+func (r *addonRepo) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM addons WHERE id = $1`, id)
+	return err
+}
+
+// Sales sums the add-ons on the store's non-cancelled orders created in [from, to).
+func (r *addonRepo) Sales(ctx context.Context, storeID string, from, to time.Time) ([]*SalesLine, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT COALESCE(a.addon_id::text, ''), a.name, SUM(a.quantity), SUM(a.amount), COUNT(DISTINCT a.order_id)
+		FROM order_item_addons a
+		JOIN orders o ON o.id = a.order_id
+		WHERE o.store_id = $1 AND o.status <> 'cancelled' AND o.created_at >= $2 AND o.created_at < $3
+		GROUP BY a.addon_id, a.name
+		ORDER BY SUM(a.amount) DESC, a.name
+	`, storeID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lines := []*SalesLine{}
+	for rows.Next() {
+		var l SalesLine
+		if err := rows.Scan(&l.AddonID, &l.Name, &l.Quantity, &l.Amount, &l.OrderCount); err != nil {
+			return nil, err
+		}
+		lines = append(lines, &l)
+	}
+	return lines, rows.Err()
+}
@@ -0,0 +1,100 @@
+package addon
+
+import (
+	"context"
+	"time"
+
+	"sumunar-pos-core/internal/addon/dto"
+	"sumunar-pos-core/internal/servicetype"
+	"sumunar-pos-core/internal/store"
+)
+
+type AddonService interface {
+	Create(ctx context.Context, req *dto.AddonRequest, userID string) (*Addon, error)
+	FindByID(ctx context.Context, id string) (*Addon, error)
+	FindAll(ctx context.Context, storeID string, limit, offset int) ([]*Addon, int, error)
+	Update(ctx context.Context, id string, req *dto.AddonRequest, userID string) (*Addon, error)
+	Delete(ctx context.Context, id string) error
+	Sales(ctx context.Context, req *dto.SalesRequest) (*dto.SalesResponse, error)
+}
+
+type service struct {
+	repo            AddonRepository
+	serviceTypeRepo servicetype.ServiceRepository
+	storeRepo       store.StoreRepository
+}
+
+func NewService(repo AddonRepository, serviceTypeRepo servicetype.ServiceRepository, storeRepo store.StoreRepository) AddonService {
+	return &service{repo: repo, serviceTypeRepo: serviceTypeRepo, storeRepo: storeRepo}
+}
+
+func (s *service) Create(ctx context.Context, req *dto.AddonRequest, userID string) (*Addon, error) {
+	if err := s.checkServiceType(ctx, req.StoreID, req.ServiceTypeID); err != nil {
+		return nil, err
+	}
+
+	a := ToAddonModel(req, userID)
+	if err := s.repo.Create(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (s *service) FindByID(ctx context.Context, id string) (*Addon, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *service) FindAll(ctx context.Context, storeID string, limit, offset int) ([]*Addon, int, error) {
+	return s.repo.FindAll(ctx, storeID, limit, offset)
+}
+
+func (s *service) Update(ctx context.Context, id string, req *dto.AddonRequest, userID string) (*Addon, error) {
+	a, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkServiceType(ctx, a.StoreID, req.ServiceTypeID); err != nil {
+		return nil, err
+	}
+
+	// store_id tidak bisa dipindah, seperti katalog lainnya
+	applyRequest(a, req)
+	a.UpdatedAt = time.Now()
+	a.UpdatedBy = userID
+
+	return a, s.repo.Update(ctx, a)
+}
+
+func (s *service) Delete(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// Sales reports the add-ons sold by the store between two business dates, both inclusive.
+func (s *service) Sales(ctx context.Context, req *dto.SalesRequest) (*dto.SalesResponse, error) {
+	settings, err := s.storeRepo.FindSettings(ctx, req.StoreID)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := settings.BusinessRange(req.From, req.To)
+	if err != nil {
+		return nil, ErrInvalidPeriod
+	}
+
+	lines, err := s.repo.Sales(ctx, req.StoreID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return ToSalesResponse(req, lines), nil
+}
+
+// checkServiceType: add-on khusus jenis layanan hanya boleh memakai jenis layanan toko yang sama.
+func (s *service) checkServiceType(ctx context.Context, storeID, serviceTypeID string) error {
+	if serviceTypeID == "" {
+		return nil
+	}
+	st, err := s.serviceTypeRepo.FindByID(ctx, serviceTypeID)
+	if err != nil || st.StoreID != storeID {
+		return ErrServiceTypeNotFound
+	}
+	return nil
+}
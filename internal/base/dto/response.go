@@ -8,3 +8,21 @@ type PaginatedResponse[T any] struct {
 	Limit  int `json:"limit"`
 	Offset int `json:"offset"`
 }
+
+// NewPaginatedResponse wraps one page of data with its paging info; page is 1-based.
+func NewPaginatedResponse[T any](data []T, total, page, limit int) PaginatedResponse[T] {
+	pages := 0
+	if limit > 0 {
+		pages = (total + limit - 1) / limit // ceil
+	}
+
+	offset := (page - 1) * limit
+	return PaginatedResponse[T]{
+		Data:   data,
+		Total:  total,
+		Page:   page,
+		Pages:  pages,
+		Limit:  limit,
+		Offset: offset,
+	}
+}
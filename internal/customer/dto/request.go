@@ -3,5 +3,6 @@ package dto
 type CustomerRequest struct {
 	Name    string `json:"name" validate:"required"`
 	Phone   string `json:"phone"`
+	Email   string `json:"email" validate:"omitempty,email"`
 	Address string `json:"address"`
 }
@@ -1,10 +1,28 @@
 package dto
 
+import "sumunar-pos-core/pkg/money"
+
 type CustomerResponse struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	Phone   string `json:"phone"`
-	Address string `json:"address"`
+	ID            string       `json:"id"`
+	Name          string       `json:"name"`
+	Phone         string       `json:"phone"`
+	Email         string       `json:"email"`
+	Address       string       `json:"address"`
+	PointsBalance int          `json:"points_balance"`
+	LifetimeSpend money.Amount `json:"lifetime_spend"`
+	Tier          string       `json:"tier"`
+}
+
+type PointsEntryResponse struct {
+	ID          string `json:"id"`
+	StoreID     string `json:"store_id"`
+	OrderID     string `json:"order_id,omitempty"`
+	Type        string `json:"type"` // earn, redeem, expire, reversal
+	Points      int    `json:"points"`
+	Remaining   int    `json:"remaining"`
+	ExpiresAt   string `json:"expires_at,omitempty"` // ISO8601
+	Description string `json:"description"`
+	CreatedAt   string `json:"created_at"`
 }
 
 type ErrorResponse struct {
@@ -0,0 +1,8 @@
+package customer
+
+import "errors"
+
+var (
+	ErrCustomerNotFound   = errors.New("customer not found")
+	ErrInsufficientPoints = errors.New("customer does not have enough points")
+)
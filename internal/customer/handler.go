@@ -0,0 +1,119 @@
+package customer
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"sumunar-pos-core/internal/customer/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service CustomerService
+}
+
+func NewHandler(service CustomerService) *Handler {
+	return &Handler{service}
+}
+
+func (h *Handler) Create(c echo.Context) error {
+	var req dto.CustomerRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	customer, err := h.service.Create(c.Request().Context(), &req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, ToCustomerResponse(customer))
+}
+
+func (h *Handler) FindByID(c echo.Context) error {
+	customer, err := h.service.FindByID(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToCustomerResponse(customer))
+}
+
+func (h *Handler) FindAll(c echo.Context) error {
+	limit, offset := pagination(c)
+
+	customers, total, err := h.service.FindAll(c.Request().Context(), limit, offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data":   ToCustomerListResponse(customers),
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+func (h *Handler) Update(c echo.Context) error {
+	var req dto.CustomerRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	customer, err := h.service.Update(c.Request().Context(), c.Param("id"), &req)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToCustomerResponse(customer))
+}
+
+func (h *Handler) Delete(c echo.Context) error {
+	if err := h.service.Delete(c.Request().Context(), c.Param("id")); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Points lists the customer's points ledger: earned, redeemed, expired and reversed points.
+func (h *Handler) Points(c echo.Context) error {
+	limit, offset := pagination(c)
+
+	entries, total, err := h.service.Points(c.Request().Context(), c.Param("id"), limit, offset)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data":   ToPointsEntryResponses(entries),
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+func pagination(c echo.Context) (limit, offset int) {
+	limit, _ = strconv.Atoi(c.QueryParam("limit"))
+	offset, _ = strconv.Atoi(c.QueryParam("offset"))
+	if limit <= 0 {
+		limit = 50
+	}
+	return limit, offset
+}
+
+func errorStatus(err error) int {
+	if errors.Is(err, ErrCustomerNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
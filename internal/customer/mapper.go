@@ -1,6 +1,8 @@
 package customer
 
 import (
+	"time"
+
 	"sumunar-pos-core/internal/base"
 	"sumunar-pos-core/internal/customer/dto"
 
@@ -12,8 +14,11 @@ func ToCustomerModel(req *dto.CustomerRequest, createdBy string) *Customer {
 		ID:      uuid.New().String(),
 		Name:    req.Name,
 		Phone:   req.Phone,
+		Email:   req.Email,
 		Address: req.Address,
 		BaseModel: base.BaseModel{
+			IsActive:  true,
+			CreatedAt: time.Now(),
 			CreatedBy: createdBy,
 		},
 	}
@@ -21,10 +26,14 @@ func ToCustomerModel(req *dto.CustomerRequest, createdBy string) *Customer {
 
 func ToCustomerResponse(customer *Customer) *dto.CustomerResponse {
 	return &dto.CustomerResponse{
-		ID:      customer.ID,
-		Name:    customer.Name,
-		Phone:   customer.Phone,
-		Address: customer.Address,
+		ID:            customer.ID,
+		Name:          customer.Name,
+		Phone:         customer.Phone,
+		Email:         customer.Email,
+		Address:       customer.Address,
+		PointsBalance: customer.PointsBalance,
+		LifetimeSpend: customer.LifetimeSpend,
+		Tier:          customer.Tier,
 	}
 }
 
@@ -35,3 +44,24 @@ func ToCustomerListResponse(customers []*Customer) []*dto.CustomerResponse {
 	}
 	return res
 }
+
+func ToPointsEntryResponses(entries []*PointsEntry) []dto.PointsEntryResponse {
+	res := make([]dto.PointsEntryResponse, 0, len(entries))
+	for _, e := range entries {
+		r := dto.PointsEntryResponse{
+			ID:          e.ID,
+			StoreID:     e.StoreID,
+			OrderID:     e.OrderID,
+			Type:        e.Type,
+			Points:      e.Points,
+			Remaining:   e.Remaining,
+			Description: e.Description,
+			CreatedAt:   e.CreatedAt.Format(time.RFC3339),
+		}
+		if e.ExpiresAt != nil {
+			r.ExpiresAt = e.ExpiresAt.Format(time.RFC3339)
+		}
+		res = append(res, r)
+	}
+	return res
+}
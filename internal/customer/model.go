@@ -2,12 +2,17 @@ package customer
 
 import (
 	"sumunar-pos-core/internal/base"
+	"sumunar-pos-core/pkg/money"
 )
 
 type Customer struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	Phone   string `json:"phone"`
-	Address string `json:"address"`
+	ID            string       `json:"id"`
+	Name          string       `json:"name"`
+	Phone         string       `json:"phone"`
+	Email         string       `json:"email"`
+	Address       string       `json:"address"`
+	PointsBalance int          `json:"points_balance"`
+	LifetimeSpend money.Amount `json:"lifetime_spend"` // total order lunas, dasar penentuan tier
+	Tier          string       `json:"tier"`           // kosong = belum masuk tier
 	base.BaseModel
 }
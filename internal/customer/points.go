@@ -0,0 +1,27 @@
+package customer
+
+import "time"
+
+// Jenis mutasi poin loyalti.
+const (
+	PointsEarn     = "earn"
+	PointsRedeem   = "redeem"
+	PointsExpire   = "expire"
+	PointsReversal = "reversal" // order dibatalkan: poin didapat ditarik, poin ditukar dikembalikan
+)
+
+// PointsEntry is one row of a customer's points ledger. Points is signed. Rows that add points
+// keep a Remaining balance so redemptions and expiry use up the oldest points first.
+type PointsEntry struct {
+	ID          string     `json:"id"`
+	CustomerID  string     `json:"customer_id"`
+	StoreID     string     `json:"store_id"`
+	OrderID     string     `json:"order_id"`
+	Type        string     `json:"type"`
+	Points      int        `json:"points"`
+	Remaining   int        `json:"remaining"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	Description string     `json:"description"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CreatedBy   string     `json:"created_by"`
+}
@@ -2,15 +2,26 @@ package customer
 
 import (
 	"context"
+	"errors"
+	"time"
+
 	"sumunar-pos-core/pkg/db"
+	"sumunar-pos-core/pkg/money"
+
+	"github.com/jackc/pgx/v5"
 )
 
 type CustomerRepository interface {
 	Create(ctx context.Context, product *Customer) error
 	FindByID(ctx context.Context, id string) (*Customer, error)
+	FindByIDForUpdate(ctx context.Context, tx db.DBTX, id string) (*Customer, error)
 	FindAll(ctx context.Context, limit, offset int) ([]*Customer, int, error)
 	Update(ctx context.Context, product *Customer) error
 	Delete(ctx context.Context, id string) error
+	AddPoints(ctx context.Context, tx db.DBTX, entry *PointsEntry) error
+	ExpirePoints(ctx context.Context, tx db.DBTX, customerID string, at time.Time) error
+	UpdateLoyalty(ctx context.Context, tx db.DBTX, id string, spend money.Amount, tier string) error
+	FindPoints(ctx context.Context, customerID string, limit, offset int) ([]*PointsEntry, int, error)
 }
 
 type customerRepo struct {
@@ -23,13 +34,14 @@ func NewCustomerRepository(db db.DBTX) CustomerRepository {
 
 func (r *customerRepo) Create(ctx context.Context, customer *Customer) error {
 	query := `
-		INSERT INTO customers (id, name, phone, address, is_active, created_at, created_by, updated_at, updated_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $6, $7)
+		INSERT INTO customers (id, name, phone, email, address, is_active, created_at, created_by, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $7, $8)
 	`
 	_, err := r.db.Exec(ctx, query,
 		customer.ID,
 		customer.Name,
 		customer.Phone,
+		customer.Email,
 		customer.Address,
 		customer.IsActive,
 		customer.CreatedAt,
@@ -38,30 +50,53 @@ func (r *customerRepo) Create(ctx context.Context, customer *Customer) error {
 	return err
 }
 
-func (r *customerRepo) FindByID(ctx context.Context, id string) (*Customer, error) {
-	query := `SELECT id, name, phone, address, is_active, created_at, created_by, updated_at, updated_by FROM customers WHERE id = $1`
-	row := r.db.QueryRow(ctx, query, id)
+const customerColumns = `id, name, phone, email, address, points_balance, lifetime_spend, tier, is_active, created_at, created_by, updated_at, updated_by`
 
-	var product Customer
+func scanCustomer(row pgx.Row) (*Customer, error) {
+	var c Customer
 	err := row.Scan(
-		&product.ID,
-		&product.Name,
-		&product.Phone,
-		&product.Address,
-		&product.IsActive,
-		&product.CreatedAt,
-		&product.CreatedBy,
-		&product.UpdatedAt,
-		&product.UpdatedBy,
+		&c.ID,
+		&c.Name,
+		&c.Phone,
+		&c.Email,
+		&c.Address,
+		&c.PointsBalance,
+		&c.LifetimeSpend,
+		&c.Tier,
+		&c.IsActive,
+		&c.CreatedAt,
+		&c.CreatedBy,
+		&c.UpdatedAt,
+		&c.UpdatedBy,
 	)
 	if err != nil {
 		return nil, err
 	}
-	return &product, nil
+	return &c, nil
+}
+
+func (r *customerRepo) FindByID(ctx context.Context, id string) (*Customer, error) {
+	query := `SELECT ` + customerColumns + ` FROM customers WHERE id = $1`
+	c, err := scanCustomer(r.db.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrCustomerNotFound
+	}
+	return c, err
+}
+
+// FindByIDForUpdate reads the customer row and locks it until tx ends, so the points balance
+// cannot change while an order redeems it.
+func (r *customerRepo) FindByIDForUpdate(ctx context.Context, tx db.DBTX, id string) (*Customer, error) {
+	query := `SELECT ` + customerColumns + ` FROM customers WHERE id = $1 FOR UPDATE`
+	c, err := scanCustomer(tx.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrCustomerNotFound
+	}
+	return c, err
 }
 
 func (r *customerRepo) FindAll(ctx context.Context, limit, offset int) ([]*Customer, int, error) {
-	query := `SELECT id, name, phone, address, is_active, created_at, created_by, updated_at, updated_by FROM customers LIMIT $1 OFFSET $2`
+	query := `SELECT ` + customerColumns + ` FROM customers LIMIT $1 OFFSET $2`
 	rows, err := r.db.Query(ctx, query, limit, offset)
 	if err != nil {
 		return nil, 0, err
@@ -70,21 +105,11 @@ func (r *customerRepo) FindAll(ctx context.Context, limit, offset int) ([]*Custo
 
 	var customers []*Customer
 	for rows.Next() {
-		var s Customer
-		if err := rows.Scan(
-			&s.ID,
-			&s.Name,
-			&s.Phone,
-			&s.Address,
-			&s.IsActive,
-			&s.CreatedAt,
-			&s.CreatedBy,
-			&s.UpdatedAt,
-			&s.UpdatedBy,
-		); err != nil {
+		c, err := scanCustomer(rows)
+		if err != nil {
 			return nil, 0, err
 		}
-		customers = append(customers, &s)
+		customers = append(customers, c)
 	}
 
 	// total count
@@ -99,13 +124,14 @@ func (r *customerRepo) FindAll(ctx context.Context, limit, offset int) ([]*Custo
 
 func (r *customerRepo) Update(ctx context.Context, product *Customer) error {
 	query := `
-		UPDATE customers SET name = $1, phone = $2, address = $3, is_active = $4,
-		updated_at = $5, updated_by = $6
-		WHERE id = $7
+		UPDATE customers SET name = $1, phone = $2, email = $3, address = $4, is_active = $5,
+		updated_at = $6, updated_by = $7
+		WHERE id = $8
 	`
 	_, err := r.db.Exec(ctx, query,
 		product.Name,
 		product.Phone,
+		product.Email,
 		product.Address,
 		product.IsActive,
 		product.UpdatedAt,
@@ -119,3 +145,156 @@ func (r *customerRepo) Delete(ctx context.Context, id string) error {
 	_, err := r.db.Exec(ctx, `DELETE FROM customers WHERE id = $1`, id)
 	return err
 }
+
+// AddPoints writes a ledger entry and moves the customer's balance. Negative entries use up the
+// oldest remaining points first and fail with ErrInsufficientPoints when the balance is too low.
+func (r *customerRepo) AddPoints(ctx context.Context, tx db.DBTX, entry *PointsEntry) error {
+	tag, err := tx.Exec(ctx, `
+		UPDATE customers SET points_balance = points_balance + $2
+		WHERE id = $1 AND points_balance + $2 >= 0
+	`, entry.CustomerID, entry.Points)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrInsufficientPoints
+	}
+
+	if entry.Points > 0 {
+		entry.Remaining = entry.Points
+	} else if err := r.consumePoints(ctx, tx, entry.CustomerID, -entry.Points); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO customer_points (id, customer_id, store_id, order_id, type, points, remaining, expires_at, description, created_at, created_by)
+		VALUES ($1, $2, $3, NULLIF($4, '')::uuid, $5, $6, $7, $8, $9, $10, NULLIF($11, '')::uuid)
+	`,
+		entry.ID,
+		entry.CustomerID,
+		entry.StoreID,
+		entry.OrderID,
+		entry.Type,
+		entry.Points,
+		entry.Remaining,
+		entry.ExpiresAt,
+		entry.Description,
+		entry.CreatedAt,
+		entry.CreatedBy,
+	)
+	return err
+}
+
+// consumePoints mengurangi sisa poin dari entri terlama (yang paling cepat kedaluwarsa dulu).
+func (r *customerRepo) consumePoints(ctx context.Context, tx db.DBTX, customerID string, points int) error {
+	rows, err := tx.Query(ctx, `
+		SELECT id, remaining FROM customer_points
+		WHERE customer_id = $1 AND remaining > 0
+		ORDER BY expires_at NULLS LAST, created_at
+		FOR UPDATE
+	`, customerID)
+	if err != nil {
+		return err
+	}
+	type open struct {
+		id        string
+		remaining int
+	}
+	var opens []open
+	for rows.Next() {
+		var o open
+		if err := rows.Scan(&o.id, &o.remaining); err != nil {
+			rows.Close()
+			return err
+		}
+		opens = append(opens, o)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, o := range opens {
+		if points == 0 {
+			break
+		}
+		used := min(points, o.remaining)
+		if _, err := tx.Exec(ctx, `UPDATE customer_points SET remaining = remaining - $2 WHERE id = $1`, o.id, used); err != nil {
+			return err
+		}
+		points -= used
+	}
+	return nil
+}
+
+// ExpirePoints writes off every point that expired before at, in one statement.
+func (r *customerRepo) ExpirePoints(ctx context.Context, tx db.DBTX, customerID string, at time.Time) error {
+	_, err := tx.Exec(ctx, `
+		WITH due AS (
+			SELECT id, store_id, remaining FROM customer_points
+			WHERE customer_id = $1 AND remaining > 0 AND expires_at <= $2
+			FOR UPDATE
+		), cleared AS (
+			UPDATE customer_points p SET remaining = 0 FROM due WHERE p.id = due.id
+		), logged AS (
+			INSERT INTO customer_points (id, customer_id, store_id, type, points, remaining, description, created_at)
+			SELECT gen_random_uuid(), $1, store_id, 'expire', -remaining, 0, 'Poin kedaluwarsa', $2 FROM due
+		)
+		UPDATE customers SET points_balance = GREATEST(points_balance - (SELECT COALESCE(SUM(remaining), 0) FROM due), 0)
+		WHERE id = $1
+	`, customerID, at)
+	return err
+}
+
+// UpdateLoyalty adds spend (negative for a cancelled order) to the customer's lifetime spend and sets the tier.
+func (r *customerRepo) UpdateLoyalty(ctx context.Context, tx db.DBTX, id string, spend money.Amount, tier string) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE customers SET lifetime_spend = GREATEST(lifetime_spend + $2, 0), tier = $3 WHERE id = $1
+	`, id, spend, tier)
+	return err
+}
+
+func (r *customerRepo) FindPoints(ctx context.Context, customerID string, limit, offset int) ([]*PointsEntry, int, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, customer_id, store_id, COALESCE(order_id::text, ''), type, points, remaining, expires_at, description,
+			created_at, COALESCE(created_by::text, '')
+		FROM customer_points
+		WHERE customer_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`, customerID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var entries []*PointsEntry
+	for rows.Next() {
+		var e PointsEntry
+		if err := rows.Scan(
+			&e.ID,
+			&e.CustomerID,
+			&e.StoreID,
+			&e.OrderID,
+			&e.Type,
+			&e.Points,
+			&e.Remaining,
+			&e.ExpiresAt,
+			&e.Description,
+			&e.CreatedAt,
+			&e.CreatedBy,
+		); err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM customer_points WHERE customer_id = $1`, customerID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
@@ -5,9 +5,8 @@ import (
 	"log"
 	"sumunar-pos-core/internal/customer/dto"
 	"sumunar-pos-core/middleware"
+	"sumunar-pos-core/pkg/db"
 	"time"
-
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type CustomerService interface {
@@ -16,14 +15,15 @@ type CustomerService interface {
 	FindAll(ctx context.Context, limit, offset int) ([]*Customer, int, error)
 	Update(ctx context.Context, id string, req *dto.CustomerRequest) (*Customer, error)
 	Delete(ctx context.Context, id string) error
+	Points(ctx context.Context, id string, limit, offset int) ([]*PointsEntry, int, error)
 }
 
 type service struct {
 	repo CustomerRepository
-	db   *pgxpool.Pool
+	db   db.DBTX
 }
 
-func NewService(repo CustomerRepository, db *pgxpool.Pool) CustomerService {
+func NewService(repo CustomerRepository, db db.DBTX) CustomerService {
 	return &service{repo, db}
 }
 
@@ -43,7 +43,11 @@ func (s *service) Create(ctx context.Context, req *dto.CustomerRequest) (*Custom
 	return product, nil
 }
 
+// FindByID returns the customer with an up-to-date points balance: expired points are written off first.
 func (s *service) FindByID(ctx context.Context, id string) (*Customer, error) {
+	if err := s.repo.ExpirePoints(ctx, s.db, id, time.Now()); err != nil {
+		return nil, err
+	}
 	product, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -72,6 +76,7 @@ func (s *service) Update(ctx context.Context, id string, req *dto.CustomerReques
 
 	product.Name = req.Name
 	product.Phone = req.Phone
+	product.Email = req.Email
 	product.Address = req.Address
 	product.UpdatedAt = time.Now()
 	product.UpdatedBy = userID
@@ -82,3 +87,11 @@ func (s *service) Update(ctx context.Context, id string, req *dto.CustomerReques
 func (s *service) Delete(ctx context.Context, id string) error {
 	return s.repo.Delete(ctx, id)
 }
+
+// Points returns the customer's points ledger, newest first.
+func (s *service) Points(ctx context.Context, id string, limit, offset int) ([]*PointsEntry, int, error) {
+	if _, err := s.FindByID(ctx, id); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.FindPoints(ctx, id, limit, offset)
+}
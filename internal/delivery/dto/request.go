@@ -0,0 +1,38 @@
+package dto
+
+import "sumunar-pos-core/pkg/money"
+
+// JobRequest schedules a pickup or delivery for an order; the job belongs to the order's store.
+type JobRequest struct {
+	OrderID string `json:"order_id" validate:"required,uuid"`
+	Type    string `json:"type" validate:"required,oneof=pickup delivery"`
+	JobUpdateRequest
+}
+
+// JobUpdateRequest changes a scheduled or failed job. Order and type cannot change.
+type JobUpdateRequest struct {
+	Address     string        `json:"address"` // kosong = alamat pelanggan
+	DistanceKm  float64       `json:"distance_km" validate:"gte=0"`
+	WindowStart string        `json:"window_start" validate:"required"` // ISO8601
+	WindowEnd   string        `json:"window_end" validate:"required"`   // ISO8601
+	Fee         *money.Amount `json:"fee" validate:"omitempty,gte=0"`   // kosong = dari tabel ongkos toko
+	CourierID   string        `json:"courier_id" validate:"omitempty,uuid"`
+	Notes       string        `json:"notes"`
+}
+
+// JobStatusRequest moves a job along its status flow; reason is required for failed.
+type JobStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=scheduled on_the_way picked_up delivered failed"`
+	Reason string `json:"reason"`
+}
+
+// JobListRequest is the query string of GET /api/delivery/jobs.
+type JobListRequest struct {
+	StoreID   string `query:"store_id"`
+	OrderID   string `query:"order_id"`
+	CourierID string `query:"courier_id"`
+	Status    string `query:"status" validate:"omitempty,oneof=scheduled on_the_way picked_up delivered failed"`
+	Date      string `query:"date"` // YYYY-MM-DD, hari bisnis toko; butuh store_id
+	Limit     int    `query:"limit"`
+	Offset    int    `query:"offset"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+import "sumunar-pos-core/pkg/money"
+
+type JobResponse struct {
+	ID            string       `json:"id"`
+	StoreID       string       `json:"store_id"`
+	OrderID       string       `json:"order_id"`
+	InvoiceNumber string       `json:"invoice_number"`
+	CustomerName  string       `json:"customer_name"`
+	CustomerPhone string       `json:"customer_phone"`
+	Type          string       `json:"type"`
+	Address       string       `json:"address"`
+	DistanceKm    float64      `json:"distance_km"`
+	WindowStart   string       `json:"window_start"`
+	WindowEnd     string       `json:"window_end"`
+	Fee           money.Amount `json:"fee"`
+	CourierID     string       `json:"courier_id"`
+	CourierName   string       `json:"courier_name"`
+	Status        string       `json:"status"`
+	Notes         string       `json:"notes"`
+	FailReason    string       `json:"fail_reason,omitempty"`
+	CompletedAt   string       `json:"completed_at,omitempty"`
+	CreatedAt     string       `json:"created_at"`
+	UpdatedAt     string       `json:"updated_at"`
+}
+
+type FeeResponse struct {
+	StoreID    string       `json:"store_id"`
+	DistanceKm float64      `json:"distance_km"`
+	Fee        money.Amount `json:"fee"`
+}
@@ -0,0 +1,19 @@
+package delivery
+
+import "errors"
+
+var (
+	ErrJobNotFound          = errors.New("delivery job not found")
+	ErrOrderNotFound        = errors.New("order not found")
+	ErrOrderCancelled       = errors.New("cancelled orders cannot be picked up or delivered")
+	ErrOrderTaken           = errors.New("order is already taken, its delivery jobs cannot change")
+	ErrInvalidWindow        = errors.New("window_start and window_end must be ISO8601 date-times, window_end after window_start")
+	ErrAddressRequired      = errors.New("address is required when the customer has none")
+	ErrOutsideDeliveryArea  = errors.New("distance is beyond the store's delivery rates")
+	ErrInvalidCourier       = errors.New("courier must be an active courier or worker of the order's store")
+	ErrJobNotEditable       = errors.New("only scheduled or failed jobs can be changed")
+	ErrInvalidStatus        = errors.New("delivery job status transition is not allowed")
+	ErrFailReasonRequired   = errors.New("reason is required when a job fails")
+	ErrNotAssignedToCourier = errors.New("delivery job is not assigned to you")
+	ErrInvalidFilter        = errors.New("date must be YYYY-MM-DD and needs store_id")
+)
@@ -0,0 +1,187 @@
+package delivery
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"sumunar-pos-core/internal/delivery/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service DeliveryService
+}
+
+func NewHandler(service DeliveryService) *Handler {
+	return &Handler{service}
+}
+
+// Create godoc
+// @Summary Jadwalkan jemput atau antar cucian untuk order
+// @Tags delivery
+// @Accept json
+// @Produce json
+// @Param body body dto.JobRequest true "Order, jenis job, alamat, jendela waktu dan kurir"
+// @Success 201 {object} dto.JobResponse
+// @Router /delivery/jobs [post]
+func (h *Handler) Create(c echo.Context) error {
+	var req dto.JobRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Get("user_id").(string)
+	j, err := h.service.Create(c.Request().Context(), &req, userID)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, ToJobResponse(j))
+}
+
+func (h *Handler) FindByID(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	role, _ := c.Get("role").(string)
+	j, err := h.service.FindByID(c.Request().Context(), c.Param("id"), userID, role)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToJobResponse(j))
+}
+
+// FindAll lists jobs filtered by store, order, courier, status and business day.
+func (h *Handler) FindAll(c echo.Context) error {
+	var req dto.JobListRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if req.Limit <= 0 {
+		req.Limit = 50
+	}
+
+	jobs, total, err := h.service.FindAll(c.Request().Context(), &req)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data":   ToJobListResponse(jobs),
+		"total":  total,
+		"limit":  req.Limit,
+		"offset": req.Offset,
+	})
+}
+
+func (h *Handler) Update(c echo.Context) error {
+	var req dto.JobUpdateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Get("user_id").(string)
+	j, err := h.service.Update(c.Request().Context(), c.Param("id"), &req, userID)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToJobResponse(j))
+}
+
+func (h *Handler) Delete(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	if err := h.service.Delete(c.Request().Context(), c.Param("id"), userID); err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// UpdateStatus godoc
+// @Summary Ubah status job antar-jemput
+// @Tags delivery
+// @Accept json
+// @Produce json
+// @Param id path string true "Job ID"
+// @Param body body dto.JobStatusRequest true "Status baru, alasan wajib untuk failed"
+// @Success 200 {object} dto.JobResponse
+// @Router /delivery/jobs/{id}/status [post]
+func (h *Handler) UpdateStatus(c echo.Context) error {
+	var req dto.JobStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Get("user_id").(string)
+	role, _ := c.Get("role").(string)
+	j, err := h.service.UpdateStatus(c.Request().Context(), c.Param("id"), &req, userID, role)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToJobResponse(j))
+}
+
+// CourierJobs godoc
+// @Summary Daftar job kurir hari ini, termasuk job terlambat yang belum selesai
+// @Tags delivery
+// @Produce json
+// @Success 200 {array} dto.JobResponse
+// @Router /delivery/courier/jobs [get]
+func (h *Handler) CourierJobs(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	jobs, err := h.service.CourierJobs(c.Request().Context(), userID, time.Now())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"data": ToJobListResponse(jobs)})
+}
+
+// Fee quotes the store's delivery fee for ?distance_km=.
+func (h *Handler) Fee(c echo.Context) error {
+	storeID := c.QueryParam("store_id")
+	distance, err := strconv.ParseFloat(c.QueryParam("distance_km"), 64)
+	if storeID == "" || err != nil || distance < 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "store_id and distance_km are required")
+	}
+
+	fee, err := h.service.Fee(c.Request().Context(), storeID, distance)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, dto.FeeResponse{StoreID: storeID, DistanceKm: distance, Fee: fee})
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrJobNotFound), errors.Is(err, ErrOrderNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrNotAssignedToCourier):
+		return http.StatusForbidden
+	case errors.Is(err, ErrInvalidWindow), errors.Is(err, ErrAddressRequired), errors.Is(err, ErrInvalidCourier),
+		errors.Is(err, ErrFailReasonRequired), errors.Is(err, ErrInvalidFilter):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrOrderCancelled), errors.Is(err, ErrOrderTaken), errors.Is(err, ErrOutsideDeliveryArea),
+		errors.Is(err, ErrJobNotEditable), errors.Is(err, ErrInvalidStatus):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,85 @@
+package delivery
+
+import (
+	"strings"
+	"time"
+
+	"sumunar-pos-core/internal/delivery/dto"
+
+	"github.com/google/uuid"
+)
+
+func ToJobModel(req *dto.JobRequest, storeID, createdBy string, at time.Time) (*Job, error) {
+	j := &Job{
+		ID:        uuid.New().String(),
+		StoreID:   storeID,
+		OrderID:   req.OrderID,
+		Type:      req.Type,
+		Status:    StatusScheduled,
+		CreatedAt: at,
+		CreatedBy: createdBy,
+		UpdatedAt: at,
+		UpdatedBy: createdBy,
+	}
+	if err := applyRequest(j, &req.JobUpdateRequest); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// applyRequest copies the editable fields of req onto j. Address and fee are resolved by the service
+// when left empty.
+func applyRequest(j *Job, req *dto.JobUpdateRequest) error {
+	start, err := time.Parse(time.RFC3339, req.WindowStart)
+	if err != nil {
+		return ErrInvalidWindow
+	}
+	end, err := time.Parse(time.RFC3339, req.WindowEnd)
+	if err != nil || !end.After(start) {
+		return ErrInvalidWindow
+	}
+
+	j.Address = strings.TrimSpace(req.Address)
+	j.DistanceKm = req.DistanceKm
+	j.WindowStart = start
+	j.WindowEnd = end
+	j.CourierID = req.CourierID
+	j.Notes = strings.TrimSpace(req.Notes)
+	return nil
+}
+
+func ToJobResponse(j *Job) *dto.JobResponse {
+	res := &dto.JobResponse{
+		ID:            j.ID,
+		StoreID:       j.StoreID,
+		OrderID:       j.OrderID,
+		InvoiceNumber: j.InvoiceNumber,
+		CustomerName:  j.CustomerName,
+		CustomerPhone: j.CustomerPhone,
+		Type:          j.Type,
+		Address:       j.Address,
+		DistanceKm:    j.DistanceKm,
+		WindowStart:   j.WindowStart.Format(time.RFC3339),
+		WindowEnd:     j.WindowEnd.Format(time.RFC3339),
+		Fee:           j.Fee,
+		CourierID:     j.CourierID,
+		CourierName:   j.CourierName,
+		Status:        j.Status,
+		Notes:         j.Notes,
+		FailReason:    j.FailReason,
+		CreatedAt:     j.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     j.UpdatedAt.Format(time.RFC3339),
+	}
+	if j.CompletedAt != nil {
+		res.CompletedAt = j.CompletedAt.Format(time.RFC3339)
+	}
+	return res
+}
+
+func ToJobListResponse(jobs []*Job) []*dto.JobResponse {
+	res := make([]*dto.JobResponse, 0, len(jobs))
+	for _, j := range jobs {
+		res = append(res, ToJobResponse(j))
+	}
+	return res
+}
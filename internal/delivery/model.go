@@ -0,0 +1,91 @@
+package delivery
+
+import (
+	"time"
+
+	"sumunar-pos-core/pkg/money"
+)
+
+// Jenis job antar-jemput.
+const (
+	TypePickup   = "pickup"   // jemput cucian dari pelanggan
+	TypeDelivery = "delivery" // antar cucian yang sudah selesai
+)
+
+// Status job antar-jemput.
+const (
+	StatusScheduled = "scheduled"
+	StatusOnTheWay  = "on_the_way"
+	StatusPickedUp  = "picked_up" // akhir job pickup
+	StatusDelivered = "delivered" // akhir job delivery
+	StatusFailed    = "failed"    // pelanggan tidak ada, alamat salah, dll; bisa dijadwalkan ulang
+)
+
+// RoleCourier is the user role of couriers; they only see and update their own jobs.
+const RoleCourier = "courier"
+
+// Job is a pickup from or delivery to a customer, attached to an order and done by a courier.
+type Job struct {
+	ID          string
+	StoreID     string
+	OrderID     string
+	Type        string
+	Address     string
+	DistanceKm  float64
+	WindowStart time.Time
+	WindowEnd   time.Time
+	Fee         money.Amount // ikut ditagihkan di order
+	CourierID   string       // kosong = belum ditugaskan
+	Status      string
+	Notes       string
+	FailReason  string
+	CompletedAt *time.Time
+	CreatedAt   time.Time
+	CreatedBy   string
+	UpdatedAt   time.Time
+	UpdatedBy   string
+
+	// dari join, hanya dibaca
+	InvoiceNumber string
+	CustomerName  string
+	CustomerPhone string
+	CourierName   string
+}
+
+// JobFilter is the query of the job list. A zero From or To leaves that side of the window open.
+type JobFilter struct {
+	StoreID   string
+	OrderID   string
+	CourierID string
+	Status    string
+	From      time.Time
+	To        time.Time
+	Limit     int
+	Offset    int
+}
+
+var transitions = map[string][]string{
+	StatusScheduled: {StatusOnTheWay, StatusFailed},
+	StatusOnTheWay:  {StatusPickedUp, StatusDelivered, StatusFailed},
+	StatusFailed:    {StatusScheduled},
+}
+
+// CanMoveTo reports whether the job may go to status: picked_up only ends a pickup and delivered
+// only ends a delivery.
+func (j *Job) CanMoveTo(status string) bool {
+	switch {
+	case status == StatusPickedUp && j.Type != TypePickup, status == StatusDelivered && j.Type != TypeDelivery:
+		return false
+	}
+	for _, next := range transitions[j.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Editable reports whether address, window, fee and courier may still change.
+func (j *Job) Editable() bool {
+	return j.Status == StatusScheduled || j.Status == StatusFailed
+}
@@ -0,0 +1,34 @@
+package delivery
+
+import "testing"
+
+func TestCanMoveTo(t *testing.T) {
+	tests := []struct {
+		typ, from, to string
+		want          bool
+	}{
+		{TypePickup, StatusScheduled, StatusOnTheWay, true},
+		{TypePickup, StatusScheduled, StatusFailed, true},
+		{TypePickup, StatusScheduled, StatusPickedUp, false}, // kurir harus berangkat dulu
+		{TypePickup, StatusOnTheWay, StatusPickedUp, true},
+		{TypePickup, StatusOnTheWay, StatusDelivered, false}, // pickup tidak diantar
+		{TypePickup, StatusOnTheWay, StatusFailed, true},
+		{TypePickup, StatusOnTheWay, StatusScheduled, false},
+		{TypeDelivery, StatusOnTheWay, StatusDelivered, true},
+		{TypeDelivery, StatusOnTheWay, StatusPickedUp, false},
+		{TypeDelivery, StatusScheduled, StatusDelivered, false},
+		{TypeDelivery, StatusFailed, StatusScheduled, true}, // dijadwalkan ulang
+		{TypeDelivery, StatusFailed, StatusOnTheWay, false},
+		{TypePickup, StatusPickedUp, StatusOnTheWay, false},
+		{TypePickup, StatusPickedUp, StatusFailed, false},
+		{TypeDelivery, StatusDelivered, StatusFailed, false},
+		{TypeDelivery, StatusDelivered, StatusScheduled, false},
+		{TypeDelivery, StatusScheduled, "unknown", false},
+	}
+	for _, tt := range tests {
+		j := &Job{Type: tt.typ, Status: tt.from}
+		if got := j.CanMoveTo(tt.to); got != tt.want {
+			t.Errorf("%s %s -> %s: CanMoveTo = %v, want %v", tt.typ, tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"sumunar-pos-core/pkg/db"
+	"sumunar-pos-core/pkg/money"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type DeliveryRepository interface {
+	Create(ctx context.Context, tx db.DBTX, j *Job) error
+	FindByID(ctx context.Context, id string) (*Job, error)
+	FindForUpdate(ctx context.Context, tx db.DBTX, id string) (*Job, error)
+	FindAll(ctx context.Context, filter *JobFilter) ([]*Job, int, error)
+	FindCourierJobs(ctx context.Context, courierID, storeID string, from, to time.Time) ([]*Job, error)
+	Update(ctx context.Context, tx db.DBTX, j *Job) error
+	UpdateStatus(ctx context.Context, tx db.DBTX, j *Job) error
+	Delete(ctx context.Context, tx db.DBTX, id string) error
+	SumOrderFees(ctx context.Context, tx db.DBTX, orderID string) (money.Amount, error)
+}
+
+type deliveryRepo struct {
+	db db.DBTX
+}
+
+func NewDeliveryRepository(db db.DBTX) DeliveryRepository {
+	return &deliveryRepo{db}
+}
+
+const jobColumns = `j.id, j.store_id, j.order_id, j.type, j.address, j.distance_km, j.window_start, j.window_end, j.fee,
+	COALESCE(j.courier_id::text, ''), j.status, j.notes, j.fail_reason, j.completed_at,
+	j.created_at, COALESCE(j.created_by::text, ''), j.updated_at, COALESCE(j.updated_by::text, ''),
+	o.invoice_number, COALESCE(c.name, ''), COALESCE(c.phone, ''), COALESCE(u.fullname, '')`
+
+const jobFrom = ` FROM delivery_jobs j
+	JOIN orders o ON o.id = j.order_id
+	LEFT JOIN customers c ON c.id = o.customer_id
+	LEFT JOIN users u ON u.id = j.courier_id`
+
+func (r *deliveryRepo) Create(ctx context.Context, tx db.DBTX, j *Job) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO delivery_jobs (id, store_id, order_id, type, address, distance_km, window_start, window_end, fee,
+			courier_id, status, notes, fail_reason, created_at, created_by, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, '')::uuid, $11, $12, $13, $14, NULLIF($15, '')::uuid, $14, NULLIF($15, '')::uuid)
+	`,
+		j.ID,
+		j.StoreID,
+		j.OrderID,
+		j.Type,
+		j.Address,
+		j.DistanceKm,
+		j.WindowStart,
+		j.WindowEnd,
+		j.Fee,
+		j.CourierID,
+		j.Status,
+		j.Notes,
+		j.FailReason,
+		j.CreatedAt,
+		j.CreatedBy,
+	)
+	return err
+}
+
+func scanJob(row pgx.Row) (*Job, error) {
+	var j Job
+	err := row.Scan(
+		&j.ID,
+		&j.StoreID,
+		&j.OrderID,
+		&j.Type,
+		&j.Address,
+		&j.DistanceKm,
+		&j.WindowStart,
+		&j.WindowEnd,
+		&j.Fee,
+		&j.CourierID,
+		&j.Status,
+		&j.Notes,
+		&j.FailReason,
+		&j.CompletedAt,
+		&j.CreatedAt,
+		&j.CreatedBy,
+		&j.UpdatedAt,
+		&j.UpdatedBy,
+		&j.InvoiceNumber,
+		&j.CustomerName,
+		&j.CustomerPhone,
+		&j.CourierName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+func scanJobs(rows pgx.Rows) ([]*Job, error) {
+	defer rows.Close()
+
+	jobs := []*Job{}
+	for rows.Next() {
+		j, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, rows.Err()
+}
+
+func (r *deliveryRepo) FindByID(ctx context.Context, id string) (*Job, error) {
+	j, err := scanJob(r.db.QueryRow(ctx, `SELECT `+jobColumns+jobFrom+` WHERE j.id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrJobNotFound
+	}
+	return j, err
+}
+
+// FindForUpdate reads the job and locks it until tx ends.
+func (r *deliveryRepo) FindForUpdate(ctx context.Context, tx db.DBTX, id string) (*Job, error) {
+	j, err := scanJob(tx.QueryRow(ctx, `SELECT `+jobColumns+jobFrom+` WHERE j.id = $1 FOR UPDATE OF j`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrJobNotFound
+	}
+	return j, err
+}
+
+func (f *JobFilter) where() (string, []any) {
+	var conds []string
+	args := []any{}
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.StoreID != "" {
+		add("j.store_id = $%d", f.StoreID)
+	}
+	if f.OrderID != "" {
+		add("j.order_id = $%d", f.OrderID)
+	}
+	if f.CourierID != "" {
+		add("j.courier_id = $%d", f.CourierID)
+	}
+	if f.Status != "" {
+		add("j.status = $%d", f.Status)
+	}
+	if !f.From.IsZero() {
+		add("j.window_start >= $%d", f.From)
+	}
+	if !f.To.IsZero() {
+		add("j.window_start < $%d", f.To)
+	}
+
+	if len(conds) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+func (r *deliveryRepo) FindAll(ctx context.Context, filter *JobFilter) ([]*Job, int, error) {
+	where, args := filter.where()
+	query := `SELECT ` + jobColumns + jobFrom + where +
+		fmt.Sprintf(` ORDER BY j.window_start, j.created_at LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+
+	rows, err := r.db.Query(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	jobs, err := scanJobs(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM delivery_jobs j`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	return jobs, total, nil
+}
+
+// FindCourierJobs returns the courier's jobs of a store with the window starting in [from, to), plus
+// earlier jobs still scheduled or on the way so nothing overdue drops off the list.
+func (r *deliveryRepo) FindCourierJobs(ctx context.Context, courierID, storeID string, from, to time.Time) ([]*Job, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+jobColumns+jobFrom+`
+		WHERE j.courier_id = $1 AND j.store_id = $2 AND j.window_start < $4
+			AND (j.window_start >= $3 OR j.status IN ('scheduled', 'on_the_way'))
+		ORDER BY j.window_start, j.created_at
+	`, courierID, storeID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return scanJobs(rows)
+}
+
+func (r *deliveryRepo) Update(ctx context.Context, tx db.DBTX, j *Job) error {
+	tag, err := tx.Exec(ctx, `
+		UPDATE delivery_jobs SET address = $1, distance_km = $2, window_start = $3, window_end = $4, fee = $5,
+			courier_id = NULLIF($6, '')::uuid, notes = $7, updated_at = $8, updated_by = NULLIF($9, '')::uuid
+		WHERE id = $10
+	`,
+		j.Address,
+		j.DistanceKm,
+		j.WindowStart,
+		j.WindowEnd,
+		j.Fee,
+		j.CourierID,
+		j.Notes,
+		j.UpdatedAt,
+		j.UpdatedBy,
+		j.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrJobNotFound
+	}
+	return nil
+}
+
+func (r *deliveryRepo) UpdateStatus(ctx context.Context, tx db.DBTX, j *Job) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE delivery_jobs SET status = $1, fail_reason = $2, completed_at = $3, updated_at = $4, updated_by = NULLIF($5, '')::uuid
+		WHERE id = $6
+	`, j.Status, j.FailReason, j.CompletedAt, j.UpdatedAt, j.UpdatedBy, j.ID)
+	return err
+}
+
+func (r *deliveryRepo) Delete(ctx context.Context, tx db.DBTX, id string) error {
+	_, err := tx.Exec(ctx, `DELETE FROM delivery_jobs WHERE id = $1`, id)
+	return err
+}
+
+// SumOrderFees is the delivery fee of an order: the fees of all its jobs.
+func (r *deliveryRepo) SumOrderFees(ctx context.Context, tx db.DBTX, orderID string) (money.Amount, error) {
+	var total money.Amount
+	err := tx.QueryRow(ctx, `SELECT COALESCE(SUM(fee), 0) FROM delivery_jobs WHERE order_id = $1`, orderID).Scan(&total)
+	return total, err
+}
@@ -0,0 +1,349 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"sumunar-pos-core/internal/customer"
+	"sumunar-pos-core/internal/delivery/dto"
+	"sumunar-pos-core/internal/enum"
+	"sumunar-pos-core/internal/order"
+	"sumunar-pos-core/internal/store"
+	"sumunar-pos-core/internal/user"
+	"sumunar-pos-core/internal/userstore"
+	"sumunar-pos-core/pkg/db"
+	"sumunar-pos-core/pkg/money"
+)
+
+// FeeCharger puts the sum of an order's job fees on the order, inside the job's transaction.
+// The order service implements it.
+type FeeCharger interface {
+	SetDeliveryFee(ctx context.Context, tx db.DBTX, orderID string, fee money.Amount, by string) error
+}
+
+type DeliveryService interface {
+	Create(ctx context.Context, req *dto.JobRequest, userID string) (*Job, error)
+	FindByID(ctx context.Context, id, userID, role string) (*Job, error)
+	FindAll(ctx context.Context, req *dto.JobListRequest) ([]*Job, int, error)
+	Update(ctx context.Context, id string, req *dto.JobUpdateRequest, userID string) (*Job, error)
+	Delete(ctx context.Context, id, userID string) error
+	UpdateStatus(ctx context.Context, id string, req *dto.JobStatusRequest, userID, role string) (*Job, error)
+	CourierJobs(ctx context.Context, courierID string, at time.Time) ([]*Job, error)
+	Fee(ctx context.Context, storeID string, distance float64) (money.Amount, error)
+}
+
+type service struct {
+	repo          DeliveryRepository
+	orderRepo     order.OrderRepository
+	customerRepo  customer.CustomerRepository
+	storeRepo     store.StoreRepository
+	userRepo      user.UserRepository
+	userStoreRepo userstore.UserStoreRepository
+	charger       FeeCharger
+	db            db.TxBeginner
+}
+
+func NewService(repo DeliveryRepository, orderRepo order.OrderRepository, customerRepo customer.CustomerRepository,
+	storeRepo store.StoreRepository, userRepo user.UserRepository, userStoreRepo userstore.UserStoreRepository,
+	charger FeeCharger, db db.TxBeginner) DeliveryService {
+	return &service{repo, orderRepo, customerRepo, storeRepo, userRepo, userStoreRepo, charger, db}
+}
+
+func (s *service) Create(ctx context.Context, req *dto.JobRequest, userID string) (*Job, error) {
+	o, _, err := s.orderRepo.FindByID(ctx, req.OrderID)
+	if err != nil {
+		if errors.Is(err, order.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	if o.Status == enum.OrderStatusCancelled {
+		return nil, ErrOrderCancelled
+	}
+
+	j, err := ToJobModel(req, o.StoreID, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if j.Address == "" {
+		cust, err := s.customerRepo.FindByID(ctx, o.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+		if j.Address = cust.Address; j.Address == "" {
+			return nil, ErrAddressRequired
+		}
+	}
+	if err := s.resolve(ctx, j, req.Fee); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.repo.Create(ctx, tx, j); err != nil {
+		return nil, err
+	}
+	if err := s.chargeOrder(ctx, tx, j.OrderID, userID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(ctx, j.ID)
+}
+
+// FindByID returns a job; couriers only see the jobs assigned to them.
+func (s *service) FindByID(ctx context.Context, id, userID, role string) (*Job, error) {
+	j, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if role == RoleCourier && j.CourierID != userID {
+		return nil, ErrNotAssignedToCourier
+	}
+	return j, nil
+}
+
+func (s *service) FindAll(ctx context.Context, req *dto.JobListRequest) ([]*Job, int, error) {
+	filter := &JobFilter{
+		StoreID:   req.StoreID,
+		OrderID:   req.OrderID,
+		CourierID: req.CourierID,
+		Status:    req.Status,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+	}
+	if req.Date != "" {
+		if req.StoreID == "" {
+			return nil, 0, ErrInvalidFilter
+		}
+		settings, err := s.storeRepo.FindSettings(ctx, req.StoreID)
+		if err != nil {
+			return nil, 0, err
+		}
+		day, err := settings.ParseBusinessDay(req.Date)
+		if err != nil {
+			return nil, 0, ErrInvalidFilter
+		}
+		filter.From, filter.To = day.Start, day.End
+	}
+	return s.repo.FindAll(ctx, filter)
+}
+
+func (s *service) Update(ctx context.Context, id string, req *dto.JobUpdateRequest, userID string) (*Job, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	j, err := s.repo.FindForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !j.Editable() {
+		return nil, ErrJobNotEditable
+	}
+
+	prevAddress := j.Address
+	if err := applyRequest(j, req); err != nil {
+		return nil, err
+	}
+	if j.Address == "" {
+		j.Address = prevAddress
+	}
+	if err := s.resolve(ctx, j, req.Fee); err != nil {
+		return nil, err
+	}
+	j.UpdatedAt = time.Now()
+	j.UpdatedBy = userID
+
+	if err := s.repo.Update(ctx, tx, j); err != nil {
+		return nil, err
+	}
+	if err := s.chargeOrder(ctx, tx, j.OrderID, userID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(ctx, j.ID)
+}
+
+// Delete removes a scheduled or failed job and takes its fee off the order.
+func (s *service) Delete(ctx context.Context, id, userID string) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	j, err := s.repo.FindForUpdate(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if !j.Editable() {
+		return ErrJobNotEditable
+	}
+
+	if err := s.repo.Delete(ctx, tx, j.ID); err != nil {
+		return err
+	}
+	if err := s.chargeOrder(ctx, tx, j.OrderID, userID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// UpdateStatus moves a job along scheduled → on_the_way → picked_up/delivered; scheduled and on_the_way
+// jobs can fail, failed jobs can be scheduled again. Couriers may only move their own jobs.
+func (s *service) UpdateStatus(ctx context.Context, id string, req *dto.JobStatusRequest, userID, role string) (*Job, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	j, err := s.repo.FindForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if role == RoleCourier && j.CourierID != userID {
+		return nil, ErrNotAssignedToCourier
+	}
+	if !j.CanMoveTo(req.Status) {
+		return nil, ErrInvalidStatus
+	}
+
+	now := time.Now()
+	j.Status = req.Status
+	j.FailReason = ""
+	j.CompletedAt = nil
+	switch req.Status {
+	case StatusFailed:
+		if req.Reason == "" {
+			return nil, ErrFailReasonRequired
+		}
+		j.FailReason = req.Reason
+	case StatusPickedUp, StatusDelivered:
+		j.CompletedAt = &now
+	}
+	j.UpdatedAt = now
+	j.UpdatedBy = userID
+
+	if err := s.repo.UpdateStatus(ctx, tx, j); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// CourierJobs is the courier's list for the day: per store the courier works at, the jobs of the
+// store's business day at the given time plus overdue jobs not done yet.
+func (s *service) CourierJobs(ctx context.Context, courierID string, at time.Time) ([]*Job, error) {
+	storeIDs, err := s.userStoreRepo.FindStoresByUserID(ctx, courierID)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := []*Job{}
+	for _, storeID := range storeIDs {
+		settings, err := s.storeRepo.FindSettings(ctx, storeID)
+		if err != nil {
+			return nil, err
+		}
+		day := settings.BusinessDayOf(at)
+		storeJobs, err := s.repo.FindCourierJobs(ctx, courierID, storeID, day.Start, day.End)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, storeJobs...)
+	}
+	return jobs, nil
+}
+
+// Fee quotes the store's delivery fee for a distance in km.
+func (s *service) Fee(ctx context.Context, storeID string, distance float64) (money.Amount, error) {
+	settings, err := s.storeRepo.FindSettings(ctx, storeID)
+	if err != nil {
+		return 0, err
+	}
+	fee, ok := settings.DeliveryFee(distance)
+	if !ok {
+		return 0, ErrOutsideDeliveryArea
+	}
+	return fee, nil
+}
+
+// resolve checks the courier and sets the fee: override when given, else from the store's rates.
+func (s *service) resolve(ctx context.Context, j *Job, override *money.Amount) error {
+	if err := s.checkCourier(ctx, j.StoreID, j.CourierID); err != nil {
+		return err
+	}
+	if override != nil {
+		j.Fee = *override
+		return nil
+	}
+	fee, err := s.Fee(ctx, j.StoreID, j.DistanceKm)
+	if err != nil {
+		return err
+	}
+	j.Fee = fee
+	return nil
+}
+
+// checkCourier accepts an empty courier (not assigned yet) or an active courier or worker of the store.
+func (s *service) checkCourier(ctx context.Context, storeID, courierID string) error {
+	if courierID == "" {
+		return nil
+	}
+	u, err := s.userRepo.FindByID(ctx, courierID)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return ErrInvalidCourier
+		}
+		return err
+	}
+	if !u.IsActive || (u.Role != RoleCourier && u.Role != "worker") {
+		return ErrInvalidCourier
+	}
+
+	storeIDs, err := s.userStoreRepo.FindStoresByUserID(ctx, courierID)
+	if err != nil {
+		return err
+	}
+	for _, id := range storeIDs {
+		if id == storeID {
+			return nil
+		}
+	}
+	return ErrInvalidCourier
+}
+
+// chargeOrder puts the fees of all the order's jobs on the order. The order is locked before summing
+// so concurrent job changes of the same order see each other's fees, and its status is checked under
+// that lock: the fee of a cancelled or taken order no longer changes.
+func (s *service) chargeOrder(ctx context.Context, tx db.DBTX, orderID, by string) error {
+	o, err := s.orderRepo.FindByIDForUpdate(ctx, tx, orderID)
+	if err != nil {
+		return err
+	}
+	switch o.Status {
+	case enum.OrderStatusCancelled:
+		return ErrOrderCancelled
+	case enum.OrderStatusTaken:
+		return ErrOrderTaken
+	}
+	fee, err := s.repo.SumOrderFees(ctx, tx, orderID)
+	if err != nil {
+		return err
+	}
+	return s.charger.SetDeliveryFee(ctx, tx, orderID, fee, by)
+}
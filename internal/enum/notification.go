@@ -0,0 +1,22 @@
+package enum
+
+const (
+	NotificationChannelWhatsApp = "whatsapp"
+	NotificationChannelSMS      = "sms"
+	NotificationChannelEmail    = "email"
+)
+
+const (
+	NotificationStatusPending = "pending"
+	NotificationStatusSent    = "sent"
+	NotificationStatusFailed  = "failed"
+)
+
+// Notification events, one per point in the order lifecycle a customer can be told about.
+const (
+	NotificationEventOrderReceived  = "order_received"
+	NotificationEventOrderProcessed = "order_processed"
+	NotificationEventOrderReady     = "order_ready"
+	NotificationEventOrderTaken     = "order_taken"
+	NotificationEventOrderCancelled = "order_cancelled"
+)
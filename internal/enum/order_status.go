@@ -5,4 +5,5 @@ const (
 	OrderStatusProcessed = "processed"
 	OrderStatusDone      = "done"
 	OrderStatusTaken     = "taken"
+	OrderStatusCancelled = "cancelled"
 )
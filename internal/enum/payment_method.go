@@ -0,0 +1,6 @@
+package enum
+
+const (
+	PaymentMethodCash    = "cash"
+	PaymentMethodPrepaid = "prepaid" // potong kuota/saldo paket prabayar pelanggan, dibuat otomatis per toko
+)
@@ -0,0 +1,8 @@
+package enum
+
+const (
+	PaymentStatusUnpaid   = "unpaid"
+	PaymentStatusPartial  = "partial"
+	PaymentStatusPaid     = "paid"
+	PaymentStatusOverpaid = "overpaid"
+)
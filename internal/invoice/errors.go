@@ -0,0 +1,9 @@
+package invoice
+
+import "errors"
+
+var (
+	ErrStoreNotFound    = errors.New("store not found")
+	ErrCustomerNotFound = errors.New("customer not found")
+	ErrInvalidDateRange = errors.New("from and to must be dates in YYYY-MM-DD format with from <= to")
+)
@@ -0,0 +1,56 @@
+package invoice
+
+import (
+	"errors"
+	"net/http"
+
+	"sumunar-pos-core/internal/order"
+
+	"github.com/labstack/echo/v4"
+)
+
+const mimePDF = "application/pdf"
+
+type Handler struct {
+	service InvoiceService
+}
+
+func NewHandler(service InvoiceService) *Handler {
+	return &Handler{service}
+}
+
+// OrderInvoice returns the A4 invoice of an order as PDF.
+func (h *Handler) OrderInvoice(c echo.Context) error {
+	data, o, err := h.service.OrderInvoice(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `inline; filename="`+o.InvoiceNumber+`.pdf"`)
+	return c.Blob(http.StatusOK, mimePDF, data)
+}
+
+// CustomerStatement returns a customer's orders as PDF, ?store_id=&customer_id=&from=YYYY-MM-DD&to=YYYY-MM-DD.
+func (h *Handler) CustomerStatement(c echo.Context) error {
+	customerID := c.QueryParam("customer_id")
+	from, to := c.QueryParam("from"), c.QueryParam("to")
+
+	data, err := h.service.CustomerStatement(c.Request().Context(), c.QueryParam("store_id"), customerID, from, to)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `inline; filename="statement-`+from+`-`+to+`.pdf"`)
+	return c.Blob(http.StatusOK, mimePDF, data)
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, order.ErrOrderNotFound), errors.Is(err, ErrStoreNotFound), errors.Is(err, ErrCustomerNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidDateRange):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,34 @@
+package invoice
+
+import (
+	"sumunar-pos-core/internal/customer"
+	"sumunar-pos-core/internal/order"
+	"sumunar-pos-core/internal/store"
+	"sumunar-pos-core/pkg/money"
+)
+
+// Invoice is the data rendered on a single-order A4 invoice.
+type Invoice struct {
+	Store    *store.Store
+	Customer *customer.Customer
+	Order    *order.Order
+	Items    []*order.OrderItem
+	Payments []*order.OrderPayment
+	Summary  []SummaryLine
+}
+
+// SummaryLine is one row of the totals block under the item table.
+type SummaryLine struct {
+	Label  string
+	Amount money.Amount
+	Bold   bool
+}
+
+// Statement lists a customer's orders at one store over a period.
+type Statement struct {
+	Store    *store.Store
+	Customer *customer.Customer
+	From     string
+	To       string
+	Orders   []*order.Order
+}
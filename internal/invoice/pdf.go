@@ -0,0 +1,315 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"sumunar-pos-core/internal/addon"
+	"sumunar-pos-core/internal/customer"
+	"sumunar-pos-core/internal/enum"
+	"sumunar-pos-core/internal/order"
+	"sumunar-pos-core/internal/store"
+	"sumunar-pos-core/pkg/money"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+const (
+	pageMargin = 15.0
+	pageWidth  = 180.0 // lebar A4 dikurangi margin kiri dan kanan
+	lineHeight = 6.0
+	printDate  = "02/01/2006"
+)
+
+// document wraps gofpdf with the translator for the core fonts, which only cover cp1252.
+type document struct {
+	pdf *gofpdf.Fpdf
+	tr  func(string) string
+}
+
+func newDocument() *document {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pageMargin, pageMargin, pageMargin)
+	pdf.SetAutoPageBreak(true, pageMargin+5)
+	pdf.AliasNbPages("")
+
+	d := &document{pdf: pdf, tr: pdf.UnicodeTranslatorFromDescriptor("")}
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pageMargin)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(0, 5, fmt.Sprintf("Halaman %d/{nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+	return d
+}
+
+func (d *document) output() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := d.pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func renderInvoice(inv *Invoice) ([]byte, error) {
+	d := newDocument()
+	pdf := d.pdf
+	o := inv.Order
+
+	info := []string{
+		"No: " + o.InvoiceNumber,
+		"Tanggal: " + o.CreatedAt.Format(printDate),
+	}
+	if !o.PickupDate.IsZero() {
+		info = append(info, "Tanggal Ambil: "+o.PickupDate.Format(printDate))
+	}
+	d.header(inv.Store, "INVOICE", info)
+	d.customerBlock(inv.Customer)
+
+	widths := []float64{10, 80, 25, 30, 35}
+	aligns := []string{"C", "L", "R", "R", "R"}
+	d.tableHeader(widths, []string{"No", "Item", "Qty", "Harga", "Jumlah"})
+	for i, item := range inv.Items {
+		name := item.Name()
+		if item.Notes != "" {
+			name += "\n" + item.Notes
+		}
+		if item.ChargedQuantity != item.Quantity {
+			name += "\nAktual " + formatQuantity(item.Quantity) + " " + item.Unit
+		}
+		if item.MinChargeApplied() {
+			name += "\nTagihan minimum"
+		}
+		if item.ExpressTier != "" {
+			name += "\nExpress " + item.ExpressTier + " " + formatPercent(item.ExpressRate) + " " + item.ExpressSurcharge.Format()
+		}
+		for _, a := range item.Addons {
+			name += "\n+ " + addonLabel(a) + " " + a.Amount.Format()
+		}
+		if item.DiscountAmount > 0 {
+			name += "\nDiskon -" + item.DiscountAmount.Format()
+		}
+		d.tableRow(widths, aligns, []string{
+			strconv.Itoa(i + 1),
+			name,
+			formatQuantity(item.ChargedQuantity) + " " + item.Unit,
+			item.UnitPrice.Format(),
+			item.TotalPrice.Format(),
+		})
+	}
+
+	pdf.Ln(2)
+	for _, line := range inv.Summary {
+		style := ""
+		if line.Bold {
+			style = "B"
+		}
+		pdf.SetFont("Helvetica", style, 10)
+		pdf.SetX(pageMargin + pageWidth - 80)
+		pdf.CellFormat(45, lineHeight, d.tr(line.Label), "", 0, "L", false, 0, "")
+		pdf.CellFormat(35, lineHeight, line.Amount.Format(), "", 1, "R", false, 0, "")
+	}
+
+	pdf.Ln(4)
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(0, lineHeight, "Status Pembayaran: "+paymentStatusLabel(order.PaymentStatus(o.TotalPrice, o.PaidAmount)), "", 1, "L", false, 0, "")
+	if len(inv.Payments) > 0 {
+		widths := []float64{40, 40, 60, 40}
+		aligns := []string{"L", "L", "L", "R"}
+		d.tableHeader(widths, []string{"Tanggal", "Metode", "Referensi", "Jumlah"})
+		for _, p := range inv.Payments {
+			d.tableRow(widths, aligns, []string{
+				p.ReceivedAt.Format(printDate),
+				p.Method,
+				p.Reference,
+				p.AppliedAmount().Format(),
+			})
+		}
+	}
+
+	return d.output()
+}
+
+func renderStatement(st *Statement) ([]byte, error) {
+	d := newDocument()
+	pdf := d.pdf
+
+	d.header(st.Store, "REKAP TAGIHAN", []string{
+		"Periode: " + st.From + " s/d " + st.To,
+		"Dicetak: " + time.Now().Format(printDate),
+	})
+	d.customerBlock(st.Customer)
+
+	widths := []float64{25, 50, 30, 25, 25, 25}
+	aligns := []string{"L", "L", "L", "R", "R", "R"}
+	d.tableHeader(widths, []string{"Tanggal", "No Invoice", "Status", "Total", "Dibayar", "Sisa"})
+
+	var total, paid, outstanding money.Amount
+	for _, o := range st.Orders {
+		due := order.OutstandingAmount(o.TotalPrice, o.PaidAmount)
+		total += o.TotalPrice
+		paid += o.PaidAmount
+		outstanding += due
+		d.tableRow(widths, aligns, []string{
+			o.CreatedAt.Format(printDate),
+			o.InvoiceNumber,
+			paymentStatusLabel(order.PaymentStatus(o.TotalPrice, o.PaidAmount)),
+			o.TotalPrice.Format(),
+			o.PaidAmount.Format(),
+			due.Format(),
+		})
+	}
+	if len(st.Orders) == 0 {
+		pdf.SetFont("Helvetica", "I", 9)
+		pdf.CellFormat(pageWidth, lineHeight, "Tidak ada transaksi pada periode ini", "1", 1, "C", false, 0, "")
+	}
+
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.CellFormat(widths[0]+widths[1]+widths[2], lineHeight, "Total", "1", 0, "R", false, 0, "")
+	pdf.CellFormat(widths[3], lineHeight, total.Format(), "1", 0, "R", false, 0, "")
+	pdf.CellFormat(widths[4], lineHeight, paid.Format(), "1", 0, "R", false, 0, "")
+	pdf.CellFormat(widths[5], lineHeight, outstanding.Format(), "1", 1, "R", false, 0, "")
+
+	return d.output()
+}
+
+// header draws the store logo and contact on the left and the document title with info lines on the right.
+func (d *document) header(st *store.Store, title string, info []string) {
+	pdf := d.pdf
+	textX := pageMargin
+
+	if st.Logo != nil {
+		if w := d.logo(strings.TrimPrefix(*st.Logo, "/"), 20); w > 0 {
+			textX += w + 4
+		}
+	}
+
+	pdf.SetXY(textX, pageMargin)
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(90, 7, d.tr(st.Name), "", 2, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.MultiCell(90, 4.5, d.tr(st.Address), "", "L", false)
+	if st.Phone != nil {
+		pdf.SetX(textX)
+		pdf.CellFormat(90, 4.5, d.tr("Telp. "+*st.Phone), "", 1, "L", false, 0, "")
+	}
+	leftBottom := pdf.GetY()
+
+	pdf.SetXY(pageMargin+pageWidth-70, pageMargin)
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(70, 9, title, "", 2, "R", false, 0, "")
+	pdf.SetFont("Helvetica", "", 9)
+	for _, line := range info {
+		pdf.CellFormat(70, 4.5, d.tr(line), "", 2, "R", false, 0, "")
+	}
+
+	y := max(pdf.GetY(), leftBottom, pageMargin+20) + 3
+	pdf.Line(pageMargin, y, pageMargin+pageWidth, y)
+	pdf.SetXY(pageMargin, y+4)
+}
+
+// logo draws the image at the top-left with the given height and returns its width,
+// or 0 when the file is missing or cannot be decoded.
+func (d *document) logo(path string, height float64) float64 {
+	if _, err := os.Stat(path); err != nil {
+		return 0
+	}
+
+	opt := gofpdf.ImageOptions{ImageType: strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), "."), ReadDpi: true}
+	info := d.pdf.RegisterImageOptions(path, opt)
+	if !d.pdf.Ok() || info == nil {
+		d.pdf.ClearError()
+		return 0
+	}
+
+	width := info.Width() * height / info.Height()
+	d.pdf.ImageOptions(path, pageMargin, pageMargin, width, height, false, opt, 0, "")
+	return width
+}
+
+func (d *document) customerBlock(c *customer.Customer) {
+	pdf := d.pdf
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(0, lineHeight, "Ditagihkan kepada:", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 5, d.tr(c.Name), "", 1, "L", false, 0, "")
+	if c.Phone != "" {
+		pdf.CellFormat(0, 5, d.tr(c.Phone), "", 1, "L", false, 0, "")
+	}
+	if c.Address != "" {
+		pdf.MultiCell(100, 5, d.tr(c.Address), "", "L", false)
+	}
+	pdf.Ln(4)
+}
+
+func (d *document) tableHeader(widths []float64, titles []string) {
+	pdf := d.pdf
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetFillColor(230, 230, 230)
+	for i, title := range titles {
+		pdf.CellFormat(widths[i], 7, title, "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+// tableRow draws one bordered row; cells wrap and the row takes the height of the tallest cell.
+func (d *document) tableRow(widths []float64, aligns []string, cells []string) {
+	pdf := d.pdf
+	pdf.SetFont("Helvetica", "", 9)
+
+	rows := 1
+	for i, cell := range cells {
+		if n := len(pdf.SplitLines([]byte(d.tr(cell)), widths[i]-2)); n > rows {
+			rows = n
+		}
+	}
+	height := float64(rows) * 5
+
+	_, pageHeight := pdf.GetPageSize()
+	if pdf.GetY()+height > pageHeight-pageMargin-5 {
+		pdf.AddPage()
+	}
+
+	x, y := pdf.GetXY()
+	for i, cell := range cells {
+		pdf.Rect(x, y, widths[i], height, "D")
+		pdf.MultiCell(widths[i], 5, d.tr(cell), "", aligns[i], false)
+		x += widths[i]
+		pdf.SetXY(x, y)
+	}
+	pdf.SetXY(pageMargin, y+height)
+}
+
+func paymentStatusLabel(status string) string {
+	switch status {
+	case enum.PaymentStatusPaid:
+		return "LUNAS"
+	case enum.PaymentStatusPartial:
+		return "DIBAYAR SEBAGIAN"
+	case enum.PaymentStatusOverpaid:
+		return "LEBIH BAYAR"
+	default:
+		return "BELUM DIBAYAR"
+	}
+}
+
+// addonLabel, misal "Hanger x3"; add-on per unit cukup namanya karena ikut kuantitas item.
+func addonLabel(a *order.OrderItemAddon) string {
+	if a.PriceType == addon.PriceFixed && a.Quantity != 1 {
+		return a.Name + " x" + formatQuantity(a.Quantity)
+	}
+	return a.Name
+}
+
+func formatQuantity(q float64) string {
+	return strings.Replace(strconv.FormatFloat(q, 'f', -1, 64), ".", ",", 1)
+}
+
+func formatPercent(p float64) string {
+	return "(" + formatQuantity(p) + "%)"
+}
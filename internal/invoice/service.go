@@ -0,0 +1,139 @@
+package invoice
+
+import (
+	"context"
+	"fmt"
+
+	"sumunar-pos-core/internal/customer"
+	"sumunar-pos-core/internal/order"
+	"sumunar-pos-core/internal/store"
+)
+
+type InvoiceService interface {
+	OrderInvoice(ctx context.Context, orderID string) ([]byte, *order.Order, error)
+	CustomerStatement(ctx context.Context, storeID, customerID, from, to string) ([]byte, error)
+}
+
+type service struct {
+	orderRepo    order.OrderRepository
+	customerRepo customer.CustomerRepository
+	storeRepo    store.StoreRepository
+}
+
+func NewService(orderRepo order.OrderRepository, customerRepo customer.CustomerRepository, storeRepo store.StoreRepository) InvoiceService {
+	return &service{orderRepo, customerRepo, storeRepo}
+}
+
+// OrderInvoice renders the A4 invoice PDF of one order.
+func (s *service) OrderInvoice(ctx context.Context, orderID string) ([]byte, *order.Order, error) {
+	o, items, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	st, err := s.storeRepo.FindByID(ctx, o.StoreID)
+	if err != nil {
+		return nil, nil, ErrStoreNotFound
+	}
+	c, err := s.customerRepo.FindByID(ctx, o.CustomerID)
+	if err != nil {
+		return nil, nil, ErrCustomerNotFound
+	}
+
+	payments, err := s.orderRepo.FindPayments(ctx, o.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inv := &Invoice{
+		Store:    st,
+		Customer: c,
+		Order:    o,
+		Items:    items,
+		Payments: payments,
+	}
+	inv.Summary = summaryLines(inv)
+
+	data, err := renderInvoice(inv)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, o, nil
+}
+
+// CustomerStatement renders the orders of a customer at a store between two dates (inclusive).
+func (s *service) CustomerStatement(ctx context.Context, storeID, customerID, from, to string) ([]byte, error) {
+	st, err := s.storeRepo.FindByID(ctx, storeID)
+	if err != nil {
+		return nil, ErrStoreNotFound
+	}
+	settings, err := s.storeRepo.FindSettings(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := settings.BusinessRange(from, to)
+	if err != nil {
+		return nil, ErrInvalidDateRange
+	}
+	c, err := s.customerRepo.FindByID(ctx, customerID)
+	if err != nil {
+		return nil, ErrCustomerNotFound
+	}
+
+	orders, err := s.orderRepo.FindByCustomer(ctx, storeID, customerID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderStatement(&Statement{
+		Store:    st,
+		Customer: c,
+		From:     from,
+		To:       to,
+		Orders:   orders,
+	})
+}
+
+// summaryLines builds the totals block: subtotal, discount, promo, tax, cash rounding and the amount due.
+func summaryLines(inv *Invoice) []SummaryLine {
+	o := inv.Order
+	lines := []SummaryLine{{Label: "Subtotal", Amount: o.Subtotal}}
+	if o.Discount > 0 {
+		lines = append(lines, SummaryLine{Label: "Diskon " + formatPercent(o.Discount), Amount: o.DiscountAmount.Neg()})
+	} else if o.DiscountAmount > 0 {
+		lines = append(lines, SummaryLine{Label: "Diskon", Amount: o.DiscountAmount.Neg()})
+	}
+	if o.PromoDiscount > 0 {
+		lines = append(lines, SummaryLine{Label: "Promo " + o.PromoCode, Amount: o.PromoDiscount.Neg()})
+	}
+	if o.TierDiscountAmount > 0 {
+		lines = append(lines, SummaryLine{Label: "Member " + o.TierName + " " + formatPercent(o.TierDiscount), Amount: o.TierDiscountAmount.Neg()})
+	}
+	if o.PointsDiscount > 0 {
+		lines = append(lines, SummaryLine{Label: fmt.Sprintf("Tukar %d poin", o.PointsRedeemed), Amount: o.PointsDiscount.Neg()})
+	}
+	if o.TaxRate > 0 && !o.TaxInclusive {
+		lines = append(lines,
+			SummaryLine{Label: "DPP", Amount: o.TaxBase},
+			SummaryLine{Label: "PPN " + formatPercent(o.TaxRate), Amount: o.TaxAmount},
+		)
+	}
+	if o.DeliveryFee > 0 {
+		lines = append(lines, SummaryLine{Label: "Antar-jemput", Amount: o.DeliveryFee})
+	}
+	if o.Rounding != 0 {
+		lines = append(lines, SummaryLine{Label: "Pembulatan", Amount: o.Rounding})
+	}
+	lines = append(lines, SummaryLine{Label: "Total", Amount: o.TotalPrice, Bold: true})
+	if o.TaxRate > 0 && o.TaxInclusive {
+		lines = append(lines,
+			SummaryLine{Label: "Termasuk PPN " + formatPercent(o.TaxRate), Amount: o.TaxAmount},
+			SummaryLine{Label: "DPP", Amount: o.TaxBase},
+		)
+	}
+	lines = append(lines,
+		SummaryLine{Label: "Dibayar", Amount: o.PaidAmount},
+		SummaryLine{Label: "Sisa Tagihan", Amount: order.OutstandingAmount(o.TotalPrice, o.PaidAmount), Bold: true},
+	)
+	return lines
+}
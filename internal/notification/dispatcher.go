@@ -0,0 +1,143 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"sumunar-pos-core/internal/enum"
+	"sumunar-pos-core/pkg/db"
+)
+
+// DispatcherConfig controls how often the outbox is polled and how failures are retried.
+type DispatcherConfig struct {
+	Interval    time.Duration // jeda antar polling outbox
+	BatchSize   int
+	MaxAttempts int           // setelah ini status menjadi failed
+	BaseBackoff time.Duration // jeda retry pertama, berlipat dua setiap percobaan
+	MaxBackoff  time.Duration
+	SendTimeout time.Duration
+	Lease       time.Duration // lama batch yang diklaim tidak diambil dispatcher lain, default BatchSize*SendTimeout + 1 menit
+}
+
+// Dispatcher sends pending outbox notifications through the provider registered for their channel.
+type Dispatcher struct {
+	repo      NotificationRepository
+	db        db.DBTX
+	providers map[string]Provider
+	cfg       DispatcherConfig
+}
+
+func NewDispatcher(repo NotificationRepository, db db.DBTX, providers map[string]Provider, cfg DispatcherConfig) *Dispatcher {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 10 * time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 20
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 5
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = 30 * time.Second
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = time.Hour
+	}
+	if cfg.SendTimeout <= 0 {
+		cfg.SendTimeout = 15 * time.Second
+	}
+	if cfg.Lease <= 0 {
+		// pesan dikirim satu per satu, lease harus cukup untuk satu batch penuh yang semuanya timeout
+		cfg.Lease = time.Duration(cfg.BatchSize)*cfg.SendTimeout + time.Minute
+	}
+	return &Dispatcher{repo, db, providers, cfg}
+}
+
+// Run polls the outbox until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := d.DispatchOnce(ctx)
+			if err != nil {
+				log.Println("❌ notification dispatch:", err)
+			}
+			// batch penuh berarti masih ada antrean, lanjut tanpa menunggu tick
+			if err != nil || n < d.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce claims one batch of due notifications under a lease, then sends them outside any
+// transaction and records each outcome on its own, so one failed write does not resend the rest.
+// It returns the number of notifications claimed.
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	now := time.Now()
+	due, err := d.repo.ClaimDue(ctx, d.db, now, now.Add(d.cfg.Lease), d.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	for _, n := range due {
+		// saat berhenti, sisa batch dibiarkan sampai lease habis tanpa menambah percobaan
+		if ctx.Err() != nil {
+			errs = append(errs, ctx.Err())
+			break
+		}
+		d.deliver(ctx, n)
+		if err := d.repo.UpdateDelivery(ctx, d.db, n); err != nil {
+			errs = append(errs, fmt.Errorf("notification %s: %w", n.ID, err))
+		}
+	}
+	return len(due), errors.Join(errs...)
+}
+
+// deliver sends n and updates its status, attempts and next attempt time in place.
+func (d *Dispatcher) deliver(ctx context.Context, n *Notification) {
+	n.Attempts++
+
+	err := ErrNoProvider
+	if provider, ok := d.providers[n.Channel]; ok {
+		sendCtx, cancel := context.WithTimeout(ctx, d.cfg.SendTimeout)
+		err = provider.Send(sendCtx, &Message{Recipient: n.Recipient, Subject: n.Subject, Body: n.Body})
+		cancel()
+	}
+
+	now := time.Now()
+	if err == nil {
+		n.Status = enum.NotificationStatusSent
+		n.LastError = ""
+		n.SentAt = &now
+		return
+	}
+
+	n.LastError = fmt.Sprintf("%s: %v", n.Channel, err)
+	if n.Attempts >= d.cfg.MaxAttempts {
+		n.Status = enum.NotificationStatusFailed
+		return
+	}
+	n.NextAttemptAt = now.Add(d.backoff(n.Attempts))
+}
+
+// backoff doubles the delay after every failed attempt: base, 2*base, 4*base, ... up to MaxBackoff.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.cfg.BaseBackoff
+	for i := 1; i < attempts && delay < d.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, d.cfg.MaxBackoff)
+}
@@ -0,0 +1,229 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"sumunar-pos-core/internal/enum"
+	"sumunar-pos-core/pkg/db"
+)
+
+// memoryRepo is an in-memory outbox; only the methods the dispatcher uses are implemented.
+type memoryRepo struct {
+	NotificationRepository
+	mu         sync.Mutex
+	rows       map[string]Notification
+	failUpdate map[string]bool
+}
+
+func newMemoryRepo(rows ...Notification) *memoryRepo {
+	r := &memoryRepo{rows: map[string]Notification{}, failUpdate: map[string]bool{}}
+	for _, n := range rows {
+		r.rows[n.ID] = n
+	}
+	return r
+}
+
+func (r *memoryRepo) ClaimDue(ctx context.Context, tx db.DBTX, now, leaseUntil time.Time, limit int) ([]*Notification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var due []*Notification
+	for id, n := range r.rows {
+		if len(due) == limit {
+			break
+		}
+		if n.Status != enum.NotificationStatusPending || n.NextAttemptAt.After(now) {
+			continue
+		}
+		n.NextAttemptAt = leaseUntil
+		r.rows[id] = n
+		claimed := n
+		due = append(due, &claimed)
+	}
+	return due, nil
+}
+
+func (r *memoryRepo) UpdateDelivery(ctx context.Context, tx db.DBTX, n *Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.failUpdate[n.ID] {
+		return errors.New("connection reset")
+	}
+	r.rows[n.ID] = *n
+	return nil
+}
+
+func (r *memoryRepo) get(id string) Notification {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rows[id]
+}
+
+// makeDue moves a notification's next attempt into the past, as if its backoff had elapsed.
+func (r *memoryRepo) makeDue(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := r.rows[id]
+	n.NextAttemptAt = time.Now().Add(-time.Second)
+	r.rows[id] = n
+}
+
+func pending(id, channel string) Notification {
+	return Notification{
+		ID:            id,
+		Channel:       channel,
+		Recipient:     "6281234567890",
+		Body:          "Cucian Anda sudah selesai",
+		Status:        enum.NotificationStatusPending,
+		NextAttemptAt: time.Now().Add(-time.Minute),
+	}
+}
+
+func TestDispatchSends(t *testing.T) {
+	repo := newMemoryRepo(pending("n1", enum.NotificationChannelWhatsApp))
+	provider := &FakeProvider{}
+	d := NewDispatcher(repo, nil, map[string]Provider{enum.NotificationChannelWhatsApp: provider}, DispatcherConfig{})
+
+	count, err := d.DispatchOnce(context.Background())
+	if err != nil || count != 1 {
+		t.Fatalf("DispatchOnce = %d, %v; want 1, nil", count, err)
+	}
+
+	n := repo.get("n1")
+	if n.Status != enum.NotificationStatusSent || n.Attempts != 1 || n.SentAt == nil || n.LastError != "" {
+		t.Errorf("after send: status %s, attempts %d, sent_at %v, error %q", n.Status, n.Attempts, n.SentAt, n.LastError)
+	}
+	if sent := provider.Sent(); len(sent) != 1 || sent[0].Recipient != "6281234567890" {
+		t.Errorf("provider got %+v", sent)
+	}
+
+	// yang sudah terkirim tidak diklaim lagi
+	if count, _ := d.DispatchOnce(context.Background()); count != 0 {
+		t.Errorf("second dispatch claimed %d, want 0", count)
+	}
+}
+
+func TestDispatchRetriesWithBackoffThenFails(t *testing.T) {
+	repo := newMemoryRepo(pending("n1", enum.NotificationChannelSMS))
+	provider := &FakeProvider{Err: errors.New("gateway down")}
+	d := NewDispatcher(repo, nil, map[string]Provider{enum.NotificationChannelSMS: provider}, DispatcherConfig{
+		MaxAttempts: 3,
+		BaseBackoff: time.Minute,
+		MaxBackoff:  90 * time.Second,
+	})
+
+	wantBackoff := []time.Duration{time.Minute, 90 * time.Second}
+	for attempt, backoff := range wantBackoff {
+		before := time.Now()
+		if _, err := d.DispatchOnce(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		n := repo.get("n1")
+		if n.Status != enum.NotificationStatusPending || n.Attempts != attempt+1 {
+			t.Fatalf("attempt %d: status %s, attempts %d", attempt+1, n.Status, n.Attempts)
+		}
+		if n.LastError != "sms: gateway down" {
+			t.Errorf("attempt %d: last error %q", attempt+1, n.LastError)
+		}
+		if wait := n.NextAttemptAt.Sub(before); wait < backoff || wait > backoff+time.Second {
+			t.Errorf("attempt %d: next attempt in %v, want %v", attempt+1, wait, backoff)
+		}
+
+		// belum jatuh tempo, tidak dicoba lagi
+		if count, _ := d.DispatchOnce(context.Background()); count != 0 {
+			t.Fatalf("attempt %d: retried before backoff elapsed", attempt+1)
+		}
+		repo.makeDue("n1")
+	}
+
+	if _, err := d.DispatchOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := repo.get("n1"); n.Status != enum.NotificationStatusFailed || n.Attempts != 3 {
+		t.Errorf("after max attempts: status %s, attempts %d; want failed, 3", n.Status, n.Attempts)
+	}
+	if len(provider.Sent()) != 0 {
+		t.Errorf("failing provider recorded messages")
+	}
+}
+
+func TestDispatchUnknownChannel(t *testing.T) {
+	repo := newMemoryRepo(pending("n1", enum.NotificationChannelEmail))
+	d := NewDispatcher(repo, nil, map[string]Provider{}, DispatcherConfig{MaxAttempts: 1})
+
+	if _, err := d.DispatchOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := repo.get("n1"); n.Status != enum.NotificationStatusFailed {
+		t.Errorf("status %s, want failed", n.Status)
+	}
+}
+
+func TestDispatchRecordsEachResult(t *testing.T) {
+	repo := newMemoryRepo(pending("n1", enum.NotificationChannelWhatsApp), pending("n2", enum.NotificationChannelWhatsApp))
+	repo.failUpdate["n1"] = true
+	provider := &FakeProvider{}
+	d := NewDispatcher(repo, nil, map[string]Provider{enum.NotificationChannelWhatsApp: provider}, DispatcherConfig{Lease: time.Hour})
+
+	count, err := d.DispatchOnce(context.Background())
+	if count != 2 || err == nil {
+		t.Fatalf("DispatchOnce = %d, %v; want 2 and the failed write", count, err)
+	}
+	if n := repo.get("n2"); n.Status != enum.NotificationStatusSent {
+		t.Errorf("n2 status %s, want sent despite n1's failed write", n.Status)
+	}
+	// n1 tetap pending di bawah lease, tidak langsung dikirim ulang
+	if n := repo.get("n1"); n.Status != enum.NotificationStatusPending || n.NextAttemptAt.Before(time.Now().Add(59*time.Minute)) {
+		t.Errorf("n1: status %s, next attempt %v; want pending under lease", n.Status, n.NextAttemptAt)
+	}
+	if count, _ := d.DispatchOnce(context.Background()); count != 0 {
+		t.Errorf("leased notification claimed again")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	d := NewDispatcher(newMemoryRepo(), nil, nil, DispatcherConfig{BaseBackoff: 30 * time.Second, MaxBackoff: 2 * time.Minute})
+	want := []time.Duration{30 * time.Second, time.Minute, 2 * time.Minute, 2 * time.Minute}
+	for i, w := range want {
+		if got := d.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+}
+
+func TestSMTPSendTimesOut(t *testing.T) {
+	// server yang menerima koneksi tapi tidak pernah menyapa
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	p := &SMTPProvider{Host: host, Port: port, From: "noreply@example.com"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := p.Send(ctx, &Message{Recipient: "a@example.com", Subject: "Tes", Body: "Tes"}); err == nil {
+		t.Fatal("Send to a silent server succeeded")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Send returned after %v, want it bounded by the context", elapsed)
+	}
+}
@@ -0,0 +1,19 @@
+package dto
+
+type TemplateRequest struct {
+	StoreID  string `json:"store_id" validate:"required"`
+	Event    string `json:"event" validate:"required,oneof=order_received order_processed order_ready order_taken order_cancelled"`
+	Channel  string `json:"channel" validate:"required,oneof=whatsapp sms email"`
+	Subject  string `json:"subject"` // dipakai untuk email
+	Body     string `json:"body" validate:"required"`
+	IsActive *bool  `json:"is_active"`
+}
+
+// NotificationListRequest is the query string of the outbox list.
+type NotificationListRequest struct {
+	StoreID string `query:"store_id" validate:"omitempty,uuid"`
+	OrderID string `query:"order_id" validate:"omitempty,uuid"`
+	Status  string `query:"status" validate:"omitempty,oneof=pending sent failed"`
+	Limit   int    `query:"limit"`
+	Offset  int    `query:"offset"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+type NotificationResponse struct {
+	ID            string `json:"id"`
+	StoreID       string `json:"store_id"`
+	OrderID       string `json:"order_id,omitempty"`
+	Event         string `json:"event"`
+	Channel       string `json:"channel"`
+	Recipient     string `json:"recipient"`
+	Subject       string `json:"subject,omitempty"`
+	Body          string `json:"body"`
+	Status        string `json:"status"`
+	Attempts      int    `json:"attempts"`
+	NextAttemptAt string `json:"next_attempt_at"`
+	LastError     string `json:"last_error,omitempty"`
+	CreatedAt     string `json:"created_at"`
+	SentAt        string `json:"sent_at,omitempty"`
+}
+
+type TemplateResponse struct {
+	ID        string `json:"id"`
+	StoreID   string `json:"store_id"`
+	Event     string `json:"event"`
+	Channel   string `json:"channel"`
+	Subject   string `json:"subject"`
+	Body      string `json:"body"`
+	IsActive  bool   `json:"is_active"`
+	UpdatedAt string `json:"updated_at"`
+	UpdatedBy string `json:"updated_by,omitempty"`
+}
@@ -0,0 +1,11 @@
+package notification
+
+import "errors"
+
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+	ErrTemplateNotFound     = errors.New("notification template not found")
+	ErrInvalidTemplate      = errors.New("invalid notification template")
+	ErrNotRetryable         = errors.New("only failed notifications can be retried")
+	ErrNoProvider           = errors.New("no provider configured for channel")
+)
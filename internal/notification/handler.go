@@ -0,0 +1,104 @@
+package notification
+
+import (
+	"errors"
+	"net/http"
+
+	"sumunar-pos-core/internal/notification/dto"
+	"sumunar-pos-core/internal/store"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service NotificationService
+}
+
+func NewHandler(service NotificationService) *Handler {
+	return &Handler{service}
+}
+
+// FindAll lists the outbox, ?store_id=&order_id=&status=pending|sent|failed.
+func (h *Handler) FindAll(c echo.Context) error {
+	var req dto.NotificationListRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+
+	list, total, err := h.service.FindAll(c.Request().Context(), req.StoreID, req.OrderID, req.Status, req.Limit, req.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data":   ToNotificationListResponse(list),
+		"total":  total,
+		"limit":  req.Limit,
+		"offset": req.Offset,
+	})
+}
+
+func (h *Handler) Retry(c echo.Context) error {
+	n, err := h.service.Retry(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToNotificationResponse(n))
+}
+
+func (h *Handler) Templates(c echo.Context) error {
+	templates, err := h.service.Templates(c.Request().Context(), c.QueryParam("store_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToTemplateListResponse(templates))
+}
+
+// SaveTemplate creates the store's template for an event and channel, or replaces the existing one.
+func (h *Handler) SaveTemplate(c echo.Context) error {
+	var req dto.TemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Get("user_id").(string)
+
+	t, err := h.service.SaveTemplate(c.Request().Context(), &req, userID)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToTemplateResponse(t))
+}
+
+func (h *Handler) DeleteTemplate(c echo.Context) error {
+	if err := h.service.DeleteTemplate(c.Request().Context(), c.Param("id")); err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrNotificationNotFound), errors.Is(err, ErrTemplateNotFound), errors.Is(err, store.ErrStoreNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidTemplate):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrNotRetryable):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"time"
+
+	"sumunar-pos-core/internal/notification/dto"
+
+	"github.com/google/uuid"
+)
+
+func ToTemplateModel(req *dto.TemplateRequest, updatedBy string) *Template {
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	return &Template{
+		ID:        uuid.New().String(),
+		StoreID:   req.StoreID,
+		Event:     req.Event,
+		Channel:   req.Channel,
+		Subject:   req.Subject,
+		Body:      req.Body,
+		IsActive:  isActive,
+		UpdatedAt: time.Now(),
+		UpdatedBy: updatedBy,
+	}
+}
+
+func ToTemplateResponse(t *Template) *dto.TemplateResponse {
+	return &dto.TemplateResponse{
+		ID:        t.ID,
+		StoreID:   t.StoreID,
+		Event:     t.Event,
+		Channel:   t.Channel,
+		Subject:   t.Subject,
+		Body:      t.Body,
+		IsActive:  t.IsActive,
+		UpdatedAt: t.UpdatedAt.Format(time.RFC3339),
+		UpdatedBy: t.UpdatedBy,
+	}
+}
+
+func ToTemplateListResponse(templates []*Template) []*dto.TemplateResponse {
+	res := make([]*dto.TemplateResponse, 0, len(templates))
+	for _, t := range templates {
+		res = append(res, ToTemplateResponse(t))
+	}
+	return res
+}
+
+func ToNotificationResponse(n *Notification) *dto.NotificationResponse {
+	res := &dto.NotificationResponse{
+		ID:            n.ID,
+		StoreID:       n.StoreID,
+		OrderID:       n.OrderID,
+		Event:         n.Event,
+		Channel:       n.Channel,
+		Recipient:     n.Recipient,
+		Subject:       n.Subject,
+		Body:          n.Body,
+		Status:        n.Status,
+		Attempts:      n.Attempts,
+		NextAttemptAt: n.NextAttemptAt.Format(time.RFC3339),
+		LastError:     n.LastError,
+		CreatedAt:     n.CreatedAt.Format(time.RFC3339),
+	}
+	if n.SentAt != nil {
+		res.SentAt = n.SentAt.Format(time.RFC3339)
+	}
+	return res
+}
+
+func ToNotificationListResponse(list []*Notification) []*dto.NotificationResponse {
+	res := make([]*dto.NotificationResponse, 0, len(list))
+	for _, n := range list {
+		res = append(res, ToNotificationResponse(n))
+	}
+	return res
+}
@@ -0,0 +1,47 @@
+package notification
+
+import "time"
+
+// Notification is one outbox message to one recipient.
+type Notification struct {
+	ID            string     `json:"id"`
+	StoreID       string     `json:"store_id"`
+	OrderID       string     `json:"order_id"`
+	Event         string     `json:"event"`   // order_received, order_ready, ...
+	Channel       string     `json:"channel"` // whatsapp, sms, email
+	Recipient     string     `json:"recipient"`
+	Subject       string     `json:"subject"` // hanya untuk email
+	Body          string     `json:"body"`
+	Status        string     `json:"status"` // pending, sent, failed
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	LastError     string     `json:"last_error"`
+	CreatedAt     time.Time  `json:"created_at"`
+	SentAt        *time.Time `json:"sent_at"`
+}
+
+// Template is a store's message for an event on a channel, written in text/template syntax.
+type Template struct {
+	ID        string    `json:"id"`
+	StoreID   string    `json:"store_id"`
+	Event     string    `json:"event"`
+	Channel   string    `json:"channel"`
+	Subject   string    `json:"subject"`
+	Body      string    `json:"body"`
+	IsActive  bool      `json:"is_active"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedBy string    `json:"updated_by"`
+}
+
+// TemplateData is what templates can reference, e.g. {{.InvoiceNumber}} or {{.PickupDate}}.
+type TemplateData struct {
+	StoreName     string
+	StorePhone    string
+	CustomerName  string
+	InvoiceNumber string
+	Status        string
+	Total         string
+	Paid          string
+	Outstanding   string
+	PickupDate    string
+}
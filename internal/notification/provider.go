@@ -0,0 +1,190 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message is what a provider delivers.
+type Message struct {
+	Recipient string
+	Subject   string
+	Body      string
+}
+
+// Provider delivers messages on one channel.
+type Provider interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// WhatsAppProvider posts messages to an HTTP WhatsApp gateway:
+// POST {URL} with {"to": "...", "message": "..."} and a bearer token.
+type WhatsAppProvider struct {
+	URL    string
+	Token  string
+	Client *http.Client
+}
+
+func (p *WhatsAppProvider) Send(ctx context.Context, msg *Message) error {
+	return postJSON(ctx, p.Client, p.URL, p.Token, map[string]string{
+		"to":      msg.Recipient,
+		"message": msg.Body,
+	})
+}
+
+// SMSProvider posts messages to an HTTP SMS gateway:
+// POST {URL} with {"to": "...", "from": "...", "text": "..."} and a bearer API key.
+type SMSProvider struct {
+	URL    string
+	APIKey string
+	Sender string
+	Client *http.Client
+}
+
+func (p *SMSProvider) Send(ctx context.Context, msg *Message) error {
+	return postJSON(ctx, p.Client, p.URL, p.APIKey, map[string]string{
+		"to":   msg.Recipient,
+		"from": p.Sender,
+		"text": msg.Body,
+	})
+}
+
+// SMTPProvider sends plain text email. Auth is skipped when Username is empty.
+type SMTPProvider struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// Send talks SMTP over a connection bound to ctx: smtp.SendMail has no timeout, so a server that
+// stops answering would otherwise hang the dispatcher.
+func (p *SMTPProvider) Send(ctx context.Context, msg *Message) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", p.From)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.Recipient)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(p.Host, p.Port))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, p.Host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: p.Host}); err != nil {
+			return err
+		}
+	}
+	if p.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", p.Username, p.Password, p.Host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(p.From); err != nil {
+		return err
+	}
+	if err := c.Rcpt(msg.Recipient); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// FakeProvider keeps messages in memory instead of sending them. Use it for local
+// development and tests; set Err to simulate a failing gateway.
+type FakeProvider struct {
+	mu   sync.Mutex
+	sent []Message
+	Err  error
+}
+
+func (p *FakeProvider) Send(ctx context.Context, msg *Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.Err != nil {
+		return p.Err
+	}
+	p.sent = append(p.sent, *msg)
+	log.Printf("📨 [fake] to %s: %s", msg.Recipient, msg.Body)
+	return nil
+}
+
+// Sent returns a copy of the messages delivered so far.
+func (p *FakeProvider) Sent() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Message(nil), p.sent...)
+}
+
+func postJSON(ctx context.Context, client *http.Client, url, token string, payload any) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("gateway returned %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
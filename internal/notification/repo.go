@@ -0,0 +1,237 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"sumunar-pos-core/pkg/db"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type NotificationRepository interface {
+	Create(ctx context.Context, tx db.DBTX, n *Notification) error
+	FindByID(ctx context.Context, id string) (*Notification, error)
+	FindAll(ctx context.Context, storeID, orderID, status string, limit, offset int) ([]*Notification, int, error)
+	ClaimDue(ctx context.Context, tx db.DBTX, now, leaseUntil time.Time, limit int) ([]*Notification, error)
+	UpdateDelivery(ctx context.Context, tx db.DBTX, n *Notification) error
+	FindTemplates(ctx context.Context, storeID, event string) ([]*Template, error)
+	FindTemplateByID(ctx context.Context, id string) (*Template, error)
+	UpsertTemplate(ctx context.Context, t *Template) error
+	DeleteTemplate(ctx context.Context, id string) error
+}
+
+type notificationRepo struct {
+	db db.DBTX
+}
+
+func NewNotificationRepository(db db.DBTX) NotificationRepository {
+	return &notificationRepo{db}
+}
+
+const notificationColumns = `id, store_id, COALESCE(order_id::text, ''), event, channel, recipient, subject, body, status, attempts, next_attempt_at, last_error, created_at, sent_at`
+
+func (r *notificationRepo) Create(ctx context.Context, tx db.DBTX, n *Notification) error {
+	query := `
+		INSERT INTO notifications (id, store_id, order_id, event, channel, recipient, subject, body, status, attempts, next_attempt_at, created_at)
+		VALUES ($1, $2, NULLIF($3, '')::uuid, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	_, err := tx.Exec(ctx, query,
+		n.ID,
+		n.StoreID,
+		n.OrderID,
+		n.Event,
+		n.Channel,
+		n.Recipient,
+		n.Subject,
+		n.Body,
+		n.Status,
+		n.Attempts,
+		n.NextAttemptAt,
+		n.CreatedAt,
+	)
+	return err
+}
+
+func (r *notificationRepo) FindByID(ctx context.Context, id string) (*Notification, error) {
+	row := r.db.QueryRow(ctx, `SELECT `+notificationColumns+` FROM notifications WHERE id = $1`, id)
+	n, err := scanNotification(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotificationNotFound
+	}
+	return n, err
+}
+
+func (r *notificationRepo) FindAll(ctx context.Context, storeID, orderID, status string, limit, offset int) ([]*Notification, int, error) {
+	where := ` WHERE ($1::uuid IS NULL OR store_id = $1) AND ($2::uuid IS NULL OR order_id = $2) AND ($3 = '' OR status = $3)`
+
+	rows, err := r.db.Query(ctx, `SELECT `+notificationColumns+` FROM notifications`+where+` ORDER BY created_at DESC LIMIT $4 OFFSET $5`,
+		db.NullID(storeID), db.NullID(orderID), status, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var list []*Notification
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		list = append(list, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM notifications`+where, db.NullID(storeID), db.NullID(orderID), status).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+// ClaimDue leases pending notifications that are due by moving their next attempt to leaseUntil, in
+// one statement. Other dispatchers skip them until the lease runs out, so a dispatcher that dies
+// mid-batch only delays its messages. SKIP LOCKED lets several dispatchers claim side by side.
+func (r *notificationRepo) ClaimDue(ctx context.Context, tx db.DBTX, now, leaseUntil time.Time, limit int) ([]*Notification, error) {
+	query := `
+		UPDATE notifications SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM notifications
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + notificationColumns
+	rows, err := tx.Query(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*Notification
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, n)
+	}
+	return list, rows.Err()
+}
+
+func (r *notificationRepo) UpdateDelivery(ctx context.Context, tx db.DBTX, n *Notification) error {
+	query := `
+		UPDATE notifications SET status = $1, attempts = $2, next_attempt_at = $3, last_error = $4, sent_at = $5
+		WHERE id = $6
+	`
+	_, err := tx.Exec(ctx, query, n.Status, n.Attempts, n.NextAttemptAt, n.LastError, n.SentAt, n.ID)
+	return err
+}
+
+func scanNotification(row pgx.Row) (*Notification, error) {
+	var n Notification
+	err := row.Scan(
+		&n.ID,
+		&n.StoreID,
+		&n.OrderID,
+		&n.Event,
+		&n.Channel,
+		&n.Recipient,
+		&n.Subject,
+		&n.Body,
+		&n.Status,
+		&n.Attempts,
+		&n.NextAttemptAt,
+		&n.LastError,
+		&n.CreatedAt,
+		&n.SentAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+const templateColumns = `id, store_id, event, channel, subject, body, is_active, updated_at, COALESCE(updated_by::text, '')`
+
+// FindTemplates returns the store's templates. An empty event returns all of them.
+func (r *notificationRepo) FindTemplates(ctx context.Context, storeID, event string) ([]*Template, error) {
+	query := `SELECT ` + templateColumns + ` FROM notification_templates WHERE store_id = $1 AND ($2 = '' OR event = $2) ORDER BY event, channel`
+	rows, err := r.db.Query(ctx, query, storeID, event)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*Template
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, t)
+	}
+	return list, rows.Err()
+}
+
+func (r *notificationRepo) FindTemplateByID(ctx context.Context, id string) (*Template, error) {
+	t, err := scanTemplate(r.db.QueryRow(ctx, `SELECT `+templateColumns+` FROM notification_templates WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTemplateNotFound
+	}
+	return t, err
+}
+
+// UpsertTemplate saves the template, replacing the store's existing one for the same event and channel.
+func (r *notificationRepo) UpsertTemplate(ctx context.Context, t *Template) error {
+	query := `
+		INSERT INTO notification_templates (id, store_id, event, channel, subject, body, is_active, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, '')::uuid)
+		ON CONFLICT (store_id, event, channel) DO UPDATE SET
+			subject = EXCLUDED.subject,
+			body = EXCLUDED.body,
+			is_active = EXCLUDED.is_active,
+			updated_at = EXCLUDED.updated_at,
+			updated_by = EXCLUDED.updated_by
+		RETURNING id
+	`
+	return r.db.QueryRow(ctx, query,
+		t.ID,
+		t.StoreID,
+		t.Event,
+		t.Channel,
+		t.Subject,
+		t.Body,
+		t.IsActive,
+		t.UpdatedAt,
+		t.UpdatedBy,
+	).Scan(&t.ID)
+}
+
+func (r *notificationRepo) DeleteTemplate(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM notification_templates WHERE id = $1`, id)
+	return err
+}
+
+func scanTemplate(row pgx.Row) (*Template, error) {
+	var t Template
+	err := row.Scan(
+		&t.ID,
+		&t.StoreID,
+		&t.Event,
+		&t.Channel,
+		&t.Subject,
+		&t.Body,
+		&t.IsActive,
+		&t.UpdatedAt,
+		&t.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,180 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"sumunar-pos-core/internal/customer"
+	"sumunar-pos-core/internal/enum"
+	"sumunar-pos-core/internal/notification/dto"
+	"sumunar-pos-core/internal/order"
+	"sumunar-pos-core/internal/store"
+	"sumunar-pos-core/pkg/db"
+
+	"github.com/google/uuid"
+)
+
+type NotificationService interface {
+	Enqueue(ctx context.Context, tx db.DBTX, event string, o *order.Order, cust *customer.Customer) error
+	FindAll(ctx context.Context, storeID, orderID, status string, limit, offset int) ([]*Notification, int, error)
+	Retry(ctx context.Context, id string) (*Notification, error)
+	Templates(ctx context.Context, storeID string) ([]*Template, error)
+	SaveTemplate(ctx context.Context, req *dto.TemplateRequest, updatedBy string) (*Template, error)
+	DeleteTemplate(ctx context.Context, id string) error
+}
+
+type service struct {
+	repo           NotificationRepository
+	storeRepo      store.StoreRepository
+	db             db.TxBeginner
+	defaultChannel string
+}
+
+// NewService creates the notification service. defaultChannel is used with the built-in templates
+// for stores that have not configured their own; leave it empty to only send configured templates.
+func NewService(repo NotificationRepository, storeRepo store.StoreRepository, db db.TxBeginner, defaultChannel string) NotificationService {
+	return &service{repo, storeRepo, db, defaultChannel}
+}
+
+// Enqueue renders the store's templates for the event and writes them to the outbox using tx,
+// so they are only sent if the order change commits.
+func (s *service) Enqueue(ctx context.Context, tx db.DBTX, event string, o *order.Order, cust *customer.Customer) error {
+	templates, err := s.repo.FindTemplates(ctx, o.StoreID, event)
+	if err != nil {
+		return err
+	}
+	if len(templates) == 0 {
+		if t, ok := defaultTemplates[event]; ok && s.defaultChannel != "" {
+			t.Event, t.Channel, t.IsActive = event, s.defaultChannel, true
+			templates = append(templates, &t)
+		}
+	}
+	if len(templates) == 0 {
+		return nil
+	}
+
+	st, err := s.storeRepo.FindByID(ctx, o.StoreID)
+	if err != nil {
+		return err
+	}
+	data := toTemplateData(st, o, cust)
+
+	now := time.Now()
+	for _, t := range templates {
+		if !t.IsActive {
+			continue
+		}
+		recipient := recipientFor(t.Channel, cust)
+		if recipient == "" {
+			continue
+		}
+
+		subject, err := render(t.Subject, data)
+		if err != nil {
+			log.Printf("⚠️  notification template %s/%s for store %s: %v", t.Event, t.Channel, o.StoreID, err)
+			continue
+		}
+		body, err := render(t.Body, data)
+		if err != nil {
+			log.Printf("⚠️  notification template %s/%s for store %s: %v", t.Event, t.Channel, o.StoreID, err)
+			continue
+		}
+
+		n := &Notification{
+			ID:            uuid.New().String(),
+			StoreID:       o.StoreID,
+			OrderID:       o.ID,
+			Event:         event,
+			Channel:       t.Channel,
+			Recipient:     recipient,
+			Subject:       subject,
+			Body:          body,
+			Status:        enum.NotificationStatusPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		}
+		if err := s.repo.Create(ctx, tx, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *service) FindAll(ctx context.Context, storeID, orderID, status string, limit, offset int) ([]*Notification, int, error) {
+	return s.repo.FindAll(ctx, storeID, orderID, status, limit, offset)
+}
+
+// Retry puts a failed notification back in the queue with a fresh set of attempts.
+func (s *service) Retry(ctx context.Context, id string) (*Notification, error) {
+	n, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if n.Status != enum.NotificationStatusFailed {
+		return nil, ErrNotRetryable
+	}
+
+	n.Status = enum.NotificationStatusPending
+	n.Attempts = 0
+	n.NextAttemptAt = time.Now()
+	return n, s.repo.UpdateDelivery(ctx, s.db, n)
+}
+
+func (s *service) Templates(ctx context.Context, storeID string) ([]*Template, error) {
+	return s.repo.FindTemplates(ctx, storeID, "")
+}
+
+func (s *service) SaveTemplate(ctx context.Context, req *dto.TemplateRequest, updatedBy string) (*Template, error) {
+	if _, err := s.storeRepo.FindByID(ctx, req.StoreID); err != nil {
+		return nil, store.ErrStoreNotFound
+	}
+
+	for _, text := range []string{req.Subject, req.Body} {
+		if _, err := render(text, sampleData); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+		}
+	}
+
+	t := ToTemplateModel(req, updatedBy)
+	return t, s.repo.UpsertTemplate(ctx, t)
+}
+
+func (s *service) DeleteTemplate(ctx context.Context, id string) error {
+	if _, err := s.repo.FindTemplateByID(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteTemplate(ctx, id)
+}
+
+func recipientFor(channel string, cust *customer.Customer) string {
+	if cust == nil {
+		return ""
+	}
+	if channel == enum.NotificationChannelEmail {
+		return cust.Email
+	}
+	return cust.Phone
+}
+
+func toTemplateData(st *store.Store, o *order.Order, cust *customer.Customer) TemplateData {
+	data := TemplateData{
+		StoreName:     st.Name,
+		InvoiceNumber: o.InvoiceNumber,
+		Status:        o.Status,
+		Total:         o.TotalPrice.Format(),
+		Paid:          o.PaidAmount.Format(),
+		Outstanding:   order.OutstandingAmount(o.TotalPrice, o.PaidAmount).Format(),
+	}
+	if st.Phone != nil {
+		data.StorePhone = *st.Phone
+	}
+	if cust != nil {
+		data.CustomerName = cust.Name
+	}
+	if !o.PickupDate.IsZero() {
+		data.PickupDate = o.PickupDate.Format("02/01/2006 15:04")
+	}
+	return data
+}
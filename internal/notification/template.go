@@ -0,0 +1,49 @@
+package notification
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"sumunar-pos-core/internal/enum"
+)
+
+// defaultTemplates are used for a store that has not configured any template for an event.
+var defaultTemplates = map[string]Template{
+	enum.NotificationEventOrderReceived: {
+		Subject: "Pesanan {{.InvoiceNumber}} diterima",
+		Body: "Halo {{.CustomerName}}, cucian Anda sudah kami terima di {{.StoreName}}.\n" +
+			"No. Invoice: {{.InvoiceNumber}}\nTotal: Rp {{.Total}}\nPerkiraan selesai: {{.PickupDate}}\nTerima kasih.",
+	},
+	enum.NotificationEventOrderReady: {
+		Subject: "Pesanan {{.InvoiceNumber}} siap diambil",
+		Body: "Halo {{.CustomerName}}, cucian Anda dengan No. Invoice {{.InvoiceNumber}} sudah selesai dan siap diambil di {{.StoreName}}.\n" +
+			"{{if ne .Outstanding \"0\"}}Sisa pembayaran: Rp {{.Outstanding}}\n{{end}}Terima kasih.",
+	},
+}
+
+// sampleData is used to check that a template renders before it is saved.
+var sampleData = TemplateData{
+	StoreName:     "Sumunar Laundry",
+	StorePhone:    "08123456789",
+	CustomerName:  "Budi",
+	InvoiceNumber: "INV-250101-001",
+	Status:        enum.OrderStatusDone,
+	Total:         "50.000",
+	Paid:          "20.000",
+	Outstanding:   "30.000",
+	PickupDate:    "03/01/2025",
+}
+
+func render(text string, data TemplateData) (string, error) {
+	tmpl, err := template.New("notification").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
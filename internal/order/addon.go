@@ -0,0 +1,40 @@
+package order
+
+import (
+	"context"
+
+	"sumunar-pos-core/internal/order/dto"
+
+	"github.com/google/uuid"
+)
+
+// priceAddons mengisi add-on item dari katalog: harganya diambil saat ini dan disimpan sebagai snapshot.
+// Add-on harus milik toko order, aktif, dan berlaku untuk jenis layanan item.
+func (s *OrderService) priceAddons(ctx context.Context, order *Order, item *OrderItem, reqAddons []dto.OrderItemAddonRequest) error {
+	item.Addons = make([]*OrderItemAddon, 0, len(reqAddons))
+	item.AddonAmount = 0
+	for _, req := range reqAddons {
+		a, err := s.addonRepo.FindByID(ctx, req.AddonID)
+		if err != nil {
+			return err
+		}
+		if a.StoreID != order.StoreID || !a.IsActive || !a.AppliesTo(item.ServiceTypeID) {
+			return ErrAddonNotApplicable
+		}
+
+		quantity, amount := a.Charge(req.Quantity, item.ChargedQuantity)
+		item.Addons = append(item.Addons, &OrderItemAddon{
+			ID:          uuid.New().String(),
+			OrderID:     item.OrderID,
+			OrderItemID: item.ID,
+			AddonID:     a.ID,
+			Name:        a.Name,
+			PriceType:   a.PriceType,
+			UnitPrice:   a.Price,
+			Quantity:    quantity,
+			Amount:      amount,
+		})
+		item.AddonAmount += amount
+	}
+	return nil
+}
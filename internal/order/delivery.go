@@ -0,0 +1,30 @@
+package order
+
+import (
+	"context"
+	"time"
+
+	"sumunar-pos-core/pkg/db"
+	"sumunar-pos-core/pkg/money"
+)
+
+// SetDeliveryFee charges the order's delivery jobs on it: fee replaces the previous delivery fee and
+// the total is rounded again. It runs inside the caller's transaction.
+func (s *OrderService) SetDeliveryFee(ctx context.Context, tx db.DBTX, orderID string, fee money.Amount, by string) error {
+	order, err := s.repo.FindByIDForUpdate(ctx, tx, orderID)
+	if err != nil {
+		return err
+	}
+	if order.DeliveryFee == fee {
+		return nil
+	}
+	settings, err := s.storeRepo.FindSettings(ctx, order.StoreID)
+	if err != nil {
+		return err
+	}
+
+	applyDeliveryFee(order, fee, settings)
+	order.UpdatedAt = time.Now()
+	order.UpdatedBy = by
+	return s.repo.UpdateDeliveryFee(ctx, tx, order)
+}
@@ -0,0 +1,130 @@
+package order
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"sumunar-pos-core/internal/order/dto"
+	"sumunar-pos-core/internal/promo"
+	"sumunar-pos-core/internal/store"
+	"sumunar-pos-core/pkg/db"
+	"sumunar-pos-core/pkg/hash"
+	"sumunar-pos-core/pkg/money"
+)
+
+// applyPromo menghitung potongan kode promo dari item yang sudah diberi harga (setelah diskon item).
+// Kode yang sama dengan yang sudah tercatat di order tidak dicek ulang masa berlaku/kuotanya,
+// supaya order lama tetap bisa diedit setelah promo berakhir.
+func (s *OrderService) applyPromo(ctx context.Context, order *Order, items []*OrderItem, code string, at time.Time) error {
+	code = strings.TrimSpace(code)
+	prevID, prevCode := order.PromoID, order.PromoCode
+	order.PromoID, order.PromoCode, order.PromoDiscount = "", "", 0
+	if code == "" {
+		return nil
+	}
+
+	p, err := s.promoRepo.FindByCode(ctx, order.StoreID, code)
+	if err != nil {
+		return err
+	}
+	kept := prevID != "" && prevID == p.ID && strings.EqualFold(prevCode, code)
+	if !kept {
+		if err := p.Check(at); err != nil {
+			return err
+		}
+	}
+
+	lines := make([]promo.Line, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, promo.Line{ServiceTypeID: item.ServiceTypeID, Amount: item.TotalPrice})
+	}
+	discount, err := p.Discount(lines)
+	if err != nil {
+		return err
+	}
+
+	order.PromoID = p.ID
+	order.PromoCode = p.Code
+	order.PromoDiscount = discount
+	return nil
+}
+
+// swapPromo memindahkan pemakaian kuota promo dari prevID ke order.PromoID di dalam transaksi.
+func (s *OrderService) swapPromo(ctx context.Context, tx db.DBTX, prevID, newID string) error {
+	if prevID == newID {
+		return nil
+	}
+	if prevID != "" {
+		if err := s.promoRepo.Release(ctx, tx, prevID); err != nil {
+			return err
+		}
+	}
+	if newID != "" {
+		return s.promoRepo.Redeem(ctx, tx, newID)
+	}
+	return nil
+}
+
+// checkDiscountLimit membatasi diskon manual (diskon item + diskon order + potongan dari harga override,
+// tanpa promo) sesuai role kasir. Di atas batas, diskon butuh persetujuan owner lewat email dan password-nya.
+func (s *OrderService) checkDiscountLimit(ctx context.Context, order *Order, items []*OrderItem, overrides []*OrderItemPriceOverride,
+	settings *store.StoreSettings, role string, approval *dto.DiscountApprovalRequest) error {
+	order.DiscountApprovedBy = ""
+
+	var gross, manual money.Amount
+	for _, item := range items {
+		gross += item.TotalPrice + item.DiscountAmount
+		manual += item.DiscountAmount
+	}
+	manual += order.DiscountAmount
+	// harga yang diturunkan manual juga diskon, dihitung dari harga katalog
+	markdown := overrideMarkdown(items, overrides)
+	gross += markdown
+	manual += markdown
+
+	if withinLimit(settings, role, manual, gross) {
+		return nil
+	}
+	if approval == nil {
+		return ErrDiscountApprovalRequired
+	}
+
+	approver, err := s.userRepo.FindByEmail(ctx, approval.Email)
+	if err != nil || approver.Password == nil || !hash.CheckPasswordHash(approval.Password, *approver.Password) {
+		return ErrInvalidDiscountApproval
+	}
+	if (approver.Role != "owner" && approver.Role != "admin") || !withinLimit(settings, approver.Role, manual, gross) {
+		return ErrInvalidDiscountApproval
+	}
+	order.DiscountApprovedBy = approver.ID
+	return nil
+}
+
+// overrideMarkdown is how much the hand-entered prices below the catalog price take off the items:
+// (catalog - override) × charged quantity. Overrides above the catalog price do not count.
+func overrideMarkdown(items []*OrderItem, overrides []*OrderItemPriceOverride) money.Amount {
+	byID := make(map[string]*OrderItem, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+
+	var markdown money.Amount
+	for _, o := range overrides {
+		item, ok := byID[o.OrderItemID]
+		if !ok || o.OverridePrice >= o.CatalogPrice {
+			continue
+		}
+		markdown += (o.CatalogPrice - o.OverridePrice).Mul(item.ChargedQuantity, money.RoundHalfUp)
+	}
+	return markdown
+}
+
+// withinLimit: role tanpa batas di pengaturan toko boleh memberi diskon berapa pun.
+func withinLimit(settings *store.StoreSettings, role string, manual, gross money.Amount) bool {
+	limit, ok := settings.DiscountLimit(role)
+	if !ok || manual == 0 {
+		return true
+	}
+	return manual <= gross.Percent(limit, money.RoundUp)
+}
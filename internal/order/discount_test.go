@@ -0,0 +1,71 @@
+package order
+
+import (
+	"testing"
+
+	"sumunar-pos-core/internal/store"
+	"sumunar-pos-core/pkg/money"
+)
+
+func TestWithinLimit(t *testing.T) {
+	settings := &store.StoreSettings{DiscountLimits: map[string]float64{"cashier": 10, "admin": 25, "trainee": 0}}
+	tests := []struct {
+		role          string
+		manual, gross money.Amount
+		want          bool
+	}{
+		{"cashier", money.Rupiah(10000), money.Rupiah(100000), true},
+		{"cashier", money.Rupiah(10000) + 1, money.Rupiah(100000), false},
+		{"cashier", money.Amount(101), money.Amount(1005), true}, // 10% dari 1005 sen dibulatkan ke atas
+		{"cashier", money.Amount(102), money.Amount(1005), false},
+		{"cashier", 0, 0, true},
+		{"admin", money.Rupiah(25000), money.Rupiah(100000), true},
+		{"admin", money.Rupiah(30000), money.Rupiah(100000), false},
+		{"trainee", 0, money.Rupiah(100000), true},
+		{"trainee", 1, money.Rupiah(100000), false},
+		{"owner", money.Rupiah(100000), money.Rupiah(100000), true}, // tanpa batas
+	}
+	for _, tt := range tests {
+		if got := withinLimit(settings, tt.role, tt.manual, tt.gross); got != tt.want {
+			t.Errorf("withinLimit(%s, %v, %v) = %v, want %v", tt.role, tt.manual, tt.gross, got, tt.want)
+		}
+	}
+}
+
+func TestOverrideMarkdown(t *testing.T) {
+	items := []*OrderItem{
+		{ID: "a", ChargedQuantity: 3.5},
+		{ID: "b", ChargedQuantity: 2},
+	}
+	tests := []struct {
+		name      string
+		overrides []*OrderItemPriceOverride
+		want      money.Amount
+	}{
+		{"none", nil, 0},
+		{"below catalog", []*OrderItemPriceOverride{
+			{OrderItemID: "a", CatalogPrice: money.Rupiah(7000), OverridePrice: money.Rupiah(6000)},
+		}, money.Rupiah(3500)},
+		{"above catalog", []*OrderItemPriceOverride{
+			{OrderItemID: "a", CatalogPrice: money.Rupiah(7000), OverridePrice: money.Rupiah(8000)},
+		}, 0},
+		{"at catalog", []*OrderItemPriceOverride{
+			{OrderItemID: "b", CatalogPrice: money.Rupiah(7000), OverridePrice: money.Rupiah(7000)},
+		}, 0},
+		{"several items", []*OrderItemPriceOverride{
+			{OrderItemID: "a", CatalogPrice: money.Rupiah(7000), OverridePrice: money.Rupiah(6000)},
+			{OrderItemID: "b", CatalogPrice: money.Rupiah(15000), OverridePrice: money.Rupiah(12500)},
+		}, money.Rupiah(8500)},
+		{"unknown item", []*OrderItemPriceOverride{
+			{OrderItemID: "c", CatalogPrice: money.Rupiah(7000), OverridePrice: money.Rupiah(1000)},
+		}, 0},
+		{"rounded", []*OrderItemPriceOverride{
+			{OrderItemID: "a", CatalogPrice: money.Amount(1000), OverridePrice: money.Amount(999)},
+		}, money.Amount(4)}, // 1 sen x 3,5
+	}
+	for _, tt := range tests {
+		if got := overrideMarkdown(items, tt.overrides); got != tt.want {
+			t.Errorf("%s: overrideMarkdown() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
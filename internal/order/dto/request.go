@@ -1,21 +1,90 @@
 package dto
 
+import "sumunar-pos-core/pkg/money"
+
 type OrderRequest struct {
-	StoreID         string             `json:"store_id" validate:"required"`
-	CustomerID      string             `json:"customer_id"`
-	CustomerName    string             `json:"customer_name"`
-	CustomerPhone   string             `json:"customer_phone"`
-	CustomerAddress string             `json:"customer_address"`
-	Status          string             `json:"status" validate:"required,oneof='PENDING' 'ONGOING' 'COMPLETED' 'CANCELLED'"`
-	PickupDate      string             `json:"pickup_date"` // ISO8601
-	Items           []OrderItemRequest `json:"items" validate:"required,min=1,dive"`
-	Discount        float64            `json:"discount"`    // persen: 0 - 100
-	PaidAmount      float64            `json:"paid_amount"` // nilai yang dibayar
+	StoreID          string                   `json:"store_id" validate:"required"`
+	CustomerID       string                   `json:"customer_id"`
+	CustomerName     string                   `json:"customer_name"`
+	CustomerPhone    string                   `json:"customer_phone"`
+	CustomerAddress  string                   `json:"customer_address"`
+	PickupDate       string                   `json:"pickup_date"` // ISO8601; kosong = tanggal ambil paling cepat
+	Express          string                   `json:"express"`     // nama tier express untuk semua item, kosong = standar
+	Items            []OrderItemRequest       `json:"items" validate:"required,min=1,dive"`
+	Discount         float64                  `json:"discount" validate:"gte=0,lte=100"` // persen: 0 - 100
+	DiscountFixed    money.Amount             `json:"discount_fixed" validate:"gte=0"`   // potongan nominal untuk seluruh order
+	PromoCode        string                   `json:"promo_code"`
+	RedeemPoints     int                      `json:"redeem_points" validate:"gte=0"` // poin pelanggan yang ditukar, hanya saat order dibuat
+	DiscountApproval *DiscountApprovalRequest `json:"discount_approval"`              // wajib jika diskon manual melebihi batas role kasir
+	PaidAmount       money.Amount             `json:"paid_amount"`                    // uang muka saat order dibuat, diabaikan saat update
+	PaymentMethodID  string                   `json:"payment_method_id"`              // kosong = metode "cash" milik store
+	PaymentRef       string                   `json:"payment_reference"`
 }
 
 type OrderItemRequest struct {
-	ProductServiceID string  `json:"product_service_id" validate:"required"`
-	Quantity         float64 `json:"quantity" validate:"required,gt=0"`
-	TotalPrice       float64 `json:"total_price"`
-	Notes            string  `json:"notes"` // optional
+	ProductServiceID    string                  `json:"product_service_id" validate:"required"`
+	Quantity            float64                 `json:"quantity" validate:"required,gt=0"`
+	Discount            float64                 `json:"discount" validate:"gte=0,lte=100"`     // diskon item dalam persen
+	DiscountFixed       money.Amount            `json:"discount_fixed" validate:"gte=0"`       // potongan nominal untuk item ini
+	UnitPrice           *money.Amount           `json:"unit_price" validate:"omitempty,gte=0"` // kosong = harga katalog; diisi = override manual
+	PriceOverrideReason string                  `json:"price_override_reason"`                 // wajib jika unit_price berbeda dari katalog
+	Notes               string                  `json:"notes"`                                 // optional
+	Addons              []OrderItemAddonRequest `json:"addons" validate:"omitempty,dive"`
+}
+
+// OrderItemAddonRequest puts a catalog add-on on the item.
+type OrderItemAddonRequest struct {
+	AddonID  string  `json:"addon_id" validate:"required,uuid"`
+	Quantity float64 `json:"quantity" validate:"gte=0"` // hanya add-on fixed, 0 = 1; per_unit ikut kuantitas item
+}
+
+// PickupEstimateRequest asks for the earliest pickup date before the order is saved.
+type PickupEstimateRequest struct {
+	StoreID string                      `json:"store_id" validate:"required,uuid"`
+	Express string                      `json:"express"`
+	Items   []PickupEstimateItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type PickupEstimateItemRequest struct {
+	ProductServiceID string `json:"product_service_id" validate:"required,uuid"`
+}
+
+// DiscountApprovalRequest is an owner's login, entered on the cashier's device to approve a discount.
+type DiscountApprovalRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type OrderStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=pending processed done taken cancelled"`
+	Reason string `json:"reason"`
+}
+
+type OrderPaymentRequest struct {
+	Amount          money.Amount `json:"amount" validate:"required,gt=0"`
+	PaymentMethodID string       `json:"payment_method_id" validate:"required"`
+	Reference       string       `json:"reference"`
+}
+
+// OrderWalletPaymentRequest pays an order from the customer's prepaid wallet. A quota wallet covers
+// the eligible items it still has quota for; a balance wallet pays Amount, or the outstanding amount.
+type OrderWalletPaymentRequest struct {
+	WalletID string       `json:"wallet_id" validate:"required"`
+	Amount   money.Amount `json:"amount" validate:"gte=0"` // hanya untuk paket saldo, 0 = sisa tagihan
+}
+
+// OrderListRequest is the query string of GET /api/order. Dates accept YYYY-MM-DD (whole day) or RFC3339.
+type OrderListRequest struct {
+	StoreID       string `query:"store_id" validate:"omitempty,uuid"`
+	Status        string `query:"status" validate:"omitempty,oneof=pending processed done taken cancelled"`
+	PaymentStatus string `query:"payment_status" validate:"omitempty,oneof=unpaid partial paid overpaid"`
+	CustomerID    string `query:"customer_id" validate:"omitempty,uuid"`
+	Search        string `query:"q"` // nomor invoice atau no. HP pelanggan
+	CreatedFrom   string `query:"created_from"`
+	CreatedTo     string `query:"created_to"`
+	PickupFrom    string `query:"pickup_from"`
+	PickupTo      string `query:"pickup_to"`
+	Sort          string `query:"sort" validate:"omitempty,oneof=created_at -created_at pickup_date -pickup_date total_price -total_price invoice_number -invoice_number"`
+	Limit         int    `query:"limit"`
+	Offset        int    `query:"offset"`
 }
@@ -1,32 +1,133 @@
 package dto
 
+import "sumunar-pos-core/pkg/money"
+
 type OrderResponse struct {
-	ID              string              `json:"id"`
-	InvoiceNumber   string              `json:"invoice_number"`
-	StoreID         string              `json:"store_id"`
-	CustomerID      string              `json:"customer_id"`
-	CustomerName    string              `json:"customer_name"`
-	CustomerPhone   string              `json:"customer_phone"`
-	CustomerAddress string              `json:"customer_address"`
-	Status          string              `json:"status"`
-	Discount        float64             `json:"discount"`
-	TotalPrice      float64             `json:"total_price"`
-	PaidAmount      float64             `json:"paid_amount"`
-	Change          float64             `json:"change"`
-	PickupDate      string              `json:"pickup_date"` // ISO8601
-	CreatedAt       string              `json:"created_at"`
-	CreatedBy       string              `json:"created_by"`
-	OrderItems      []OrderItemResponse `json:"items"`
+	ID                 string                       `json:"id"`
+	InvoiceNumber      string                       `json:"invoice_number"`
+	StoreID            string                       `json:"store_id"`
+	CustomerID         string                       `json:"customer_id"`
+	CustomerName       string                       `json:"customer_name"`
+	CustomerPhone      string                       `json:"customer_phone"`
+	CustomerAddress    string                       `json:"customer_address"`
+	CustomerEmail      string                       `json:"customer_email,omitempty"`
+	Status             string                       `json:"status"`
+	Subtotal           money.Amount                 `json:"subtotal"`
+	Discount           float64                      `json:"discount"`
+	DiscountFixed      money.Amount                 `json:"discount_fixed"`
+	DiscountAmount     money.Amount                 `json:"discount_amount"` // diskon order: persen + nominal
+	PromoCode          string                       `json:"promo_code,omitempty"`
+	PromoDiscount      money.Amount                 `json:"promo_discount"`
+	DiscountApprovedBy string                       `json:"discount_approved_by,omitempty"`
+	TierName           string                       `json:"tier_name,omitempty"`
+	TierDiscount       float64                      `json:"tier_discount"`
+	TierDiscountAmount money.Amount                 `json:"tier_discount_amount"`
+	PointsRedeemed     int                          `json:"points_redeemed"`
+	PointsDiscount     money.Amount                 `json:"points_discount"`
+	PointsEarned       int                          `json:"points_earned"`
+	TaxRate            float64                      `json:"tax_rate"`
+	TaxInclusive       bool                         `json:"tax_inclusive"`
+	TaxBase            money.Amount                 `json:"tax_base"` // DPP
+	TaxAmount          money.Amount                 `json:"tax_amount"`
+	DeliveryFee        money.Amount                 `json:"delivery_fee"`        // ongkos antar-jemput
+	Rounding           money.Amount                 `json:"rounding_adjustment"` // pembulatan kas, sudah termasuk di total_price
+	TotalPrice         money.Amount                 `json:"total_price"`         // grand total
+	PaidAmount         money.Amount                 `json:"paid_amount"`
+	Change             money.Amount                 `json:"change"`
+	PaymentStatus      string                       `json:"payment_status"`
+	Outstanding        money.Amount                 `json:"outstanding_amount"`
+	PickupDate         string                       `json:"pickup_date"`       // ISO8601
+	Express            string                       `json:"express,omitempty"` // tier express yang dipilih
+	CreatedAt          string                       `json:"created_at"`
+	CreatedBy          string                       `json:"created_by"`
+	CreatedByName      string                       `json:"created_by_name,omitempty"`
+	UpdatedAt          string                       `json:"updated_at,omitempty"`
+	UpdatedBy          string                       `json:"updated_by,omitempty"`
+	UpdatedByName      string                       `json:"updated_by_name,omitempty"`
+	OrderItems         []OrderItemResponse          `json:"items"`
+	StatusHistory      []OrderStatusHistoryResponse `json:"status_history,omitempty"`
+	Payments           []OrderPaymentResponse       `json:"payments,omitempty"`
 }
 
 type OrderItemResponse struct {
-	ID               string  `json:"id"`
-	ProductServiceID string  `json:"product_service_id"`
-	Quantity         float64 `json:"quantity"`
-	TotalPrice       float64 `json:"total_price"`
-	Notes            string  `json:"notes"`
+	ID               string                   `json:"id"`
+	ProductServiceID string                   `json:"product_service_id"`
+	ServiceTypeID    string                   `json:"service_type_id"`
+	ProductName      string                   `json:"product_name"`
+	ServiceName      string                   `json:"service_name"`
+	Unit             string                   `json:"unit"`
+	UnitPrice        money.Amount             `json:"unit_price"`
+	PriceOverridden  bool                     `json:"price_overridden"`
+	TaxExempt        bool                     `json:"tax_exempt"`
+	Quantity         float64                  `json:"quantity"`
+	ChargedQuantity  float64                  `json:"charged_quantity"`
+	Discount         float64                  `json:"discount"`
+	AddonAmount      money.Amount             `json:"addon_amount"`
+	ExpressTier      string                   `json:"express_tier,omitempty"`
+	ExpressRate      float64                  `json:"express_rate"`
+	ExpressSurcharge money.Amount             `json:"express_surcharge"`
+	TurnaroundHours  int                      `json:"turnaround_hours"`
+	DiscountAmount   money.Amount             `json:"discount_amount"`
+	TotalPrice       money.Amount             `json:"total_price"` // termasuk add-on dan biaya express, setelah diskon item
+	Notes            string                   `json:"notes"`
+	Addons           []OrderItemAddonResponse `json:"addons"`
+}
+
+type OrderItemAddonResponse struct {
+	AddonID   string       `json:"addon_id"`
+	Name      string       `json:"name"`
+	PriceType string       `json:"price_type"`
+	UnitPrice money.Amount `json:"unit_price"`
+	Quantity  float64      `json:"quantity"`
+	Amount    money.Amount `json:"amount"`
+}
+
+type OrderStatusHistoryResponse struct {
+	ID            string `json:"id"`
+	FromStatus    string `json:"from_status"`
+	ToStatus      string `json:"to_status"`
+	Reason        string `json:"reason"`
+	ChangedBy     string `json:"changed_by"`
+	ChangedByName string `json:"changed_by_name,omitempty"`
+	ChangedAt     string `json:"changed_at"` // ISO8601
+}
+
+type OrderPaymentResponse struct {
+	ID              string       `json:"id"`
+	Amount          money.Amount `json:"amount"`
+	Change          money.Amount `json:"change"`
+	PaymentMethodID string       `json:"payment_method_id"`
+	Method          string       `json:"method"`
+	Reference       string       `json:"reference"`
+	WalletID        string       `json:"wallet_id,omitempty"`
+	ReceivedBy      string       `json:"received_by"`
+	ReceivedByName  string       `json:"received_by_name,omitempty"`
+	ReceivedAt      string       `json:"received_at"` // ISO8601
+}
+
+type OrderQRISResponse struct {
+	OrderID       string       `json:"order_id"`
+	InvoiceNumber string       `json:"invoice_number"`
+	Amount        money.Amount `json:"amount"`
+	Payload       string       `json:"payload"` // string QRIS dinamis, dirender sebagai QR code
 }
 
 type ErrorResponse struct {
 	Message string `json:"message"`
 }
+
+type OrderPriceOverrideResponse struct {
+	ID               string       `json:"id"`
+	OrderItemID      string       `json:"order_item_id"`
+	ProductServiceID string       `json:"product_service_id"`
+	CatalogPrice     money.Amount `json:"catalog_price"`
+	OverridePrice    money.Amount `json:"override_price"`
+	Reason           string       `json:"reason"`
+	OverriddenBy     string       `json:"overridden_by"`
+	OverriddenAt     string       `json:"overridden_at"` // ISO8601
+}
+
+type PickupEstimateResponse struct {
+	PickupDate      string `json:"pickup_date"`      // ISO8601, paling cepat
+	TurnaroundHours int    `json:"turnaround_hours"` // lama pengerjaan item paling lama
+}
@@ -0,0 +1,31 @@
+package order
+
+import "errors"
+
+var (
+	ErrOrderNotFound            = errors.New("order not found")
+	ErrInvalidStatus            = errors.New("invalid order status")
+	ErrInvalidStatusTransition  = errors.New("order status transition is not allowed")
+	ErrStatusConflict           = errors.New("order status was changed by another request")
+	ErrOrderNotEditable         = errors.New("order can no longer be edited")
+	ErrOrderNotPayable          = errors.New("cancelled orders cannot receive payments")
+	ErrOrderAlreadyPaid         = errors.New("order is already fully paid")
+	ErrInvalidPaymentMethod     = errors.New("payment method is not available for this store")
+	ErrQRISNotConfigured        = errors.New("store has no QRIS configured")
+	ErrInvalidFilter            = errors.New("invalid order filter")
+	ErrProductServiceNotFound   = errors.New("product service not found")
+	ErrProductServiceNotInStore = errors.New("product service does not belong to this order's store")
+	ErrProductServiceInactive   = errors.New("product service, its product or its service type is not active")
+	ErrAddonNotApplicable       = errors.New("add-on is not available for this item's store or service type")
+	ErrOverrideReasonRequired   = errors.New("price_override_reason is required when unit_price differs from the catalog price")
+	ErrDiscountApprovalRequired = errors.New("discount exceeds your limit and needs owner approval")
+	ErrInvalidDiscountApproval  = errors.New("discount approval was rejected: invalid owner credentials or limit")
+	ErrLoyaltyDisabled          = errors.New("loyalty points are not enabled for this store")
+	ErrWalletNotApplicable      = errors.New("prepaid wallet does not belong to this order's customer and store")
+	ErrNothingToCover           = errors.New("prepaid wallet does not cover any remaining item of this order")
+	ErrExpressNotAvailable      = errors.New("express tier is not offered for this service type")
+	ErrInvalidPickupDate        = errors.New("pickup_date must be an ISO8601 date-time")
+	ErrPickupTooEarly           = errors.New("pickup_date is earlier than the items can be finished")
+	ErrPickupStoreClosed        = errors.New("store is closed at pickup_date")
+	ErrInvoiceNumberTaken       = errors.New("invoice number is already used; check the store's invoice format and sequence")
+)
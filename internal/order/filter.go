@@ -0,0 +1,150 @@
+package order
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"sumunar-pos-core/internal/enum"
+	"sumunar-pos-core/internal/order/dto"
+	"sumunar-pos-core/internal/store"
+)
+
+// OrderFilter narrows down FindAll. Zero values are ignored; time ranges are [from, to).
+type OrderFilter struct {
+	StoreID       string
+	Status        string
+	PaymentStatus string
+	CustomerID    string
+	Search        string
+	CreatedFrom   time.Time
+	CreatedTo     time.Time
+	PickupFrom    time.Time
+	PickupTo      time.Time
+	Sort          string
+	Limit         int
+	Offset        int
+}
+
+// orderSorts whitelists the sort options; a leading "-" means descending.
+var orderSorts = map[string]string{
+	"created_at":      "o.created_at ASC",
+	"-created_at":     "o.created_at DESC",
+	"pickup_date":     "o.pickup_date ASC",
+	"-pickup_date":    "o.pickup_date DESC",
+	"total_price":     "o.total_price ASC",
+	"-total_price":    "o.total_price DESC",
+	"invoice_number":  "o.invoice_number ASC",
+	"-invoice_number": "o.invoice_number DESC",
+}
+
+// paymentStatusConditions mirror PaymentStatus in SQL.
+var paymentStatusConditions = map[string]string{
+	enum.PaymentStatusUnpaid:   "(o.paid_amount <= 0 AND o.total_price > 0)",
+	enum.PaymentStatusPartial:  "(o.paid_amount > 0 AND o.paid_amount < o.total_price)",
+	enum.PaymentStatusPaid:     "(o.paid_amount = o.total_price)",
+	enum.PaymentStatusOverpaid: "(o.paid_amount > o.total_price)",
+}
+
+// where builds the WHERE clause and its arguments.
+func (f *OrderFilter) where() (string, []any) {
+	var conds []string
+	var args []any
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.StoreID != "" {
+		add("o.store_id = $%d::uuid", f.StoreID)
+	}
+	if f.Status != "" {
+		add("o.status = $%d", f.Status)
+	}
+	if cond, ok := paymentStatusConditions[f.PaymentStatus]; ok {
+		conds = append(conds, cond)
+	}
+	if f.CustomerID != "" {
+		add("o.customer_id = $%d::uuid", f.CustomerID)
+	}
+	if f.Search != "" {
+		add("(o.invoice_number ILIKE $%[1]d OR c.phone ILIKE $%[1]d)", "%"+escapeLike(f.Search)+"%")
+	}
+	if !f.CreatedFrom.IsZero() {
+		add("o.created_at >= $%d", f.CreatedFrom)
+	}
+	if !f.CreatedTo.IsZero() {
+		add("o.created_at < $%d", f.CreatedTo)
+	}
+	if !f.PickupFrom.IsZero() {
+		add("o.pickup_date >= $%d", f.PickupFrom)
+	}
+	if !f.PickupTo.IsZero() {
+		add("o.pickup_date < $%d", f.PickupTo)
+	}
+
+	if len(conds) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+func (f *OrderFilter) orderBy() string {
+	if sort, ok := orderSorts[f.Sort]; ok {
+		return sort + ", o.id"
+	}
+	return orderSorts["-created_at"] + ", o.id"
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// ToOrderFilter validates the date parameters of req and converts it into an OrderFilter. Plain dates
+// are business dates of the store in settings.
+func ToOrderFilter(req *dto.OrderListRequest, settings *store.StoreSettings) (*OrderFilter, error) {
+	f := &OrderFilter{
+		StoreID:       req.StoreID,
+		Status:        req.Status,
+		PaymentStatus: req.PaymentStatus,
+		CustomerID:    req.CustomerID,
+		Search:        strings.TrimSpace(req.Search),
+		Sort:          req.Sort,
+		Limit:         req.Limit,
+		Offset:        req.Offset,
+	}
+
+	var err error
+	if f.CreatedFrom, err = parseBound(req.CreatedFrom, false, settings); err != nil {
+		return nil, fmt.Errorf("%w: created_from", ErrInvalidFilter)
+	}
+	if f.CreatedTo, err = parseBound(req.CreatedTo, true, settings); err != nil {
+		return nil, fmt.Errorf("%w: created_to", ErrInvalidFilter)
+	}
+	if f.PickupFrom, err = parseBound(req.PickupFrom, false, settings); err != nil {
+		return nil, fmt.Errorf("%w: pickup_from", ErrInvalidFilter)
+	}
+	if f.PickupTo, err = parseBound(req.PickupTo, true, settings); err != nil {
+		return nil, fmt.Errorf("%w: pickup_to", ErrInvalidFilter)
+	}
+	return f, nil
+}
+
+// parseBound parses a range bound. A plain date is a business day; as an upper bound it covers that whole day.
+func parseBound(value string, upper bool, settings *store.StoreSettings) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	day, err := settings.ParseBusinessDay(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if upper {
+		return day.End, nil
+	}
+	return day.Start, nil
+}
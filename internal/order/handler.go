@@ -1,12 +1,19 @@
 package order
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"sumunar-pos-core/internal/addon"
+	basedto "sumunar-pos-core/internal/base/dto"
+	"sumunar-pos-core/internal/customer"
 	"sumunar-pos-core/internal/order/dto"
+	"sumunar-pos-core/internal/prepaid"
+	"sumunar-pos-core/internal/promo"
 
 	"github.com/labstack/echo/v4"
+	"github.com/skip2/go-qrcode"
 )
 
 type Handler struct {
@@ -28,48 +35,76 @@ func (h *Handler) Create(c echo.Context) error {
 	}
 
 	userID := c.Get("user_id").(string)
+	role, _ := c.Get("role").(string)
 
-	resp, err := h.service.CreateOrder(c.Request().Context(), req, userID)
+	resp, err := h.service.CreateOrder(c.Request().Context(), req, userID, role)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Message: err.Error()})
+		return c.JSON(errorStatus(err), dto.ErrorResponse{Message: err.Error()})
 	}
 
 	return c.JSON(http.StatusCreated, resp)
 }
 
-// FindAll orders
+// EstimatePickup returns the earliest pickup date for the items before the order is saved
+func (h *Handler) EstimatePickup(c echo.Context) error {
+	var req dto.PickupEstimateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: "Invalid request"})
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+	}
+
+	resp, err := h.service.EstimatePickup(c.Request().Context(), &req)
+	if err != nil {
+		return c.JSON(errorStatus(err), dto.ErrorResponse{Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// FindAll orders, filtered by the query string (see dto.OrderListRequest)
 func (h *Handler) FindAll(c echo.Context) error {
-	limitStr := c.QueryParam("limit")
-	offsetStr := c.QueryParam("offset")
+	var req dto.OrderListRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: "Invalid request"})
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+	}
 
-	limit, _ := strconv.Atoi(limitStr)
-	offset, _ := strconv.Atoi(offsetStr)
-	if limit <= 0 {
-		limit = 20
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
 	}
 
-	orders, total, err := h.service.FindAll(c.Request().Context(), limit, offset)
+	orders, total, err := h.service.FindAll(c.Request().Context(), &req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Message: err.Error()})
+		return c.JSON(errorStatus(err), dto.ErrorResponse{Message: err.Error()})
 	}
 
-	return c.JSON(http.StatusOK, map[string]any{
-		"data":  orders,
-		"total": total,
-	})
+	page := req.Offset/req.Limit + 1
+	res := basedto.NewPaginatedResponse(orders, total, page, req.Limit)
+	// offset tidak selalu kelipatan limit, kembalikan apa adanya
+	res.Offset = req.Offset
+	return c.JSON(http.StatusOK, res)
 }
 
 // GetByID
-// func (h *Handler) FindByID(c echo.Context) error {
-// 	id := c.Param("id")
+func (h *Handler) FindByID(c echo.Context) error {
+	id := c.Param("id")
 
-// 	order, err := h.service.FindByID(c.Request().Context(), id)
-// 	if err != nil {
-// 		return c.JSON(http.StatusNotFound, dto.ErrorResponse{Message: "Order not found"})
-// 	}
+	order, err := h.service.FindByID(c.Request().Context(), id)
+	if err != nil {
+		return c.JSON(errorStatus(err), dto.ErrorResponse{Message: err.Error()})
+	}
 
-// 	return c.JSON(http.StatusOK, order)
-// }
+	return c.JSON(http.StatusOK, order)
+}
 
 // Update
 func (h *Handler) Update(c echo.Context) error {
@@ -84,10 +119,11 @@ func (h *Handler) Update(c echo.Context) error {
 	}
 
 	userID := c.Get("user_id").(string)
+	role, _ := c.Get("role").(string)
 
-	resp, err := h.service.Update(c.Request().Context(), id, &req, userID)
+	resp, err := h.service.Update(c.Request().Context(), id, &req, userID, role)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Message: err.Error()})
+		return c.JSON(errorStatus(err), dto.ErrorResponse{Message: err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, resp)
@@ -96,10 +132,185 @@ func (h *Handler) Update(c echo.Context) error {
 // Delete
 func (h *Handler) Delete(c echo.Context) error {
 	id := c.Param("id")
+	userID := c.Get("user_id").(string)
 
-	if err := h.service.Delete(c.Request().Context(), id); err != nil {
-		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Message: err.Error()})
+	if err := h.service.Delete(c.Request().Context(), id, userID); err != nil {
+		return c.JSON(errorStatus(err), dto.ErrorResponse{Message: err.Error()})
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+// UpdateStatus
+func (h *Handler) UpdateStatus(c echo.Context) error {
+	id := c.Param("id")
+	var req dto.OrderStatusRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: "Invalid request"})
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+	}
+
+	userID := c.Get("user_id").(string)
+
+	resp, err := h.service.UpdateStatus(c.Request().Context(), id, &req, userID)
+	if err != nil {
+		return c.JSON(errorStatus(err), dto.ErrorResponse{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// StatusHistory
+func (h *Handler) StatusHistory(c echo.Context) error {
+	id := c.Param("id")
+
+	history, err := h.service.StatusHistory(c.Request().Context(), id)
+	if err != nil {
+		return c.JSON(errorStatus(err), dto.ErrorResponse{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"data": history,
+	})
+}
+
+// AddPayment
+func (h *Handler) AddPayment(c echo.Context) error {
+	id := c.Param("id")
+	var req dto.OrderPaymentRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: "Invalid request"})
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+	}
+
+	userID := c.Get("user_id").(string)
+
+	resp, err := h.service.AddPayment(c.Request().Context(), id, &req, userID)
+	if err != nil {
+		return c.JSON(errorStatus(err), dto.ErrorResponse{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// AddWalletPayment pays the order from the customer's prepaid package (kuota atau saldo).
+func (h *Handler) AddWalletPayment(c echo.Context) error {
+	id := c.Param("id")
+	var req dto.OrderWalletPaymentRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: "Invalid request"})
+	}
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+	}
+
+	userID := c.Get("user_id").(string)
+
+	resp, err := h.service.AddWalletPayment(c.Request().Context(), id, &req, userID)
+	if err != nil {
+		return c.JSON(errorStatus(err), dto.ErrorResponse{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// Payments
+func (h *Handler) Payments(c echo.Context) error {
+	id := c.Param("id")
+
+	payments, err := h.service.Payments(c.Request().Context(), id)
+	if err != nil {
+		return c.JSON(errorStatus(err), dto.ErrorResponse{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"data": payments,
+	})
+}
+
+// QRIS returns the dynamic QRIS string for the order's outstanding balance
+func (h *Handler) QRIS(c echo.Context) error {
+	id := c.Param("id")
+
+	resp, err := h.service.QRIS(c.Request().Context(), id)
+	if err != nil {
+		return c.JSON(errorStatus(err), dto.ErrorResponse{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// QRISImage renders the same payload as a PNG QR code, ?size= in pixels
+func (h *Handler) QRISImage(c echo.Context) error {
+	id := c.Param("id")
+	size, _ := strconv.Atoi(c.QueryParam("size"))
+	if size <= 0 || size > 1024 {
+		size = 320
+	}
+
+	resp, err := h.service.QRIS(c.Request().Context(), id)
+	if err != nil {
+		return c.JSON(errorStatus(err), dto.ErrorResponse{Message: err.Error()})
+	}
+
+	png, err := qrcode.Encode(resp.Payload, qrcode.Medium, size)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Message: err.Error()})
+	}
+
+	return c.Blob(http.StatusOK, "image/png", png)
+}
+
+// PriceOverrides
+func (h *Handler) PriceOverrides(c echo.Context) error {
+	id := c.Param("id")
+
+	overrides, err := h.service.PriceOverrides(c.Request().Context(), id)
+	if err != nil {
+		return c.JSON(errorStatus(err), dto.ErrorResponse{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"data": overrides,
+	})
+}
+
+// errorStatus maps service errors to HTTP status codes
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrOrderNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidStatus), errors.Is(err, ErrInvalidPaymentMethod), errors.Is(err, ErrInvalidFilter),
+		errors.Is(err, ErrProductServiceNotFound), errors.Is(err, ErrOverrideReasonRequired),
+		errors.Is(err, ErrProductServiceNotInStore), errors.Is(err, ErrProductServiceInactive),
+		errors.Is(err, addon.ErrAddonNotFound), errors.Is(err, ErrAddonNotApplicable),
+		errors.Is(err, ErrExpressNotAvailable), errors.Is(err, ErrInvalidPickupDate):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrInvalidStatusTransition), errors.Is(err, ErrOrderNotEditable),
+		errors.Is(err, ErrOrderNotPayable), errors.Is(err, ErrOrderAlreadyPaid),
+		errors.Is(err, ErrQRISNotConfigured), errors.Is(err, ErrPickupTooEarly), errors.Is(err, ErrPickupStoreClosed):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ErrDiscountApprovalRequired), errors.Is(err, ErrInvalidDiscountApproval):
+		return http.StatusForbidden
+	case errors.Is(err, promo.ErrPromoNotFound), errors.Is(err, promo.ErrPromoInactive),
+		errors.Is(err, promo.ErrPromoNotValid), errors.Is(err, promo.ErrPromoUsageLimit),
+		errors.Is(err, promo.ErrPromoMinSpend), errors.Is(err, promo.ErrPromoNotApplicable),
+		errors.Is(err, ErrLoyaltyDisabled), errors.Is(err, customer.ErrInsufficientPoints),
+		errors.Is(err, prepaid.ErrWalletExpired), errors.Is(err, prepaid.ErrWalletUsedUp),
+		errors.Is(err, ErrWalletNotApplicable), errors.Is(err, ErrNothingToCover):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, prepaid.ErrWalletNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrStatusConflict), errors.Is(err, ErrInvoiceNumberTaken):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
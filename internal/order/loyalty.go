@@ -0,0 +1,134 @@
+package order
+
+import (
+	"context"
+	"time"
+
+	"sumunar-pos-core/internal/customer"
+	"sumunar-pos-core/internal/store"
+	"sumunar-pos-core/pkg/db"
+
+	"github.com/google/uuid"
+)
+
+// applyLoyalty sets the customer's tier discount and the points to redeem on a new order.
+// The balance is checked again when the points are taken inside the transaction.
+func applyLoyalty(order *Order, cust *customer.Customer, settings *store.StoreSettings, points int) error {
+	if points > 0 && !settings.LoyaltyEnabled {
+		return ErrLoyaltyDisabled
+	}
+	if points > cust.PointsBalance {
+		return customer.ErrInsufficientPoints
+	}
+	order.PointsRedeemed = points
+	if tier := settings.TierFor(cust.LifetimeSpend); tier != nil {
+		order.TierName = tier.Name
+		order.TierDiscount = tier.Discount
+	}
+	return nil
+}
+
+// redeemPoints mencatat selisih poin yang ditukar terhadap prev: dipotong dari saldo pelanggan,
+// atau dikembalikan jika setelah edit order poin yang terpakai berkurang.
+func (s *OrderService) redeemPoints(ctx context.Context, tx db.DBTX, order *Order, prev int, settings *store.StoreSettings, by string, at time.Time) error {
+	diff := order.PointsRedeemed - prev
+	if diff == 0 {
+		return nil
+	}
+	entry := pointsEntry(order, customer.PointsRedeem, -diff, "Tukar poin "+order.InvoiceNumber, by, at)
+	if diff < 0 {
+		entry.Type = customer.PointsReversal
+		entry.Description = "Pengembalian poin " + order.InvoiceNumber
+		entry.ExpiresAt = settings.PointsExpiry(at)
+	}
+	return s.customerRepo.AddPoints(ctx, tx, entry)
+}
+
+// awardPoints credits the order's points and spend to the customer once the order is fully paid.
+// It runs at most once per order.
+func (s *OrderService) awardPoints(ctx context.Context, tx db.DBTX, order *Order, settings *store.StoreSettings, by string, at time.Time) error {
+	if order.PointsAwarded || !settings.LoyaltyEnabled || OutstandingAmount(order.TotalPrice, order.PaidAmount) > 0 {
+		return nil
+	}
+	cust, err := s.customerRepo.FindByID(ctx, order.CustomerID)
+	if err != nil {
+		return err
+	}
+
+	order.PointsEarned = settings.PointsFor(order.TotalPrice)
+	if order.PointsEarned > 0 {
+		entry := pointsEntry(order, customer.PointsEarn, order.PointsEarned, "Poin order "+order.InvoiceNumber, by, at)
+		entry.ExpiresAt = settings.PointsExpiry(at)
+		if err := s.customerRepo.AddPoints(ctx, tx, entry); err != nil {
+			return err
+		}
+	}
+	tier := tierName(settings.TierFor(cust.LifetimeSpend + order.TotalPrice))
+	if err := s.customerRepo.UpdateLoyalty(ctx, tx, cust.ID, order.TotalPrice, tier); err != nil {
+		return err
+	}
+
+	order.PointsAwarded = true
+	return s.repo.UpdatePoints(ctx, tx, order)
+}
+
+// reversePoints membatalkan efek loyalti dari order yang dibatalkan: poin yang ditukar dikembalikan,
+// poin dan belanja yang sudah dicatat ditarik lagi (sebatas saldo poin yang tersisa).
+func (s *OrderService) reversePoints(ctx context.Context, tx db.DBTX, order *Order, by string, at time.Time) error {
+	if order.PointsRedeemed == 0 && !order.PointsAwarded {
+		return nil
+	}
+	settings, err := s.storeRepo.FindSettings(ctx, order.StoreID)
+	if err != nil {
+		return err
+	}
+
+	if order.PointsAwarded {
+		cust, err := s.customerRepo.FindByID(ctx, order.CustomerID)
+		if err != nil {
+			return err
+		}
+		if taken := min(order.PointsEarned, cust.PointsBalance); taken > 0 {
+			entry := pointsEntry(order, customer.PointsReversal, -taken, "Batal order "+order.InvoiceNumber, by, at)
+			if err := s.customerRepo.AddPoints(ctx, tx, entry); err != nil {
+				return err
+			}
+		}
+		tier := tierName(settings.TierFor(cust.LifetimeSpend - order.TotalPrice))
+		if err := s.customerRepo.UpdateLoyalty(ctx, tx, cust.ID, -order.TotalPrice, tier); err != nil {
+			return err
+		}
+		order.PointsAwarded = false
+		if err := s.repo.UpdatePoints(ctx, tx, order); err != nil {
+			return err
+		}
+	}
+
+	if order.PointsRedeemed > 0 {
+		entry := pointsEntry(order, customer.PointsReversal, order.PointsRedeemed, "Pengembalian poin "+order.InvoiceNumber, by, at)
+		entry.ExpiresAt = settings.PointsExpiry(at)
+		return s.customerRepo.AddPoints(ctx, tx, entry)
+	}
+	return nil
+}
+
+func pointsEntry(order *Order, typ string, points int, description, by string, at time.Time) *customer.PointsEntry {
+	return &customer.PointsEntry{
+		ID:          uuid.New().String(),
+		CustomerID:  order.CustomerID,
+		StoreID:     order.StoreID,
+		OrderID:     order.ID,
+		Type:        typ,
+		Points:      points,
+		Description: description,
+		CreatedAt:   at,
+		CreatedBy:   by,
+	}
+}
+
+func tierName(tier *store.LoyaltyTier) string {
+	if tier == nil {
+		return ""
+	}
+	return tier.Name
+}
@@ -4,7 +4,9 @@ import (
 	"time"
 
 	"sumunar-pos-core/internal/customer"
+	"sumunar-pos-core/internal/enum"
 	"sumunar-pos-core/internal/order/dto"
+	"sumunar-pos-core/internal/paymentmethod"
 
 	"github.com/google/uuid"
 )
@@ -19,39 +21,19 @@ func ToOrderModel(req dto.OrderRequest, createdBy string) (*Order, []*OrderItem,
 		StoreID:       req.StoreID,
 		InvoiceNumber: "", // akan digenerate di service
 		CustomerID:    req.CustomerID,
-		Status:        "pending",
+		Status:        enum.OrderStatusPending,
 		Discount:      req.Discount,
+		DiscountFixed: req.DiscountFixed,
 		TotalPrice:    0, // akan dihitung di service
-		PaidAmount:    req.PaidAmount,
+		PaidAmount:    0, // akan dihitung di service dari pembayaran awal
 		Change:        0, // akan dihitung di service
-		PickupDate:    mustParseTime(req.PickupDate),
 		CreatedAt:     now,
 		CreatedBy:     createdBy,
 		UpdatedAt:     now,
 		UpdatedBy:     createdBy,
 	}
 
-	var items []*OrderItem
-	for _, item := range req.Items {
-		items = append(items, &OrderItem{
-			ID:               uuid.New().String(),
-			OrderID:          orderID,
-			ProductServiceID: item.ProductServiceID,
-			Quantity:         item.Quantity,
-			TotalPrice:       0, // akan dihitung di service
-			Notes:            item.Notes,
-		})
-	}
-
-	return order, items, nil
-}
-
-func mustParseTime(s string) time.Time {
-	t, err := time.Parse(time.RFC3339, s)
-	if err != nil {
-		return time.Now() // fallback, tapi bisa diubah sesuai kebutuhan
-	}
-	return t
+	return order, ToOrderItemModels(orderID, req.Items), nil
 }
 
 func ToOrderResponse(order *Order, customer *customer.Customer, items []*OrderItem) *dto.OrderResponse {
@@ -60,57 +42,211 @@ func ToOrderResponse(order *Order, customer *customer.Customer, items []*OrderIt
 		respItems = append(respItems, dto.OrderItemResponse{
 			ID:               item.ID,
 			ProductServiceID: item.ProductServiceID,
+			ServiceTypeID:    item.ServiceTypeID,
+			ProductName:      item.ProductName,
+			ServiceName:      item.ServiceName,
+			Unit:             item.Unit,
+			UnitPrice:        item.UnitPrice,
+			PriceOverridden:  item.PriceOverridden,
+			TaxExempt:        item.TaxExempt,
 			Quantity:         item.Quantity,
+			ChargedQuantity:  item.ChargedQuantity,
+			Discount:         item.Discount,
+			AddonAmount:      item.AddonAmount,
+			ExpressTier:      item.ExpressTier,
+			ExpressRate:      item.ExpressRate,
+			ExpressSurcharge: item.ExpressSurcharge,
+			TurnaroundHours:  item.TurnaroundHours,
+			DiscountAmount:   item.DiscountAmount,
 			TotalPrice:       item.TotalPrice,
 			Notes:            item.Notes,
+			Addons:           ToOrderItemAddonResponses(item.Addons),
 		})
 	}
 
-	return &dto.OrderResponse{
-		ID:              order.ID,
-		InvoiceNumber:   order.InvoiceNumber,
-		StoreID:         order.StoreID,
-		CustomerID:      order.CustomerID,
-		CustomerName:    customer.Name,
-		CustomerPhone:   customer.Phone,
-		CustomerAddress: customer.Address,
-		Status:          order.Status,
-		Discount:        order.Discount,
-		TotalPrice:      order.TotalPrice,
-		PaidAmount:      order.PaidAmount,
-		Change:          order.Change,
-		PickupDate:      order.PickupDate.Format(time.RFC3339),
-		CreatedAt:       order.CreatedAt.Format(time.RFC3339),
-		CreatedBy:       order.CreatedBy,
-		OrderItems:      respItems,
+	res := &dto.OrderResponse{
+		ID:                 order.ID,
+		InvoiceNumber:      order.InvoiceNumber,
+		StoreID:            order.StoreID,
+		CustomerID:         order.CustomerID,
+		Status:             order.Status,
+		Subtotal:           order.Subtotal,
+		Discount:           order.Discount,
+		DiscountFixed:      order.DiscountFixed,
+		DiscountAmount:     order.DiscountAmount,
+		PromoCode:          order.PromoCode,
+		PromoDiscount:      order.PromoDiscount,
+		DiscountApprovedBy: order.DiscountApprovedBy,
+		TierName:           order.TierName,
+		TierDiscount:       order.TierDiscount,
+		TierDiscountAmount: order.TierDiscountAmount,
+		PointsRedeemed:     order.PointsRedeemed,
+		PointsDiscount:     order.PointsDiscount,
+		PointsEarned:       order.PointsEarned,
+		TaxRate:            order.TaxRate,
+		TaxInclusive:       order.TaxInclusive,
+		TaxBase:            order.TaxBase,
+		TaxAmount:          order.TaxAmount,
+		DeliveryFee:        order.DeliveryFee,
+		Rounding:           order.Rounding,
+		TotalPrice:         order.TotalPrice,
+		PaidAmount:         order.PaidAmount,
+		Change:             order.Change,
+		PaymentStatus:      PaymentStatus(order.TotalPrice, order.PaidAmount),
+		Outstanding:        OutstandingAmount(order.TotalPrice, order.PaidAmount),
+		PickupDate:         order.PickupDate.Format(time.RFC3339),
+		Express:            expressTier(items),
+		CreatedAt:          order.CreatedAt.Format(time.RFC3339),
+		CreatedBy:          order.CreatedBy,
+		OrderItems:         respItems,
 	}
+	// customer bisa sudah dihapus
+	if customer != nil {
+		res.CustomerName = customer.Name
+		res.CustomerPhone = customer.Phone
+		res.CustomerAddress = customer.Address
+	}
+	return res
+}
+
+// ToOrderDetailResponse is the full order for the detail screen: named items, customer,
+// who created and last updated it, status history and payments. names maps user IDs to full names.
+func ToOrderDetailResponse(order *Order, customer *customer.Customer, items []*OrderItem,
+	history []*OrderStatusHistory, payments []*OrderPayment, names map[string]string) *dto.OrderResponse {
+	res := ToOrderResponse(order, customer, items)
+	if customer != nil {
+		res.CustomerEmail = customer.Email
+	}
+	res.CreatedByName = names[order.CreatedBy]
+	res.UpdatedAt = order.UpdatedAt.Format(time.RFC3339)
+	res.UpdatedBy = order.UpdatedBy
+	res.UpdatedByName = names[order.UpdatedBy]
+
+	res.StatusHistory = ToStatusHistoryResponses(history)
+	for i := range res.StatusHistory {
+		res.StatusHistory[i].ChangedByName = names[res.StatusHistory[i].ChangedBy]
+	}
+	res.Payments = ToOrderPaymentResponses(payments)
+	for i := range res.Payments {
+		res.Payments[i].ReceivedByName = names[res.Payments[i].ReceivedBy]
+	}
+	return res
 }
 
 func UpdateOrderModel(order *Order, req *dto.OrderRequest, updatedBy string) *Order {
 	order.CustomerID = req.CustomerID
-	order.Status = req.Status
 	order.Discount = req.Discount
+	order.DiscountFixed = req.DiscountFixed
 	// order.TotalPrice = req.TotalPrice
-	order.PaidAmount = req.PaidAmount
 	// order.Change = req.Change
-	// order.PickupDate = req.PickupDate
 	order.UpdatedAt = time.Now()
 	order.UpdatedBy = updatedBy
 
 	return order
 }
 
-func ToOrderItemModelsForUpdate(orderID string, reqItems []dto.OrderItemRequest) []*OrderItem {
+// ToOrderItemModels builds the order lines; snapshot and prices are filled in by the service.
+func ToOrderItemModels(orderID string, reqItems []dto.OrderItemRequest) []*OrderItem {
 	items := make([]*OrderItem, 0, len(reqItems))
 	for _, item := range reqItems {
 		items = append(items, &OrderItem{
-			ID:               uuid.New().String(), // ID baru karena hapus-tambah ulang
+			ID:               uuid.New().String(), // ID baru juga saat update karena hapus-tambah ulang
 			OrderID:          orderID,
 			ProductServiceID: item.ProductServiceID,
 			Quantity:         item.Quantity,
-			// TotalPrice:       item.TotalPrice,
-			Notes: item.Notes,
+			Discount:         item.Discount,
+			Notes:            item.Notes,
 		})
 	}
 	return items
 }
+
+func ToOrderItemAddonResponses(addons []*OrderItemAddon) []dto.OrderItemAddonResponse {
+	res := make([]dto.OrderItemAddonResponse, 0, len(addons))
+	for _, a := range addons {
+		res = append(res, dto.OrderItemAddonResponse{
+			AddonID:   a.AddonID,
+			Name:      a.Name,
+			PriceType: a.PriceType,
+			UnitPrice: a.UnitPrice,
+			Quantity:  a.Quantity,
+			Amount:    a.Amount,
+		})
+	}
+	return res
+}
+
+func ToStatusHistoryModel(orderID, fromStatus, toStatus, reason, changedBy string, changedAt time.Time) *OrderStatusHistory {
+	return &OrderStatusHistory{
+		ID:         uuid.New().String(),
+		OrderID:    orderID,
+		FromStatus: fromStatus,
+		ToStatus:   toStatus,
+		Reason:     reason,
+		ChangedBy:  changedBy,
+		ChangedAt:  changedAt,
+	}
+}
+
+func ToStatusHistoryResponses(history []*OrderStatusHistory) []dto.OrderStatusHistoryResponse {
+	res := make([]dto.OrderStatusHistoryResponse, 0, len(history))
+	for _, h := range history {
+		res = append(res, dto.OrderStatusHistoryResponse{
+			ID:         h.ID,
+			FromStatus: h.FromStatus,
+			ToStatus:   h.ToStatus,
+			Reason:     h.Reason,
+			ChangedBy:  h.ChangedBy,
+			ChangedAt:  h.ChangedAt.Format(time.RFC3339),
+		})
+	}
+	return res
+}
+
+func ToPriceOverrideResponses(overrides []*OrderItemPriceOverride) []dto.OrderPriceOverrideResponse {
+	res := make([]dto.OrderPriceOverrideResponse, 0, len(overrides))
+	for _, o := range overrides {
+		res = append(res, dto.OrderPriceOverrideResponse{
+			ID:               o.ID,
+			OrderItemID:      o.OrderItemID,
+			ProductServiceID: o.ProductServiceID,
+			CatalogPrice:     o.CatalogPrice,
+			OverridePrice:    o.OverridePrice,
+			Reason:           o.Reason,
+			OverriddenBy:     o.OverriddenBy,
+			OverriddenAt:     o.OverriddenAt.Format(time.RFC3339),
+		})
+	}
+	return res
+}
+
+func ToOrderPaymentModel(orderID string, req *dto.OrderPaymentRequest, method *paymentmethod.PaymentMethod, receivedBy string) *OrderPayment {
+	return &OrderPayment{
+		ID:              uuid.New().String(),
+		OrderID:         orderID,
+		Amount:          req.Amount,
+		PaymentMethodID: method.ID,
+		Method:          method.Code,
+		Reference:       req.Reference,
+		ReceivedBy:      receivedBy,
+		ReceivedAt:      time.Now(),
+	}
+}
+
+func ToOrderPaymentResponses(payments []*OrderPayment) []dto.OrderPaymentResponse {
+	res := make([]dto.OrderPaymentResponse, 0, len(payments))
+	for _, p := range payments {
+		res = append(res, dto.OrderPaymentResponse{
+			ID:              p.ID,
+			Amount:          p.Amount,
+			Change:          p.Change,
+			PaymentMethodID: p.PaymentMethodID,
+			Method:          p.Method,
+			Reference:       p.Reference,
+			WalletID:        p.WalletID,
+			ReceivedBy:      p.ReceivedBy,
+			ReceivedAt:      p.ReceivedAt.Format(time.RFC3339),
+		})
+	}
+	return res
+}
@@ -1,29 +1,145 @@
 package order
 
-import "time"
+import (
+	"time"
+
+	"sumunar-pos-core/pkg/money"
+)
 
 type Order struct {
-	ID            string    `json:"id"`
-	StoreID       string    `json:"store_id"`
-	InvoiceNumber string    `json:"invoice_number"`
-	CustomerID    string    `json:"customer_id"`
-	Status        string    `json:"status"`   // pending, processed, done, taken
-	Discount      float64   `json:"discount"` // e.g. 10.00 for 10%
-	TotalPrice    float64   `json:"total_price"`
-	PaidAmount    float64   `json:"paid_amount"`
-	Change        float64   `json:"change"`
-	PickupDate    time.Time `json:"pickup_date"`
-	CreatedAt     time.Time `json:"created_at"`
-	CreatedBy     string    `json:"created_by"` // user ID dari kasir
-	UpdatedAt     time.Time `json:"updated_at"`
-	UpdatedBy     string    `json:"updated_by"`
+	ID                 string       `json:"id"`
+	StoreID            string       `json:"store_id"`
+	InvoiceNumber      string       `json:"invoice_number"`
+	CustomerID         string       `json:"customer_id"`
+	Status             string       `json:"status"`          // pending, processed, done, taken, cancelled
+	Subtotal           money.Amount `json:"subtotal"`        // jumlah total item
+	Discount           float64      `json:"discount"`        // e.g. 10.00 for 10%
+	DiscountFixed      money.Amount `json:"discount_fixed"`  // potongan nominal tambahan untuk seluruh order
+	DiscountAmount     money.Amount `json:"discount_amount"` // nominal diskon manual dari Subtotal (persen + nominal)
+	PromoID            string       `json:"promo_id"`        // kosong jika tanpa kode promo
+	PromoCode          string       `json:"promo_code"`
+	PromoDiscount      money.Amount `json:"promo_discount"`
+	DiscountApprovedBy string       `json:"discount_approved_by"` // owner yang menyetujui diskon di atas batas role kasir
+	TierName           string       `json:"tier_name"`            // tier pelanggan saat order dibuat
+	TierDiscount       float64      `json:"tier_discount"`        // persen diskon otomatis tier
+	TierDiscountAmount money.Amount `json:"tier_discount_amount"`
+	PointsRedeemed     int          `json:"points_redeemed"` // poin yang ditukar sebagai potongan
+	PointsDiscount     money.Amount `json:"points_discount"`
+	PointsEarned       int          `json:"points_earned"`  // poin yang didapat setelah order lunas
+	PointsAwarded      bool         `json:"points_awarded"` // poin dan belanja sudah dicatat ke pelanggan
+	TaxRate            float64      `json:"tax_rate"`       // persen PPN toko saat order dibuat, 0 = non-PKP
+	TaxInclusive       bool         `json:"tax_inclusive"`  // harga item sudah termasuk PPN
+	TaxBase            money.Amount `json:"tax_base"`       // DPP: bagian setelah diskon yang dikenai pajak, tanpa PPN
+	TaxAmount          money.Amount `json:"tax_amount"`
+	DeliveryFee        money.Amount `json:"delivery_fee"`        // ongkos antar-jemput dari delivery job order, tanpa pajak dan diskon
+	Rounding           money.Amount `json:"rounding_adjustment"` // pembulatan kas toko, sudah termasuk di TotalPrice
+	TotalPrice         money.Amount `json:"total_price"`         // grand total yang harus dibayar
+	PaidAmount         money.Amount `json:"paid_amount"`         // jumlah dari semua pembayaran yang teralokasi ke order
+	Change             money.Amount `json:"change"`              // total kembalian dari semua pembayaran
+	PickupDate         time.Time    `json:"pickup_date"`
+	CreatedAt          time.Time    `json:"created_at"`
+	CreatedBy          string       `json:"created_by"` // user ID dari kasir
+	UpdatedAt          time.Time    `json:"updated_at"`
+	UpdatedBy          string       `json:"updated_by"`
 }
 
 type OrderItem struct {
-	ID               string  `json:"id"`
-	OrderID          string  `json:"order_id"`
-	ProductServiceID string  `json:"product_service_id"`
-	Quantity         float64 `json:"quantity"` // 3 pcs, 2.5 kg, etc
-	TotalPrice       float64 `json:"total_price"`
-	Notes            string  `json:"notes"` // opsional, misal "khusus pakaian putih"
+	ID               string            `json:"id"`
+	OrderID          string            `json:"order_id"`
+	ProductServiceID string            `json:"product_service_id"`
+	ServiceTypeID    string            `json:"service_type_id"` // snapshot, dipakai untuk promo dan pajak
+	ProductName      string            `json:"product_name"`    // snapshot katalog saat order dibuat/diubah
+	ServiceName      string            `json:"service_name"`
+	Unit             string            `json:"unit"`
+	UnitPrice        money.Amount      `json:"unit_price"`
+	PriceOverridden  bool              `json:"price_overridden"` // harga diisi manual, lihat OrderItemPriceOverride
+	TaxExempt        bool              `json:"tax_exempt"`       // jenis layanan dibebaskan dari PPN di toko ini
+	Quantity         float64           `json:"quantity"`         // 3 pcs, 2.5 kg, etc
+	ChargedQuantity  float64           `json:"charged_quantity"` // kuantitas yang ditagih setelah aturan harga (pembulatan, minimum)
+	AddonAmount      money.Amount      `json:"addon_amount"`     // jumlah semua add-on item, sebelum diskon item
+	ExpressTier      string            `json:"express_tier"`     // tier express yang dipilih pelanggan, kosong = standar
+	ExpressRate      float64           `json:"express_rate"`     // persen biaya tambahan tier express
+	ExpressSurcharge money.Amount      `json:"express_surcharge"`
+	TurnaroundHours  int               `json:"turnaround_hours"` // lama pengerjaan item (standar atau express)
+	Discount         float64           `json:"discount"`         // diskon item dalam persen
+	DiscountAmount   money.Amount      `json:"discount_amount"`  // total potongan item (persen + nominal)
+	TotalPrice       money.Amount      `json:"total_price"`      // UnitPrice x ChargedQuantity (min. tagihan minimum) + ExpressSurcharge + AddonAmount - DiscountAmount
+	Notes            string            `json:"notes"`            // opsional, misal "khusus pakaian putih"
+	Addons           []*OrderItemAddon `json:"addons"`
+}
+
+// OrderItemAddon is an extra on an order item, with the add-on catalog snapshot at pricing time.
+type OrderItemAddon struct {
+	ID          string       `json:"id"`
+	OrderID     string       `json:"order_id"`
+	OrderItemID string       `json:"order_item_id"`
+	AddonID     string       `json:"addon_id"`
+	Name        string       `json:"name"`
+	PriceType   string       `json:"price_type"` // fixed, per_unit
+	UnitPrice   money.Amount `json:"unit_price"`
+	Quantity    float64      `json:"quantity"` // per_unit: kuantitas yang ditagih item
+	Amount      money.Amount `json:"amount"`
+}
+
+// Name is the line title, e.g. "Kemeja - Cuci Setrika".
+func (i *OrderItem) Name() string {
+	if i.ServiceName == "" {
+		return i.ProductName
+	}
+	return i.ProductName + " - " + i.ServiceName
+}
+
+// MinChargeApplied reports whether the line was raised to the product service's minimum charge.
+func (i *OrderItem) MinChargeApplied() bool {
+	return i.TotalPrice+i.DiscountAmount-i.AddonAmount-i.ExpressSurcharge > i.UnitPrice.Mul(i.ChargedQuantity, money.RoundHalfUp)
+}
+
+// ServiceAmount is TotalPrice without the add-ons and express surcharge; the item discount is split over them pro rata.
+func (i *OrderItem) ServiceAmount() money.Amount {
+	extras := i.AddonAmount + i.ExpressSurcharge
+	if extras == 0 {
+		return i.TotalPrice
+	}
+	return i.TotalPrice - i.TotalPrice.Share(extras, i.TotalPrice+i.DiscountAmount)
+}
+
+// OrderItemPriceOverride records a unit price entered by hand instead of the catalog price.
+type OrderItemPriceOverride struct {
+	ID               string       `json:"id"`
+	OrderID          string       `json:"order_id"`
+	OrderItemID      string       `json:"order_item_id"`
+	ProductServiceID string       `json:"product_service_id"`
+	CatalogPrice     money.Amount `json:"catalog_price"`
+	OverridePrice    money.Amount `json:"override_price"`
+	Reason           string       `json:"reason"`
+	OverriddenBy     string       `json:"overridden_by"`
+	OverriddenAt     time.Time    `json:"overridden_at"`
+}
+
+type OrderStatusHistory struct {
+	ID         string    `json:"id"`
+	OrderID    string    `json:"order_id"`
+	FromStatus string    `json:"from_status"` // kosong untuk status awal
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"`
+	ChangedBy  string    `json:"changed_by"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+type OrderPayment struct {
+	ID              string       `json:"id"`
+	OrderID         string       `json:"order_id"`
+	Amount          money.Amount `json:"amount"` // nominal yang diserahkan pelanggan
+	Change          money.Amount `json:"change"` // kembalian untuk pembayaran ini
+	PaymentMethodID string       `json:"payment_method_id"`
+	Method          string       `json:"method"` // kode metode pembayaran saat transaksi, misal "cash", "bca"
+	Reference       string       `json:"reference"`
+	WalletID        string       `json:"wallet_id"` // diisi jika dibayar dari paket prabayar pelanggan
+	ReceivedBy      string       `json:"received_by"`
+	ReceivedAt      time.Time    `json:"received_at"`
+}
+
+// AppliedAmount is the part of the payment that reduces the order balance.
+func (p *OrderPayment) AppliedAmount() money.Amount {
+	return p.Amount - p.Change
 }
@@ -0,0 +1,25 @@
+package order
+
+import (
+	"context"
+
+	"sumunar-pos-core/internal/customer"
+	"sumunar-pos-core/pkg/db"
+)
+
+// Notifier queues customer notifications for an order event. It is called with the order
+// transaction so nothing is sent for a change that is rolled back.
+type Notifier interface {
+	Enqueue(ctx context.Context, tx db.DBTX, event string, order *Order, cust *customer.Customer) error
+}
+
+func (s *OrderService) notify(ctx context.Context, tx db.DBTX, order *Order, cust *customer.Customer) error {
+	if s.notifier == nil {
+		return nil
+	}
+	event, ok := statusEvents[order.Status]
+	if !ok {
+		return nil
+	}
+	return s.notifier.Enqueue(ctx, tx, event, order, cust)
+}
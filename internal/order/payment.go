@@ -0,0 +1,37 @@
+package order
+
+import (
+	"sumunar-pos-core/internal/enum"
+	"sumunar-pos-core/pkg/money"
+)
+
+// PaymentStatus derives the payment status of an order from its total and the amount applied to it.
+func PaymentStatus(total, paid money.Amount) string {
+	switch {
+	case paid <= 0 && total > 0:
+		return enum.PaymentStatusUnpaid
+	case paid < total:
+		return enum.PaymentStatusPartial
+	case paid > total:
+		return enum.PaymentStatusOverpaid
+	default:
+		return enum.PaymentStatusPaid
+	}
+}
+
+// OutstandingAmount is what the customer still owes on an order, never negative.
+func OutstandingAmount(total, paid money.Amount) money.Amount {
+	if paid >= total {
+		return 0
+	}
+	return total - paid
+}
+
+// splitPayment splits a tendered amount into the part applied to the outstanding balance
+// and the change handed back to the customer.
+func splitPayment(tendered, outstanding money.Amount) (applied, change money.Amount) {
+	if tendered <= outstanding {
+		return tendered, 0
+	}
+	return outstanding, tendered - outstanding
+}
@@ -0,0 +1,124 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"sumunar-pos-core/internal/order/dto"
+	"sumunar-pos-core/internal/servicetype"
+	"sumunar-pos-core/internal/store"
+)
+
+// applyTurnaround mengisi lama pengerjaan item dari jenis layanannya, atau lead time default toko jika jenis
+// layanan belum punya turnaround. Jika pelanggan memilih express, tier dengan nama itu harus tersedia di jenis
+// layanan item; biayanya dihitung setelah harga item diketahui.
+func (s *OrderService) applyTurnaround(ctx context.Context, item *OrderItem, serviceTypes map[string]*servicetype.ServiceType,
+	express string, settings *store.StoreSettings) error {
+	st, ok := serviceTypes[item.ServiceTypeID]
+	if !ok {
+		var err error
+		st, err = s.serviceTypeRepo.FindByID(ctx, item.ServiceTypeID)
+		if err != nil {
+			return err
+		}
+		serviceTypes[item.ServiceTypeID] = st
+	}
+
+	item.TurnaroundHours = st.TurnaroundHours
+	if item.TurnaroundHours == 0 {
+		item.TurnaroundHours = settings.PickupLeadHours
+	}
+	item.ExpressTier, item.ExpressRate = "", 0
+	express = strings.TrimSpace(express)
+	if express == "" {
+		return nil
+	}
+	tier, ok := st.Tier(express)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrExpressNotAvailable, st.Name)
+	}
+	item.ExpressTier = tier.Name
+	item.ExpressRate = tier.Surcharge
+	item.TurnaroundHours = tier.Hours
+	return nil
+}
+
+// EarliestPickup is when the order can be picked up: the slowest item's turnaround counted from at,
+// rounded up to the minute and moved to the store's next opening time if it falls outside opening
+// hours or on a holiday.
+func EarliestPickup(at time.Time, items []*OrderItem, settings *store.StoreSettings) time.Time {
+	hours := 0
+	for _, item := range items {
+		hours = max(hours, item.TurnaroundHours)
+	}
+	ready := at.Add(time.Duration(hours) * time.Hour)
+	if t := ready.Truncate(time.Minute); t.Before(ready) {
+		ready = t.Add(time.Minute)
+	}
+	return settings.NextOpen(ready)
+}
+
+// resolvePickup: pickup_date kosong diisi tanggal ambil paling cepat; yang diisi kasir tidak boleh
+// lebih cepat dari itu dan harus di jam buka toko.
+func resolvePickup(value string, earliest time.Time, settings *store.StoreSettings) (time.Time, error) {
+	if strings.TrimSpace(value) == "" {
+		return earliest, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, ErrInvalidPickupDate
+	}
+	if t.Before(earliest) {
+		return time.Time{}, fmt.Errorf("%w, earliest is %s", ErrPickupTooEarly, earliest.Format(time.RFC3339))
+	}
+	if !settings.NextOpen(t).Equal(t) {
+		return time.Time{}, ErrPickupStoreClosed
+	}
+	return t, nil
+}
+
+// EstimatePickup returns the earliest pickup date for items not ordered yet, so the cashier can
+// tell the customer before saving the order.
+func (s *OrderService) EstimatePickup(ctx context.Context, req *dto.PickupEstimateRequest) (*dto.PickupEstimateResponse, error) {
+	settings, err := s.storeRepo.FindSettings(ctx, req.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceTypes := map[string]*servicetype.ServiceType{}
+	items := make([]*OrderItem, 0, len(req.Items))
+	for _, r := range req.Items {
+		ps, err := s.productServiceRepo.FindDetailByID(ctx, r.ProductServiceID)
+		if err != nil {
+			return nil, ErrProductServiceNotFound
+		}
+		if ps.StoreID != req.StoreID {
+			return nil, ErrProductServiceNotInStore
+		}
+		item := &OrderItem{ProductServiceID: ps.ID, ServiceTypeID: ps.ServiceTypeID}
+		if err := s.applyTurnaround(ctx, item, serviceTypes, req.Express, settings); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	res := &dto.PickupEstimateResponse{
+		PickupDate: EarliestPickup(time.Now(), items, settings).In(settings.Location()).Format(time.RFC3339),
+	}
+	for _, item := range items {
+		res.TurnaroundHours = max(res.TurnaroundHours, item.TurnaroundHours)
+	}
+	return res, nil
+}
+
+// expressTier is the express tier picked for the order; every item carries the same one.
+func expressTier(items []*OrderItem) string {
+	for _, item := range items {
+		if item.ExpressTier != "" {
+			return item.ExpressTier
+		}
+	}
+	return ""
+}
@@ -0,0 +1,271 @@
+package order
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"sumunar-pos-core/internal/enum"
+	"sumunar-pos-core/internal/order/dto"
+	"sumunar-pos-core/internal/paymentmethod"
+	"sumunar-pos-core/internal/prepaid"
+	"sumunar-pos-core/pkg/db"
+	"sumunar-pos-core/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+// AddWalletPayment records a payment taken from the customer's prepaid wallet. The payment uses the
+// store's "prepaid" method so it stays out of the cash reconciliation; the money came in when the
+// package was sold.
+func (s *OrderService) AddWalletPayment(ctx context.Context, id string, req *dto.OrderWalletPaymentRequest, receivedBy string) (*dto.OrderResponse, error) {
+	// hanya untuk toko order; order dan item dibaca ulang setelah dikunci
+	current, _, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	method, err := s.prepaidMethod(ctx, current.StoreID, receivedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	order, err := s.repo.FindByIDForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status == enum.OrderStatusCancelled {
+		return nil, ErrOrderNotPayable
+	}
+	items, err := s.repo.FindItems(ctx, tx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	outstanding := OutstandingAmount(order.TotalPrice, order.PaidAmount)
+	if outstanding <= 0 {
+		return nil, ErrOrderAlreadyPaid
+	}
+
+	wallet, err := s.prepaidRepo.FindWalletForUpdate(ctx, tx, req.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet.CustomerID != order.CustomerID || wallet.StoreID != order.StoreID {
+		return nil, ErrWalletNotApplicable
+	}
+	now := time.Now()
+	if err := wallet.Usable(now); err != nil {
+		return nil, err
+	}
+
+	payment := &OrderPayment{
+		ID:              uuid.New().String(),
+		OrderID:         order.ID,
+		PaymentMethodID: method.ID,
+		Method:          method.Code,
+		Reference:       wallet.PackageName,
+		WalletID:        wallet.ID,
+		ReceivedBy:      receivedBy,
+		ReceivedAt:      now,
+	}
+
+	var entries []*prepaid.Entry
+	if wallet.Type == prepaid.TypeBalance {
+		amount := outstanding
+		if req.Amount > 0 {
+			amount = money.Min(req.Amount, outstanding)
+		}
+		amount = money.Min(amount, wallet.BalanceRemaining)
+		wallet.BalanceRemaining -= amount
+		entries = append(entries, walletEntry(wallet, order, payment, "", 0, -amount, receivedBy, now))
+	} else {
+		entries, err = s.coverQuota(ctx, tx, order, items, wallet, outstanding, payment, receivedBy, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, e := range entries {
+		payment.Amount -= e.Amount
+	}
+	if payment.Amount <= 0 {
+		return nil, ErrNothingToCover
+	}
+
+	order.PaidAmount += payment.Amount
+	order.UpdatedAt = now
+	order.UpdatedBy = receivedBy
+
+	if err := s.repo.CreatePayment(ctx, tx, payment); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdatePaymentTotals(ctx, tx, order); err != nil {
+		return nil, err
+	}
+	if err := s.prepaidRepo.UpdateWalletRemaining(ctx, tx, wallet); err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if err := s.prepaidRepo.CreateEntry(ctx, tx, e); err != nil {
+			return nil, err
+		}
+	}
+
+	settings, err := s.storeRepo.FindSettings(ctx, order.StoreID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.awardPoints(ctx, tx, order, settings, receivedBy, now); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.responseWithPayments(ctx, order)
+}
+
+// coverQuota memotong kuota untuk item yang satuannya sama dan termasuk paket. Kuantitas yang sudah
+// ditutup pembayaran wallet sebelumnya tidak dihitung lagi. Nilai yang dibayar adalah porsi item
+// tersebut dari total order (setelah diskon, pajak, dan pembulatan), paling banyak sisa tagihan.
+// Add-on dan biaya express item tidak termasuk kuota dan tetap dibayar biasa.
+func (s *OrderService) coverQuota(ctx context.Context, tx db.DBTX, order *Order, items []*OrderItem, wallet *prepaid.Wallet,
+	outstanding money.Amount, payment *OrderPayment, by string, at time.Time) ([]*prepaid.Entry, error) {
+	prev, err := s.prepaidRepo.FindOrderEntries(ctx, tx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	covered := map[string]float64{}
+	for _, e := range prev {
+		if e.ProductServiceID != "" {
+			covered[e.ProductServiceID] -= e.Quantity
+		}
+	}
+	if order.Subtotal <= 0 {
+		return nil, nil
+	}
+
+	var (
+		entries []*prepaid.Entry
+		paid    money.Amount
+	)
+	for _, item := range items {
+		if wallet.QuotaRemaining <= 0 || paid >= outstanding {
+			break
+		}
+		if item.ChargedQuantity <= 0 || !wallet.Covers(item.ProductServiceID) || !strings.EqualFold(item.Unit, wallet.Unit) {
+			continue
+		}
+
+		open := roundQuantity(item.ChargedQuantity - covered[item.ProductServiceID])
+		covered[item.ProductServiceID] = math.Max(-open, 0)
+		if open <= 0 {
+			continue
+		}
+
+		take := math.Min(open, wallet.QuotaRemaining)
+		value := order.TotalPrice.Share(item.ServiceAmount().Mul(take/item.ChargedQuantity, money.RoundHalfUp), order.Subtotal)
+		value = money.Min(value, outstanding-paid)
+
+		wallet.QuotaRemaining = roundQuantity(wallet.QuotaRemaining - take)
+		paid += value
+		entries = append(entries, walletEntry(wallet, order, payment, item.ProductServiceID, -take, -value, by, at))
+	}
+	return entries, nil
+}
+
+// refundWallets mengembalikan kuota/saldo yang dipakai order yang dibatalkan ke wallet asalnya.
+// Wallet yang sudah kedaluwarsa tetap menerima pengembalian, lalu dihapuskan lagi saat expiry berikutnya.
+func (s *OrderService) refundWallets(ctx context.Context, tx db.DBTX, order *Order, by string, at time.Time) error {
+	entries, err := s.prepaidRepo.FindOrderEntries(ctx, tx, order.ID)
+	if err != nil {
+		return err
+	}
+
+	type usage struct {
+		quantity float64
+		amount   money.Amount
+	}
+	used := map[string]*usage{}
+	var walletIDs []string
+	for _, e := range entries {
+		if e.Type != prepaid.EntryUsage && e.Type != prepaid.EntryRefund {
+			continue
+		}
+		u, ok := used[e.WalletID]
+		if !ok {
+			u = &usage{}
+			used[e.WalletID] = u
+			walletIDs = append(walletIDs, e.WalletID)
+		}
+		u.quantity -= e.Quantity
+		u.amount -= e.Amount
+	}
+
+	for _, id := range walletIDs {
+		u := used[id]
+		u.quantity = roundQuantity(u.quantity)
+		if u.quantity <= 0 && u.amount <= 0 {
+			continue
+		}
+		wallet, err := s.prepaidRepo.FindWalletForUpdate(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if wallet.Type == prepaid.TypeQuota {
+			wallet.QuotaRemaining = roundQuantity(wallet.QuotaRemaining + u.quantity)
+		} else {
+			wallet.BalanceRemaining += u.amount
+		}
+		if err := s.prepaidRepo.UpdateWalletRemaining(ctx, tx, wallet); err != nil {
+			return err
+		}
+
+		entry := walletEntry(wallet, order, nil, "", u.quantity, u.amount, by, at)
+		entry.Type = prepaid.EntryRefund
+		entry.Description = "Batal order " + order.InvoiceNumber
+		if err := s.prepaidRepo.CreateEntry(ctx, tx, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prepaidMethod returns the store's "prepaid" payment method, creating it for stores opened after
+// prepaid packages were introduced.
+func (s *OrderService) prepaidMethod(ctx context.Context, storeID, by string) (*paymentmethod.PaymentMethod, error) {
+	return paymentmethod.FindOrCreateBuiltin(ctx, s.paymentMethodRepo, storeID, enum.PaymentMethodPrepaid, by)
+}
+
+func walletEntry(wallet *prepaid.Wallet, order *Order, payment *OrderPayment, productServiceID string,
+	quantity float64, amount money.Amount, by string, at time.Time) *prepaid.Entry {
+	e := &prepaid.Entry{
+		ID:               uuid.New().String(),
+		WalletID:         wallet.ID,
+		CustomerID:       wallet.CustomerID,
+		StoreID:          wallet.StoreID,
+		OrderID:          order.ID,
+		ProductServiceID: productServiceID,
+		Type:             prepaid.EntryUsage,
+		Quantity:         quantity,
+		Amount:           amount,
+		Description:      "Bayar order " + order.InvoiceNumber,
+		CreatedAt:        at,
+		CreatedBy:        by,
+	}
+	if payment != nil {
+		e.PaymentID = payment.ID
+	}
+	return e
+}
+
+// roundQuantity membulatkan kuantitas ke 3 desimal (gram untuk kg) supaya sisa kuota tidak menyisakan galat float.
+func roundQuantity(q float64) float64 {
+	return math.Round(q*1000) / 1000
+}
@@ -0,0 +1,144 @@
+package order
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"sumunar-pos-core/internal/order/dto"
+	"sumunar-pos-core/internal/servicetype"
+	"sumunar-pos-core/internal/store"
+	"sumunar-pos-core/pkg/money"
+)
+
+// priceItems mengisi snapshot katalog (nama, unit, harga, bebas pajak) ke setiap item, menghitung harganya
+// dengan aturan harga product service (minimum, pembulatan kuantitas, tier), lalu diskon item.
+// Harga dari client hanya dipakai sebagai override manual yang wajib punya alasan dan dicatat.
+// Setiap item harus milik toko order dan masih aktif di katalog. Add-on dan biaya express (persen dari harga
+// layanan) ikut dihitung sebelum diskon item.
+// items dan reqItems harus sejajar (hasil ToOrderItemModels).
+func (s *OrderService) priceItems(ctx context.Context, order *Order, items []*OrderItem, reqItems []dto.OrderItemRequest, express string,
+	settings *store.StoreSettings, by string, at time.Time) ([]*OrderItemPriceOverride, error) {
+	var overrides []*OrderItemPriceOverride
+	serviceTypes := map[string]*servicetype.ServiceType{}
+	for i, item := range items {
+		ps, err := s.productServiceRepo.FindDetailByID(ctx, item.ProductServiceID)
+		if err != nil {
+			return nil, ErrProductServiceNotFound
+		}
+		if ps.StoreID != order.StoreID {
+			return nil, ErrProductServiceNotInStore
+		}
+		if !ps.Sellable() {
+			return nil, ErrProductServiceInactive
+		}
+		// harga dari daftar harga yang berlaku di toko saat order dibuat
+		price, err := s.productServiceRepo.PriceAt(ctx, ps.ID, order.StoreID, order.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if price != nil {
+			price.ApplyTo(&ps.ProductService)
+		}
+
+		item.ProductName = ps.ProductName
+		item.ServiceName = ps.ServiceTypeName
+		quote := ps.Quote(item.Quantity)
+		item.Unit = ps.Unit
+		item.UnitPrice = quote.UnitPrice
+		item.PriceOverridden = false
+		item.ServiceTypeID = ps.ServiceTypeID
+		item.TaxExempt = settings.IsTaxExempt(ps.ServiceTypeID)
+		if err := s.applyTurnaround(ctx, item, serviceTypes, express, settings); err != nil {
+			return nil, err
+		}
+
+		req := reqItems[i]
+		if req.UnitPrice != nil && *req.UnitPrice != quote.UnitPrice {
+			if req.PriceOverrideReason == "" {
+				return nil, ErrOverrideReasonRequired
+			}
+			item.UnitPrice = *req.UnitPrice
+			item.PriceOverridden = true
+			overrides = append(overrides, &OrderItemPriceOverride{
+				ID:               uuid.New().String(),
+				OrderID:          item.OrderID,
+				OrderItemID:      item.ID,
+				ProductServiceID: item.ProductServiceID,
+				CatalogPrice:     quote.UnitPrice,
+				OverridePrice:    item.UnitPrice,
+				Reason:           req.PriceOverrideReason,
+				OverriddenBy:     by,
+				OverriddenAt:     at,
+			})
+		}
+
+		// harga override tetap mengikuti aturan kuantitas dan tagihan minimum
+		quote = ps.Charge(item.Quantity, item.UnitPrice)
+		item.ChargedQuantity = quote.ChargedQuantity
+		if err := s.priceAddons(ctx, order, item, req.Addons); err != nil {
+			return nil, err
+		}
+		item.ExpressSurcharge = quote.Amount.Percent(item.ExpressRate, money.RoundHalfUp)
+		gross := quote.Amount + item.ExpressSurcharge + item.AddonAmount
+		item.DiscountAmount = money.Min(gross.Percent(item.Discount, money.RoundHalfUp)+req.DiscountFixed, gross)
+		item.TotalPrice = gross - item.DiscountAmount
+	}
+	return overrides, nil
+}
+
+// applyTotals menghitung rincian total order dari item yang sudah diberi harga: subtotal, diskon order,
+// potongan promo (sudah dihitung applyPromo), diskon tier dan tukar poin pelanggan, DPP dan PPN sesuai
+// pengaturan pajak toko, ongkos antar-jemput, lalu pembulatan kas. Semua hasilnya disimpan di order.
+func applyTotals(order *Order, items []*OrderItem, settings *store.StoreSettings) {
+	var subtotal, taxable money.Amount
+	for _, item := range items {
+		subtotal += item.TotalPrice
+		if !item.TaxExempt {
+			taxable += item.TotalPrice
+		}
+	}
+
+	order.Subtotal = subtotal
+	order.DiscountAmount = money.Min(subtotal.Percent(order.Discount, money.RoundHalfUp)+order.DiscountFixed, subtotal)
+	order.PromoDiscount = money.Min(order.PromoDiscount, subtotal-order.DiscountAmount)
+	reduction := order.DiscountAmount + order.PromoDiscount
+	order.TierDiscountAmount = money.Min(subtotal.Percent(order.TierDiscount, money.RoundHalfUp), subtotal-reduction)
+	reduction += order.TierDiscountAmount
+	// poin hanya ditukar sebanyak yang masih bisa dipotong
+	order.PointsRedeemed = settings.RedeemablePoints(order.PointsRedeemed, subtotal-reduction)
+	order.PointsDiscount = settings.PointsValue(order.PointsRedeemed)
+	reduction += order.PointsDiscount
+	net := subtotal - reduction
+
+	order.TaxRate = settings.TaxRate
+	order.TaxInclusive = settings.TaxInclusive
+	order.TaxBase, order.TaxAmount = 0, 0
+	gross := net
+	if settings.TaxRate > 0 {
+		// potongan tingkat order dibagi proporsional ke item kena pajak dan yang tidak
+		taxable -= reduction.Share(taxable, subtotal)
+		if settings.TaxInclusive {
+			order.TaxAmount = taxable.InclusivePercent(settings.TaxRate, money.RoundHalfUp)
+			order.TaxBase = taxable - order.TaxAmount
+		} else {
+			order.TaxBase = taxable
+			order.TaxAmount = taxable.Percent(settings.TaxRate, money.RoundHalfUp)
+			gross += order.TaxAmount
+		}
+	}
+
+	gross += order.DeliveryFee
+	order.TotalPrice = gross.Round(settings.CashRounding, settings.CashRoundingMode)
+	order.Rounding = order.TotalPrice - gross
+}
+
+// applyDeliveryFee mengganti ongkos antar-jemput order yang sudah dihitung, lalu membulatkan ulang totalnya.
+// Diskon, poin dan pajak tidak dihitung ulang karena ongkos tidak ikut di dalamnya.
+func applyDeliveryFee(order *Order, fee money.Amount, settings *store.StoreSettings) {
+	gross := order.TotalPrice - order.Rounding - order.DeliveryFee + fee
+	order.DeliveryFee = fee
+	order.TotalPrice = gross.Round(settings.CashRounding, settings.CashRoundingMode)
+	order.Rounding = order.TotalPrice - gross
+}
@@ -2,18 +2,38 @@ package order
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"sumunar-pos-core/pkg/db"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type OrderRepository interface {
 	Create(ctx context.Context, tx db.DBTX, order *Order, items []*OrderItem) error
 	FindByID(ctx context.Context, id string) (*Order, []*OrderItem, error)
-	FindAll(ctx context.Context, limit, offset int) ([]*Order, int, error)
+	FindAll(ctx context.Context, filter *OrderFilter) ([]*Order, int, error)
 	Update(ctx context.Context, tx db.DBTX, order *Order, items []*OrderItem) error
-	Delete(ctx context.Context, id string) error
-	CountTodayOrders(ctx context.Context, storeID string) (int, error)
+	Delete(ctx context.Context, tx db.DBTX, id string) error
+	NextInvoiceSequence(ctx context.Context, tx db.DBTX, storeID, periodKey string) (int, error)
 	FindOrderItemsByOrderIDs(ctx context.Context, orderIDs []string) (map[string][]*OrderItem, error)
+	CreatePriceOverride(ctx context.Context, tx db.DBTX, override *OrderItemPriceOverride) error
+	FindPriceOverrides(ctx context.Context, orderID string) ([]*OrderItemPriceOverride, error)
+	FindUserNames(ctx context.Context, userIDs []string) (map[string]string, error)
+	FindByCustomer(ctx context.Context, storeID, customerID string, from, to time.Time) ([]*Order, error)
+	UpdateStatus(ctx context.Context, tx db.DBTX, order *Order, fromStatus string) error
+	CreateStatusHistory(ctx context.Context, tx db.DBTX, history *OrderStatusHistory) error
+	FindStatusHistory(ctx context.Context, orderID string) ([]*OrderStatusHistory, error)
+	FindByIDForUpdate(ctx context.Context, tx db.DBTX, id string) (*Order, error)
+	FindItems(ctx context.Context, tx db.DBTX, orderID string) ([]*OrderItem, error)
+	UpdatePoints(ctx context.Context, tx db.DBTX, order *Order) error
+	UpdatePaymentTotals(ctx context.Context, tx db.DBTX, order *Order) error
+	UpdateDeliveryFee(ctx context.Context, tx db.DBTX, order *Order) error
+	CreatePayment(ctx context.Context, tx db.DBTX, payment *OrderPayment) error
+	FindPayments(ctx context.Context, orderID string) ([]*OrderPayment, error)
 }
 
 type orderRepo struct {
@@ -26,8 +46,10 @@ func NewOrderRepository(db db.DBTX) OrderRepository {
 
 func (r *orderRepo) Create(ctx context.Context, tx db.DBTX, order *Order, items []*OrderItem) error {
 	query := `
-		INSERT INTO orders (id, store_id, invoice_number, customer_id, status, discount, total_price, paid_amount, change, pickup_date, created_at, created_by, updated_at, updated_by)
-		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$11,$12)
+		INSERT INTO orders (id, store_id, invoice_number, customer_id, status, subtotal, discount, discount_fixed, discount_amount, promo_id, promo_code, promo_discount, discount_approved_by,
+			tier_name, tier_discount, tier_discount_amount, points_redeemed, points_discount, points_earned, points_awarded,
+			tax_rate, tax_inclusive, tax_base, tax_amount, rounding_adjustment, total_price, paid_amount, change, pickup_date, created_at, created_by, updated_at, updated_by)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,NULLIF($10, '')::uuid,$11,$12,NULLIF($13, '')::uuid,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24,$25,$26,$27,$28,$29,$30,$31,$30,$31)
 	`
 	_, err := tx.Exec(ctx, query,
 		order.ID,
@@ -35,7 +57,26 @@ func (r *orderRepo) Create(ctx context.Context, tx db.DBTX, order *Order, items
 		order.InvoiceNumber,
 		order.CustomerID,
 		order.Status,
+		order.Subtotal,
 		order.Discount,
+		order.DiscountFixed,
+		order.DiscountAmount,
+		order.PromoID,
+		order.PromoCode,
+		order.PromoDiscount,
+		order.DiscountApprovedBy,
+		order.TierName,
+		order.TierDiscount,
+		order.TierDiscountAmount,
+		order.PointsRedeemed,
+		order.PointsDiscount,
+		order.PointsEarned,
+		order.PointsAwarded,
+		order.TaxRate,
+		order.TaxInclusive,
+		order.TaxBase,
+		order.TaxAmount,
+		order.Rounding,
 		order.TotalPrice,
 		order.PaidAmount,
 		order.Change,
@@ -43,25 +84,16 @@ func (r *orderRepo) Create(ctx context.Context, tx db.DBTX, order *Order, items
 		order.CreatedAt,
 		order.CreatedBy,
 	)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.ConstraintName == "uq_orders_store_invoice_number" {
+		return ErrInvoiceNumberTaken
+	}
 	if err != nil {
 		return err
 	}
 
-	for _, item := range items {
-		_, err := tx.Exec(ctx, `
-			INSERT INTO order_items (id, order_id, product_service_id, quantity, total_price, notes)
-			VALUES ($1, $2, $3, $4, $5, $6)
-		`,
-			item.ID,
-			item.OrderID,
-			item.ProductServiceID,
-			item.Quantity,
-			item.TotalPrice,
-			item.Notes,
-		)
-		if err != nil {
-			return err
-		}
+	if err := insertItems(ctx, tx, items); err != nil {
+		return err
 	}
 
 	return nil
@@ -69,66 +101,64 @@ func (r *orderRepo) Create(ctx context.Context, tx db.DBTX, order *Order, items
 
 func (r *orderRepo) FindByID(ctx context.Context, id string) (*Order, []*OrderItem, error) {
 	query := `
-		SELECT id, store_id, invoice_number, customer_id, status, discount, total_price, paid_amount, change, pickup_date, created_at, created_by, updated_at, updated_by
-		FROM orders
-		WHERE id = $1
+		SELECT ` + orderColumns + `
+		FROM orders o
+		WHERE o.id = $1
 	`
-	row := r.db.QueryRow(ctx, query, id)
+	o, err := scanOrder(r.db.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
 
-	var o Order
-	err := row.Scan(
-		&o.ID,
-		&o.StoreID,
-		&o.InvoiceNumber,
-		&o.CustomerID,
-		&o.Status,
-		&o.Discount,
-		&o.TotalPrice,
-		&o.PaidAmount,
-		&o.Change,
-		&o.PickupDate,
-		&o.CreatedAt,
-		&o.CreatedBy,
-		&o.UpdatedAt,
-		&o.UpdatedBy,
-	)
+	items, err := r.FindItems(ctx, r.db, id)
 	if err != nil {
 		return nil, nil, err
 	}
+	return o, items, nil
+}
 
+// FindItems reads the items of an order with their add-ons. Pass the tx that locked the order to see
+// the same items the rest of the tx works on.
+func (r *orderRepo) FindItems(ctx context.Context, tx db.DBTX, orderID string) ([]*OrderItem, error) {
 	items := []*OrderItem{}
-	rows, err := r.db.Query(ctx, `SELECT id, order_id, product_service_id, quantity, total_price, notes FROM order_items WHERE order_id = $1`, id)
+	rows, err := tx.Query(ctx, `SELECT `+itemColumns+` FROM order_items WHERE order_id = $1`, orderID)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var i OrderItem
-		if err := rows.Scan(
-			&i.ID,
-			&i.OrderID,
-			&i.ProductServiceID,
-			&i.Quantity,
-			&i.TotalPrice,
-			&i.Notes,
-		); err != nil {
-			return nil, nil, err
+		i, err := scanItem(rows)
+		if err != nil {
+			return nil, err
 		}
-		items = append(items, &i)
+		items = append(items, i)
 	}
-
-	return &o, items, nil
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+	if err := r.attachAddons(ctx, tx, items); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-func (r *orderRepo) FindAll(ctx context.Context, limit, offset int) ([]*Order, int, error) {
+func (r *orderRepo) FindAll(ctx context.Context, filter *OrderFilter) ([]*Order, int, error) {
+	// join customer hanya dibutuhkan untuk pencarian no. HP
+	from := ` FROM orders o LEFT JOIN customers c ON c.id = o.customer_id`
+	where, args := filter.where()
+
 	query := `
-		SELECT id, store_id, invoice_number, customer_id, status, discount, total_price, paid_amount, change, pickup_date, created_at, created_by, updated_at, updated_by
-		FROM orders
-		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2
-	`
-	rows, err := r.db.Query(ctx, query, limit, offset)
+		SELECT ` + orderColumns +
+		from + where + `
+		ORDER BY ` + filter.orderBy() + fmt.Sprintf(`
+		LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+
+	rows, err := r.db.Query(ctx, query, append(args, filter.Limit, filter.Offset)...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -136,30 +166,18 @@ func (r *orderRepo) FindAll(ctx context.Context, limit, offset int) ([]*Order, i
 
 	var orders []*Order
 	for rows.Next() {
-		var o Order
-		if err := rows.Scan(
-			&o.ID,
-			&o.StoreID,
-			&o.InvoiceNumber,
-			&o.CustomerID,
-			&o.Status,
-			&o.Discount,
-			&o.TotalPrice,
-			&o.PaidAmount,
-			&o.Change,
-			&o.PickupDate,
-			&o.CreatedAt,
-			&o.CreatedBy,
-			&o.UpdatedAt,
-			&o.UpdatedBy,
-		); err != nil {
+		o, err := scanOrder(rows)
+		if err != nil {
 			return nil, 0, err
 		}
-		orders = append(orders, &o)
+		orders = append(orders, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
 	}
 
 	var total int
-	err = r.db.QueryRow(ctx, `SELECT COUNT(*) FROM orders`).Scan(&total)
+	err = r.db.QueryRow(ctx, `SELECT COUNT(*)`+from+where, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -167,30 +185,60 @@ func (r *orderRepo) FindAll(ctx context.Context, limit, offset int) ([]*Order, i
 	return orders, total, nil
 }
 
+// Update rewrites the priced content of the order and its items. Status, payments and awarded points
+// have their own writers (UpdateStatus, UpdatePaymentTotals, UpdatePoints) and are not touched here.
 func (r *orderRepo) Update(ctx context.Context, tx db.DBTX, order *Order, items []*OrderItem) error {
 	_, err := tx.Exec(ctx, `
 		UPDATE orders SET
 			store_id = $1,
 			invoice_number = $2,
 			customer_id = $3,
-			status = $4,
+			subtotal = $4,
 			discount = $5,
-			total_price = $6,
-			paid_amount = $7,
-			change = $8,
-			pickup_date = $9,
-			updated_at = $10,
-			updated_by = $11
-		WHERE id = $12
+			discount_fixed = $6,
+			discount_amount = $7,
+			promo_id = NULLIF($8, '')::uuid,
+			promo_code = $9,
+			promo_discount = $10,
+			discount_approved_by = NULLIF($11, '')::uuid,
+			tier_name = $12,
+			tier_discount = $13,
+			tier_discount_amount = $14,
+			points_redeemed = $15,
+			points_discount = $16,
+			tax_rate = $17,
+			tax_inclusive = $18,
+			tax_base = $19,
+			tax_amount = $20,
+			rounding_adjustment = $21,
+			total_price = $22,
+			pickup_date = $23,
+			updated_at = $24,
+			updated_by = $25
+		WHERE id = $26
 	`,
 		order.StoreID,
 		order.InvoiceNumber,
 		order.CustomerID,
-		order.Status,
+		order.Subtotal,
 		order.Discount,
+		order.DiscountFixed,
+		order.DiscountAmount,
+		order.PromoID,
+		order.PromoCode,
+		order.PromoDiscount,
+		order.DiscountApprovedBy,
+		order.TierName,
+		order.TierDiscount,
+		order.TierDiscountAmount,
+		order.PointsRedeemed,
+		order.PointsDiscount,
+		order.TaxRate,
+		order.TaxInclusive,
+		order.TaxBase,
+		order.TaxAmount,
+		order.Rounding,
 		order.TotalPrice,
-		order.PaidAmount,
-		order.Change,
 		order.PickupDate,
 		order.UpdatedAt,
 		order.UpdatedBy,
@@ -207,54 +255,211 @@ func (r *orderRepo) Update(ctx context.Context, tx db.DBTX, order *Order, items
 	}
 
 	// Insert updated items
+	return insertItems(ctx, tx, items)
+}
+
+// insertItems stores the items with their price and catalog snapshot.
+func insertItems(ctx context.Context, tx db.DBTX, items []*OrderItem) error {
 	for _, item := range items {
 		_, err := tx.Exec(ctx, `
-			INSERT INTO order_items (id, order_id, product_service_id, quantity, total_price, notes)
-			VALUES ($1, $2, $3, $4, $5, $6)
+			INSERT INTO order_items (id, order_id, product_service_id, service_type_id, product_name, service_name, unit, unit_price, price_overridden, tax_exempt,
+				quantity, charged_quantity, addon_amount, express_tier, express_rate, express_surcharge, turnaround_hours, discount, discount_amount, total_price, notes)
+			VALUES ($1, $2, $3, NULLIF($4, '')::uuid, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
 		`,
 			item.ID,
 			item.OrderID,
 			item.ProductServiceID,
+			item.ServiceTypeID,
+			item.ProductName,
+			item.ServiceName,
+			item.Unit,
+			item.UnitPrice,
+			item.PriceOverridden,
+			item.TaxExempt,
 			item.Quantity,
+			item.ChargedQuantity,
+			item.AddonAmount,
+			item.ExpressTier,
+			item.ExpressRate,
+			item.ExpressSurcharge,
+			item.TurnaroundHours,
+			item.Discount,
+			item.DiscountAmount,
 			item.TotalPrice,
 			item.Notes,
 		)
 		if err != nil {
 			return err
 		}
+		for _, a := range item.Addons {
+			_, err := tx.Exec(ctx, `
+				INSERT INTO order_item_addons (id, order_id, order_item_id, addon_id, name, price_type, unit_price, quantity, amount)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			`, a.ID, a.OrderID, a.OrderItemID, a.AddonID, a.Name, a.PriceType, a.UnitPrice, a.Quantity, a.Amount)
+			if err != nil {
+				return err
+			}
+		}
 	}
-
 	return nil
 }
 
-func (r *orderRepo) Delete(ctx context.Context, id string) error {
-	// delete order items first
-	_, err := r.db.Exec(ctx, `DELETE FROM order_items WHERE order_id = $1`, id)
+// attachAddons loads the add-ons of the items in one query.
+func (r *orderRepo) attachAddons(ctx context.Context, q db.DBTX, items []*OrderItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	byID := make(map[string]*OrderItem, len(items))
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		item.Addons = []*OrderItemAddon{}
+		byID[item.ID] = item
+		ids = append(ids, item.ID)
+	}
+
+	rows, err := q.Query(ctx, `
+		SELECT id, order_id, order_item_id, COALESCE(addon_id::text, ''), name, price_type, unit_price, quantity, amount
+		FROM order_item_addons
+		WHERE order_item_id = ANY($1)
+		ORDER BY name
+	`, ids)
 	if err != nil {
 		return err
 	}
-	// delete order
-	_, err = r.db.Exec(ctx, `DELETE FROM orders WHERE id = $1`, id)
-	return err
+	defer rows.Close()
+
+	for rows.Next() {
+		var a OrderItemAddon
+		if err := rows.Scan(&a.ID, &a.OrderID, &a.OrderItemID, &a.AddonID, &a.Name, &a.PriceType, &a.UnitPrice, &a.Quantity, &a.Amount); err != nil {
+			return err
+		}
+		if item := byID[a.OrderItemID]; item != nil {
+			item.Addons = append(item.Addons, &a)
+		}
+	}
+	return rows.Err()
+}
+
+// orderColumns is selected from "orders o"; the alias keeps it unambiguous when other tables are joined.
+const orderColumns = `o.id, o.store_id, o.invoice_number, o.customer_id, o.status, o.subtotal, o.discount, o.discount_fixed, o.discount_amount,
+	COALESCE(o.promo_id::text, ''), o.promo_code, o.promo_discount, COALESCE(o.discount_approved_by::text, ''),
+	o.tier_name, o.tier_discount, o.tier_discount_amount, o.points_redeemed, o.points_discount, o.points_earned, o.points_awarded, o.tax_rate, o.tax_inclusive, o.tax_base, o.tax_amount, o.delivery_fee, o.rounding_adjustment, o.total_price, o.paid_amount, o.change,
+	o.pickup_date, o.created_at, o.created_by, o.updated_at, o.updated_by`
+
+func scanOrder(row pgx.Row) (*Order, error) {
+	var o Order
+	err := row.Scan(
+		&o.ID,
+		&o.StoreID,
+		&o.InvoiceNumber,
+		&o.CustomerID,
+		&o.Status,
+		&o.Subtotal,
+		&o.Discount,
+		&o.DiscountFixed,
+		&o.DiscountAmount,
+		&o.PromoID,
+		&o.PromoCode,
+		&o.PromoDiscount,
+		&o.DiscountApprovedBy,
+		&o.TierName,
+		&o.TierDiscount,
+		&o.TierDiscountAmount,
+		&o.PointsRedeemed,
+		&o.PointsDiscount,
+		&o.PointsEarned,
+		&o.PointsAwarded,
+		&o.TaxRate,
+		&o.TaxInclusive,
+		&o.TaxBase,
+		&o.TaxAmount,
+		&o.DeliveryFee,
+		&o.Rounding,
+		&o.TotalPrice,
+		&o.PaidAmount,
+		&o.Change,
+		&o.PickupDate,
+		&o.CreatedAt,
+		&o.CreatedBy,
+		&o.UpdatedAt,
+		&o.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+const itemColumns = `id, order_id, product_service_id, COALESCE(service_type_id::text, ''), product_name, service_name, unit, unit_price, price_overridden, tax_exempt,
+	quantity, charged_quantity, addon_amount, express_tier, express_rate, express_surcharge, turnaround_hours, discount, discount_amount, total_price, notes`
+
+func scanItem(row pgx.Row) (*OrderItem, error) {
+	var item OrderItem
+	err := row.Scan(
+		&item.ID,
+		&item.OrderID,
+		&item.ProductServiceID,
+		&item.ServiceTypeID,
+		&item.ProductName,
+		&item.ServiceName,
+		&item.Unit,
+		&item.UnitPrice,
+		&item.PriceOverridden,
+		&item.TaxExempt,
+		&item.Quantity,
+		&item.ChargedQuantity,
+		&item.AddonAmount,
+		&item.ExpressTier,
+		&item.ExpressRate,
+		&item.ExpressSurcharge,
+		&item.TurnaroundHours,
+		&item.Discount,
+		&item.DiscountAmount,
+		&item.TotalPrice,
+		&item.Notes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// Delete removes the order with its items, history, payments and overrides. Ledger entries that point
+// to it keep their amounts with the order cleared, so run it in the tx that reversed them.
+func (r *orderRepo) Delete(ctx context.Context, tx db.DBTX, id string) error {
+	for _, query := range []string{
+		`DELETE FROM order_items WHERE order_id = $1`,
+		`DELETE FROM order_status_history WHERE order_id = $1`,
+		`DELETE FROM order_payments WHERE order_id = $1`,
+		`DELETE FROM order_item_price_overrides WHERE order_id = $1`,
+		`DELETE FROM orders WHERE id = $1`,
+	} {
+		if _, err := tx.Exec(ctx, query, id); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (r *orderRepo) CountTodayOrders(ctx context.Context, storeID string) (int, error) {
+// NextInvoiceSequence increments and returns the store's invoice counter for periodKey.
+// The row stays locked until tx ends, so concurrent orders of one store get consecutive numbers.
+func (r *orderRepo) NextInvoiceSequence(ctx context.Context, tx db.DBTX, storeID, periodKey string) (int, error) {
 	query := `
-		SELECT COUNT(*) 
-		FROM orders 
-		WHERE store_id = $1 AND DATE(created_at) = CURRENT_DATE
+		INSERT INTO invoice_sequences (store_id, period_key, last_value)
+		VALUES ($1, $2, 1)
+		ON CONFLICT (store_id, period_key) DO UPDATE SET last_value = invoice_sequences.last_value + 1
+		RETURNING last_value
 	`
-	var count int
-	err := r.db.QueryRow(ctx, query, storeID).Scan(&count)
-	if err != nil {
+	var seq int
+	if err := tx.QueryRow(ctx, query, storeID, periodKey).Scan(&seq); err != nil {
 		return 0, err
 	}
-	return count, nil
+	return seq, nil
 }
 
 func (r *orderRepo) FindOrderItemsByOrderIDs(ctx context.Context, orderIDs []string) (map[string][]*OrderItem, error) {
 	// Buat query IN
-	query := `SELECT id, order_id, product_service_id, quantity, total_price, notes FROM order_items WHERE order_id = ANY($1)`
+	query := `SELECT ` + itemColumns + ` FROM order_items WHERE order_id = ANY($1)`
 	rows, err := r.db.Query(ctx, query, orderIDs)
 	if err != nil {
 		return nil, err
@@ -262,20 +467,303 @@ func (r *orderRepo) FindOrderItemsByOrderIDs(ctx context.Context, orderIDs []str
 	defer rows.Close()
 
 	result := make(map[string][]*OrderItem)
+	var items []*OrderItem
 	for rows.Next() {
-		var item OrderItem
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		result[item.OrderID] = append(result[item.OrderID], item)
+		items = append(items, item)
+	}
+	rows.Close()
+	if err := r.attachAddons(ctx, r.db, items); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *orderRepo) CreatePriceOverride(ctx context.Context, tx db.DBTX, o *OrderItemPriceOverride) error {
+	query := `
+		INSERT INTO order_item_price_overrides (id, order_id, order_item_id, product_service_id, catalog_price, override_price, reason, overridden_by, overridden_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := tx.Exec(ctx, query,
+		o.ID,
+		o.OrderID,
+		o.OrderItemID,
+		o.ProductServiceID,
+		o.CatalogPrice,
+		o.OverridePrice,
+		o.Reason,
+		o.OverriddenBy,
+		o.OverriddenAt,
+	)
+	return err
+}
+
+func (r *orderRepo) FindPriceOverrides(ctx context.Context, orderID string) ([]*OrderItemPriceOverride, error) {
+	query := `
+		SELECT id, order_id, order_item_id, product_service_id, catalog_price, override_price, reason, overridden_by, overridden_at
+		FROM order_item_price_overrides
+		WHERE order_id = $1
+		ORDER BY overridden_at
+	`
+	rows, err := r.db.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var overrides []*OrderItemPriceOverride
+	for rows.Next() {
+		var o OrderItemPriceOverride
 		if err := rows.Scan(
-			&item.ID,
-			&item.OrderID,
-			&item.ProductServiceID,
-			&item.Quantity,
-			&item.TotalPrice,
-			&item.Notes,
+			&o.ID,
+			&o.OrderID,
+			&o.OrderItemID,
+			&o.ProductServiceID,
+			&o.CatalogPrice,
+			&o.OverridePrice,
+			&o.Reason,
+			&o.OverriddenBy,
+			&o.OverriddenAt,
 		); err != nil {
 			return nil, err
 		}
-		result[item.OrderID] = append(result[item.OrderID], &item)
+		overrides = append(overrides, &o)
 	}
+	return overrides, rows.Err()
+}
 
-	return result, nil
+// FindUserNames maps user IDs to full names, for showing who created or changed an order.
+func (r *orderRepo) FindUserNames(ctx context.Context, userIDs []string) (map[string]string, error) {
+	// kolom user yang kosong (misal updated_by order baru) dilewati, "" bukan uuid
+	ids := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	rows, err := r.db.Query(ctx, `SELECT id::text, fullname FROM users WHERE id = ANY($1::uuid[])`, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := make(map[string]string)
+	for rows.Next() {
+		var id, name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		names[id] = name
+	}
+	return names, rows.Err()
+}
+
+// FindByCustomer returns the customer's orders at a store created in [from, to), oldest first. Cancelled orders are excluded.
+func (r *orderRepo) FindByCustomer(ctx context.Context, storeID, customerID string, from, to time.Time) ([]*Order, error) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders o
+		WHERE o.store_id = $1 AND o.customer_id = $2 AND o.created_at >= $3 AND o.created_at < $4 AND o.status <> 'cancelled'
+		ORDER BY o.created_at
+	`
+	rows, err := r.db.Query(ctx, query, storeID, customerID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []*Order
+	for rows.Next() {
+		o, err := scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, o)
+	}
+
+	return orders, rows.Err()
+}
+
+// UpdateStatus moves the order to order.Status only if it is still in fromStatus,
+// so two concurrent transitions cannot both succeed.
+func (r *orderRepo) UpdateStatus(ctx context.Context, tx db.DBTX, order *Order, fromStatus string) error {
+	tag, err := tx.Exec(ctx, `
+		UPDATE orders SET status = $1, updated_at = $2, updated_by = $3
+		WHERE id = $4 AND status = $5
+	`,
+		order.Status,
+		order.UpdatedAt,
+		order.UpdatedBy,
+		order.ID,
+		fromStatus,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrStatusConflict
+	}
+	return nil
+}
+
+func (r *orderRepo) CreateStatusHistory(ctx context.Context, tx db.DBTX, h *OrderStatusHistory) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO order_status_history (id, order_id, from_status, to_status, reason, changed_by, changed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`,
+		h.ID,
+		h.OrderID,
+		h.FromStatus,
+		h.ToStatus,
+		h.Reason,
+		h.ChangedBy,
+		h.ChangedAt,
+	)
+	return err
+}
+
+func (r *orderRepo) FindStatusHistory(ctx context.Context, orderID string) ([]*OrderStatusHistory, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, order_id, from_status, to_status, reason, changed_by, changed_at
+		FROM order_status_history
+		WHERE order_id = $1
+		ORDER BY changed_at ASC
+	`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []*OrderStatusHistory{}
+	for rows.Next() {
+		var h OrderStatusHistory
+		if err := rows.Scan(
+			&h.ID,
+			&h.OrderID,
+			&h.FromStatus,
+			&h.ToStatus,
+			&h.Reason,
+			&h.ChangedBy,
+			&h.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		history = append(history, &h)
+	}
+
+	return history, rows.Err()
+}
+
+// FindByIDForUpdate reads the order row and locks it until tx ends.
+func (r *orderRepo) FindByIDForUpdate(ctx context.Context, tx db.DBTX, id string) (*Order, error) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders o
+		WHERE o.id = $1
+		FOR UPDATE
+	`
+	o, err := scanOrder(tx.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func (r *orderRepo) UpdatePaymentTotals(ctx context.Context, tx db.DBTX, order *Order) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE orders SET paid_amount = $1, change = $2, updated_at = $3, updated_by = $4
+		WHERE id = $5
+	`,
+		order.PaidAmount,
+		order.Change,
+		order.UpdatedAt,
+		order.UpdatedBy,
+		order.ID,
+	)
+	return err
+}
+
+func (r *orderRepo) CreatePayment(ctx context.Context, tx db.DBTX, p *OrderPayment) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO order_payments (id, order_id, amount, change, payment_method_id, method, reference, wallet_id, received_by, received_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, '')::uuid, $9, $10)
+	`,
+		p.ID,
+		p.OrderID,
+		p.Amount,
+		p.Change,
+		p.PaymentMethodID,
+		p.Method,
+		p.Reference,
+		p.WalletID,
+		p.ReceivedBy,
+		p.ReceivedAt,
+	)
+	return err
+}
+
+func (r *orderRepo) FindPayments(ctx context.Context, orderID string) ([]*OrderPayment, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, order_id, amount, change, payment_method_id, method, reference, COALESCE(wallet_id::text, ''), received_by, received_at
+		FROM order_payments
+		WHERE order_id = $1
+		ORDER BY received_at ASC
+	`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payments := []*OrderPayment{}
+	for rows.Next() {
+		var p OrderPayment
+		if err := rows.Scan(
+			&p.ID,
+			&p.OrderID,
+			&p.Amount,
+			&p.Change,
+			&p.PaymentMethodID,
+			&p.Method,
+			&p.Reference,
+			&p.WalletID,
+			&p.ReceivedBy,
+			&p.ReceivedAt,
+		); err != nil {
+			return nil, err
+		}
+		payments = append(payments, &p)
+	}
+
+	return payments, rows.Err()
+}
+
+// UpdatePoints saves the loyalty points earned on the order once it is paid (or taken back on cancel).
+func (r *orderRepo) UpdatePoints(ctx context.Context, tx db.DBTX, order *Order) error {
+	_, err := tx.Exec(ctx, `UPDATE orders SET points_earned = $1, points_awarded = $2 WHERE id = $3`,
+		order.PointsEarned, order.PointsAwarded, order.ID)
+	return err
+}
+
+// UpdateDeliveryFee writes the delivery fee and the total it changes.
+func (r *orderRepo) UpdateDeliveryFee(ctx context.Context, tx db.DBTX, order *Order) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE orders SET delivery_fee = $1, rounding_adjustment = $2, total_price = $3, updated_at = $4, updated_by = $5
+		WHERE id = $6
+	`,
+		order.DeliveryFee,
+		order.Rounding,
+		order.TotalPrice,
+		order.UpdatedAt,
+		order.UpdatedBy,
+		order.ID,
+	)
+	return err
 }
@@ -2,94 +2,253 @@ package order
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"sumunar-pos-core/internal/addon"
 	"sumunar-pos-core/internal/customer"
+	"sumunar-pos-core/internal/enum"
 	"sumunar-pos-core/internal/order/dto"
+	"sumunar-pos-core/internal/paymentmethod"
+	"sumunar-pos-core/internal/prepaid"
 	"sumunar-pos-core/internal/productservice"
+	"sumunar-pos-core/internal/promo"
+	"sumunar-pos-core/internal/servicetype"
+	"sumunar-pos-core/internal/store"
+	"sumunar-pos-core/internal/user"
 
 	"sumunar-pos-core/pkg/db"
+	"sumunar-pos-core/pkg/invoiceno"
+	"sumunar-pos-core/pkg/qris"
+
+	"github.com/google/uuid"
 )
 
 type OrderService struct {
 	repo               OrderRepository
 	productServiceRepo productservice.ProductServiceRepository
 	customerRepo       customer.CustomerRepository
+	paymentMethodRepo  paymentmethod.PaymentMethodRepository
+	storeRepo          store.StoreRepository
+	promoRepo          promo.PromoRepository
+	userRepo           user.UserRepository
+	prepaidRepo        prepaid.PrepaidRepository
+	addonRepo          addon.AddonRepository
+	serviceTypeRepo    servicetype.ServiceRepository
+	notifier           Notifier
 	db                 db.TxBeginner
 }
 
-func NewService(repo OrderRepository, productServiceRepo productservice.ProductServiceRepository, customerRepo customer.CustomerRepository, db db.TxBeginner) *OrderService {
-	return &OrderService{repo, productServiceRepo, customerRepo, db}
+func NewService(repo OrderRepository, productServiceRepo productservice.ProductServiceRepository, customerRepo customer.CustomerRepository,
+	paymentMethodRepo paymentmethod.PaymentMethodRepository, storeRepo store.StoreRepository, promoRepo promo.PromoRepository,
+	userRepo user.UserRepository, prepaidRepo prepaid.PrepaidRepository, addonRepo addon.AddonRepository,
+	serviceTypeRepo servicetype.ServiceRepository, notifier Notifier, db db.TxBeginner) *OrderService {
+	return &OrderService{repo, productServiceRepo, customerRepo, paymentMethodRepo, storeRepo, promoRepo, userRepo, prepaidRepo, addonRepo, serviceTypeRepo, notifier, db}
 }
 
-func (s *OrderService) CreateOrder(ctx context.Context, dto dto.OrderRequest, createdBy string) (*dto.OrderResponse, error) {
+// CreateOrder prices the order from the catalog and applies discounts; role is the cashier's role,
+// used for the per-role discount limit.
+func (s *OrderService) CreateOrder(ctx context.Context, dto dto.OrderRequest, createdBy, role string) (*dto.OrderResponse, error) {
 	order, items, err := ToOrderModel(dto, createdBy)
 	if err != nil {
 		return nil, err
 	}
 
-	cust, err := s.customerRepo.FindByID(ctx, order.CustomerID)
+	// Simpan ke DB dalam transaksi; pelanggan dikunci sampai commit supaya saldo poin yang ditukar tidak berubah
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) // auto rollback jika ada error
+
+	// saldo poin harus bersih dari poin kedaluwarsa sebelum ditukar
+	if err := s.customerRepo.ExpirePoints(ctx, tx, order.CustomerID, order.CreatedAt); err != nil {
+		return nil, err
+	}
+	cust, err := s.customerRepo.FindByIDForUpdate(ctx, tx, order.CustomerID)
 	if err != nil {
 		return nil, fmt.Errorf("customer not found: %w", err)
 	}
 
-	// Generate invoice number
-	order.InvoiceNumber, err = s.GenerateInvoiceNumber(ctx, order.StoreID)
+	// Hitung total harga dari katalog (snapshot harga disimpan di item)
+	settings, err := s.storeRepo.FindSettings(ctx, order.StoreID)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := s.priceItems(ctx, order, items, dto.Items, dto.Express, settings, createdBy, order.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	order.PickupDate, err = resolvePickup(dto.PickupDate, EarliestPickup(order.CreatedAt, items, settings), settings)
 	if err != nil {
 		return nil, err
 	}
 
-	// Hitung total harga berdasarkan product_service_id dan quantity
-	var total float64
-	for _, item := range items {
-		ps, err := s.productServiceRepo.FindByID(ctx, item.ProductServiceID)
+	// Promo, diskon, loyalti, pajak dan pembulatan kas
+	if err := s.applyPromo(ctx, order, items, dto.PromoCode, order.CreatedAt); err != nil {
+		return nil, err
+	}
+	if err := applyLoyalty(order, cust, settings, dto.RedeemPoints); err != nil {
+		return nil, err
+	}
+	applyTotals(order, items, settings)
+	if err := s.checkDiscountLimit(ctx, order, items, overrides, settings, role, dto.DiscountApproval); err != nil {
+		return nil, err
+	}
+
+	// Uang muka (jika ada) dicatat sebagai pembayaran pertama
+	var payments []*OrderPayment
+	if dto.PaidAmount > 0 {
+		method, err := s.resolvePaymentMethod(ctx, order.StoreID, dto.PaymentMethodID, createdBy)
 		if err != nil {
-			return nil, fmt.Errorf("product service not found: %w", err)
+			return nil, err
 		}
-		item.TotalPrice = ps.Price * item.Quantity
-		total += item.TotalPrice
+		payment := &OrderPayment{
+			ID:              uuid.New().String(),
+			OrderID:         order.ID,
+			Amount:          dto.PaidAmount,
+			PaymentMethodID: method.ID,
+			Method:          method.Code,
+			Reference:       dto.PaymentRef,
+			ReceivedBy:      createdBy,
+			ReceivedAt:      order.CreatedAt,
+		}
+		applied, change := splitPayment(payment.Amount, order.TotalPrice)
+		payment.Change = change
+		order.PaidAmount = applied
+		order.Change = change
+		payments = append(payments, payment)
 	}
 
-	// Hitung diskon
-	discountAmount := total * (order.Discount / 100)
-	order.TotalPrice = total - discountAmount
-
-	// Hitung kembalian
-	order.Change = order.PaidAmount - order.TotalPrice
-
-	// Simpan ke DB dalam transaksi
-	tx, err := s.db.Begin(ctx)
+	// Generate invoice number di dalam transaksi agar nomor tidak bentrok
+	order.InvoiceNumber, err = s.GenerateInvoiceNumber(ctx, tx, order.StoreID, order.CreatedAt)
 	if err != nil {
 		return nil, err
 	}
-	defer tx.Rollback(ctx) // auto rollback jika ada error
 
 	if err := s.repo.Create(ctx, tx, order, items); err != nil {
 		return nil, err
 	}
 
+	if err := s.swapPromo(ctx, tx, "", order.PromoID); err != nil {
+		return nil, err
+	}
+	if err := s.redeemPoints(ctx, tx, order, 0, settings, createdBy, order.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	for _, override := range overrides {
+		if err := s.repo.CreatePriceOverride(ctx, tx, override); err != nil {
+			return nil, err
+		}
+	}
+
+	history := ToStatusHistoryModel(order.ID, "", order.Status, "", createdBy, order.CreatedAt)
+	if err := s.repo.CreateStatusHistory(ctx, tx, history); err != nil {
+		return nil, err
+	}
+
+	for _, payment := range payments {
+		if err := s.repo.CreatePayment(ctx, tx, payment); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.awardPoints(ctx, tx, order, settings, createdBy, order.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	if err := s.notify(ctx, tx, order, cust); err != nil {
+		return nil, err
+	}
+
 	err = tx.Commit(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	res := ToOrderResponse(order, cust, items)
+	res.StatusHistory = ToStatusHistoryResponses([]*OrderStatusHistory{history})
+	res.Payments = ToOrderPaymentResponses(payments)
 	return res, nil
 }
 
-func (s *OrderService) GenerateInvoiceNumber(ctx context.Context, storeID string) (string, error) {
-	today := time.Now().Format("060102") // YYMMDD
-	count, err := s.repo.CountTodayOrders(ctx, storeID)
+// GenerateInvoiceNumber takes the next number from the store's invoice sequence and renders it
+// with the store's invoice format. It must run inside the transaction that creates the order.
+func (s *OrderService) GenerateInvoiceNumber(ctx context.Context, tx db.DBTX, storeID string, at time.Time) (string, error) {
+	st, err := s.storeRepo.FindByID(ctx, storeID)
+	if err != nil {
+		return "", fmt.Errorf("store not found: %w", err)
+	}
+
+	settings, err := s.storeRepo.FindSettings(ctx, storeID)
+	if err != nil {
+		return "", err
+	}
+
+	// tanggal dan periode reset nomor mengikuti hari bisnis toko (zona waktu dan jam tutup buku)
+	at = settings.BusinessDayOf(at).Date
+	seq, err := s.repo.NextInvoiceSequence(ctx, tx, storeID, invoiceno.PeriodKey(settings.InvoiceReset, at))
 	if err != nil {
 		return "", err
 	}
+
 	// e.g. INV-240730-001
-	return fmt.Sprintf("INV-%s-%03d", today, count+1), nil
+	return invoiceno.Format(settings.InvoiceFormat, st.Code, at, seq), nil
+}
+
+// FindByID returns the order with named items, customer, user names, status history and payments.
+func (s *OrderService) FindByID(ctx context.Context, id string) (*dto.OrderResponse, error) {
+	// ID yang bukan UUID tidak mungkin ada, jangan sampai jadi error query (500)
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrOrderNotFound
+	}
+
+	order, items, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	cust, _ := s.customerRepo.FindByID(ctx, order.CustomerID)
+
+	history, err := s.repo.FindStatusHistory(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	payments, err := s.repo.FindPayments(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := []string{order.CreatedBy, order.UpdatedBy}
+	for _, h := range history {
+		userIDs = append(userIDs, h.ChangedBy)
+	}
+	for _, p := range payments {
+		userIDs = append(userIDs, p.ReceivedBy)
+	}
+	names, err := s.repo.FindUserNames(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return ToOrderDetailResponse(order, cust, items, history, payments, names), nil
 }
 
-func (s *OrderService) FindAll(ctx context.Context, limit, offset int) ([]*dto.OrderResponse, int, error) {
-	orders, total, err := s.repo.FindAll(ctx, limit, offset)
+func (s *OrderService) FindAll(ctx context.Context, req *dto.OrderListRequest) ([]*dto.OrderResponse, int, error) {
+	// tanpa toko, tanggal dibaca di zona waktu server dengan pergantian hari tengah malam
+	settings := &store.StoreSettings{}
+	if _, err := uuid.Parse(req.StoreID); err == nil {
+		if settings, err = s.storeRepo.FindSettings(ctx, req.StoreID); err != nil {
+			return nil, 0, err
+		}
+	}
+	filter, err := ToOrderFilter(req, settings)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	orders, total, err := s.repo.FindAll(ctx, filter)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -117,49 +276,174 @@ func (s *OrderService) FindAll(ctx context.Context, limit, offset int) ([]*dto.O
 	return responses, total, nil
 }
 
-func (s *OrderService) Update(ctx context.Context, id string, req *dto.OrderRequest, updatedBy string) (*dto.OrderResponse, error) {
-	// Ambil order lama
-	order, orderItems, err := s.repo.FindByID(ctx, id)
+func (s *OrderService) Update(ctx context.Context, id string, req *dto.OrderRequest, updatedBy, role string) (*dto.OrderResponse, error) {
+	// Begin TX; order lama dikunci supaya pembayaran, status atau ongkos antar-jemput yang masuk
+	// bersamaan tidak tertimpa salinan lama
+	tx, err := s.db.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer tx.Rollback(ctx)
+
+	order, err := s.repo.FindByIDForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !IsEditable(order.Status) {
+		return nil, ErrOrderNotEditable
+	}
 
 	cust, err := s.customerRepo.FindByID(ctx, order.CustomerID)
 	if err != nil {
 		return nil, fmt.Errorf("customer not found: %w", err)
 	}
 
-	// Hitung ulang total & change
-	var total float64
-	for _, item := range req.Items {
-		total += item.TotalPrice
+	// Update order model
+	UpdateOrderModel(order, req, updatedBy)
+
+	// Buat item model baru dan hitung ulang dari katalog; pembayaran tidak diubah di sini, lihat AddPayment
+	orderItems := ToOrderItemModels(order.ID, req.Items)
+	settings, err := s.storeRepo.FindSettings(ctx, order.StoreID)
+	if err != nil {
+		return nil, err
 	}
-	total = total - (total * req.Discount / 100)
-	change := req.PaidAmount - total
+	overrides, err := s.priceItems(ctx, order, orderItems, req.Items, req.Express, settings, updatedBy, order.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	// lama pengerjaan tetap dihitung dari saat cucian diterima
+	order.PickupDate, err = resolvePickup(req.PickupDate, EarliestPickup(order.CreatedAt, orderItems, settings), settings)
+	if err != nil {
+		return nil, err
+	}
+	prevPromoID, prevPoints := order.PromoID, order.PointsRedeemed
+	if err := s.applyPromo(ctx, order, orderItems, req.PromoCode, order.UpdatedAt); err != nil {
+		return nil, err
+	}
+	applyTotals(order, orderItems, settings)
+	if err := s.checkDiscountLimit(ctx, order, orderItems, overrides, settings, role, req.DiscountApproval); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, tx, order, orderItems); err != nil {
+		return nil, err
+	}
+
+	if err := s.swapPromo(ctx, tx, prevPromoID, order.PromoID); err != nil {
+		return nil, err
+	}
+	if err := s.redeemPoints(ctx, tx, order, prevPoints, settings, updatedBy, order.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := s.awardPoints(ctx, tx, order, settings, updatedBy, order.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	for _, override := range overrides {
+		if err := s.repo.CreatePriceOverride(ctx, tx, override); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return ToOrderResponse(order, cust, orderItems), nil
+}
 
-	// Parse pickup date
-	pickupDate, err := time.Parse(time.RFC3339, req.PickupDate)
+// Delete removes an order in one transaction. An order that was not cancelled first gives back its promo
+// quota, points and prepaid quota or balance, like a cancellation does.
+func (s *OrderService) Delete(ctx context.Context, id, deletedBy string) error {
+	tx, err := s.db.Begin(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("invalid pickup_date: %w", err)
+		return err
 	}
+	defer tx.Rollback(ctx)
 
-	// Update order model
-	UpdateOrderModel(order, req, updatedBy)
-	order.TotalPrice = total
-	order.Change = change
-	order.PickupDate = pickupDate
+	order, err := s.repo.FindByIDForUpdate(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if order.Status != enum.OrderStatusCancelled {
+		now := time.Now()
+		if err := s.swapPromo(ctx, tx, order.PromoID, ""); err != nil {
+			return err
+		}
+		if err := s.reversePoints(ctx, tx, order, deletedBy, now); err != nil {
+			return err
+		}
+		if err := s.refundWallets(ctx, tx, order, deletedBy, now); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.Delete(ctx, tx, id); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
 
-	// Buat item model baru
-	orderItems = ToOrderItemModelsForUpdate(order.ID, req.Items)
+// UpdateStatus moves an order through the status state machine and records the change in its history.
+func (s *OrderService) UpdateStatus(ctx context.Context, id string, req *dto.OrderStatusRequest, updatedBy string) (*dto.OrderResponse, error) {
+	if !IsValidStatus(req.Status) {
+		return nil, ErrInvalidStatus
+	}
 
-	// Begin TX
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback(ctx)
 
-	if err := s.repo.Update(ctx, tx, order, orderItems); err != nil {
+	// order dikunci supaya pembatalan mengembalikan promo, poin, dan paket sesuai data terbaru
+	order, err := s.repo.FindByIDForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	fromStatus := order.Status
+	if !CanTransition(fromStatus, req.Status) {
+		return nil, fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, fromStatus, req.Status)
+	}
+
+	items, err := s.repo.FindItems(ctx, tx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	cust, err := s.customerRepo.FindByID(ctx, order.CustomerID)
+	if err != nil {
+		return nil, fmt.Errorf("customer not found: %w", err)
+	}
+
+	now := time.Now()
+	order.Status = req.Status
+	order.UpdatedAt = now
+	order.UpdatedBy = updatedBy
+
+	if err := s.repo.UpdateStatus(ctx, tx, order, fromStatus); err != nil {
+		return nil, err
+	}
+
+	// order batal mengembalikan kuota promo, poin pelanggan, dan kuota/saldo paket prabayar
+	if order.Status == enum.OrderStatusCancelled {
+		if err := s.swapPromo(ctx, tx, order.PromoID, ""); err != nil {
+			return nil, err
+		}
+		if err := s.reversePoints(ctx, tx, order, updatedBy, now); err != nil {
+			return nil, err
+		}
+		if err := s.refundWallets(ctx, tx, order, updatedBy, now); err != nil {
+			return nil, err
+		}
+	}
+
+	history := ToStatusHistoryModel(order.ID, fromStatus, order.Status, req.Reason, updatedBy, now)
+	if err := s.repo.CreateStatusHistory(ctx, tx, history); err != nil {
+		return nil, err
+	}
+
+	if err := s.notify(ctx, tx, order, cust); err != nil {
 		return nil, err
 	}
 
@@ -167,9 +451,191 @@ func (s *OrderService) Update(ctx context.Context, id string, req *dto.OrderRequ
 		return nil, err
 	}
 
-	return ToOrderResponse(order, cust, orderItems), nil
+	timeline, err := s.repo.FindStatusHistory(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := ToOrderResponse(order, cust, items)
+	res.StatusHistory = ToStatusHistoryResponses(timeline)
+	return res, nil
+}
+
+func (s *OrderService) StatusHistory(ctx context.Context, id string) ([]dto.OrderStatusHistoryResponse, error) {
+	if _, _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	history, err := s.repo.FindStatusHistory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return ToStatusHistoryResponses(history), nil
+}
+
+// PriceOverrides returns the audit trail of manually priced lines on the order.
+func (s *OrderService) PriceOverrides(ctx context.Context, id string) ([]dto.OrderPriceOverrideResponse, error) {
+	if _, _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	overrides, err := s.repo.FindPriceOverrides(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return ToPriceOverrideResponses(overrides), nil
+}
+
+// AddPayment records a payment against the order. Any amount above the outstanding balance
+// is returned to the customer as change for this payment only.
+func (s *OrderService) AddPayment(ctx context.Context, id string, req *dto.OrderPaymentRequest, receivedBy string) (*dto.OrderResponse, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	order, err := s.repo.FindByIDForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status == enum.OrderStatusCancelled {
+		return nil, ErrOrderNotPayable
+	}
+
+	outstanding := OutstandingAmount(order.TotalPrice, order.PaidAmount)
+	if outstanding <= 0 {
+		return nil, ErrOrderAlreadyPaid
+	}
+
+	method, err := s.resolvePaymentMethod(ctx, order.StoreID, req.PaymentMethodID, receivedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	payment := ToOrderPaymentModel(order.ID, req, method, receivedBy)
+	applied, change := splitPayment(payment.Amount, outstanding)
+	payment.Change = change
+
+	order.PaidAmount += applied
+	order.Change += change
+	order.UpdatedAt = payment.ReceivedAt
+	order.UpdatedBy = receivedBy
+
+	if err := s.repo.CreatePayment(ctx, tx, payment); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdatePaymentTotals(ctx, tx, order); err != nil {
+		return nil, err
+	}
+
+	// order yang baru lunas mendapat poin loyalti
+	settings, err := s.storeRepo.FindSettings(ctx, order.StoreID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.awardPoints(ctx, tx, order, settings, receivedBy, payment.ReceivedAt); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.responseWithPayments(ctx, order)
 }
 
-func (s *OrderService) Delete(ctx context.Context, id string) error {
-	return s.repo.Delete(ctx, id)
+// resolvePaymentMethod returns the payment method to tag a payment with. An empty id falls back
+// to the store's "cash" method; any method must belong to the order's store and be active.
+func (s *OrderService) resolvePaymentMethod(ctx context.Context, storeID, methodID, by string) (*paymentmethod.PaymentMethod, error) {
+	var (
+		method *paymentmethod.PaymentMethod
+		err    error
+	)
+	if methodID == "" {
+		method, err = paymentmethod.FindOrCreateBuiltin(ctx, s.paymentMethodRepo, storeID, enum.PaymentMethodCash, by)
+	} else {
+		method, err = s.paymentMethodRepo.FindByID(ctx, methodID)
+	}
+	if errors.Is(err, paymentmethod.ErrPaymentMethodNotFound) {
+		return nil, ErrInvalidPaymentMethod
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// metode prepaid hanya lewat AddWalletPayment, supaya selalu ada mutasi wallet-nya
+	if method.StoreID != storeID || !method.IsActive || method.Type == enum.PaymentMethodPrepaid {
+		return nil, ErrInvalidPaymentMethod
+	}
+	return method, nil
+}
+
+func (s *OrderService) Payments(ctx context.Context, id string) ([]dto.OrderPaymentResponse, error) {
+	if _, _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	payments, err := s.repo.FindPayments(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return ToOrderPaymentResponses(payments), nil
+}
+
+func (s *OrderService) responseWithPayments(ctx context.Context, order *Order) (*dto.OrderResponse, error) {
+	_, items, err := s.repo.FindByID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	cust, err := s.customerRepo.FindByID(ctx, order.CustomerID)
+	if err != nil {
+		return nil, fmt.Errorf("customer not found: %w", err)
+	}
+
+	payments, err := s.repo.FindPayments(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := ToOrderResponse(order, cust, items)
+	res.Payments = ToOrderPaymentResponses(payments)
+	return res, nil
+}
+
+// QRIS builds a dynamic QRIS payload for the order's outstanding balance from the store's static QRIS.
+func (s *OrderService) QRIS(ctx context.Context, id string) (*dto.OrderQRISResponse, error) {
+	order, _, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status == enum.OrderStatusCancelled {
+		return nil, ErrOrderNotPayable
+	}
+
+	amount := OutstandingAmount(order.TotalPrice, order.PaidAmount)
+	if amount <= 0 {
+		return nil, ErrOrderAlreadyPaid
+	}
+
+	settings, err := s.storeRepo.FindSettings(ctx, order.StoreID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.QRISPayload == "" {
+		return nil, ErrQRISNotConfigured
+	}
+
+	payload, err := qris.Dynamic(settings.QRISPayload, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.OrderQRISResponse{
+		OrderID:       order.ID,
+		InvoiceNumber: order.InvoiceNumber,
+		Amount:        amount,
+		Payload:       payload,
+	}, nil
 }
@@ -0,0 +1,43 @@
+package order
+
+import "sumunar-pos-core/internal/enum"
+
+// statusTransitions lists, for every status, the statuses an order may move to next.
+// An order can only be cancelled before it is done.
+var statusTransitions = map[string][]string{
+	enum.OrderStatusPending:   {enum.OrderStatusProcessed, enum.OrderStatusCancelled},
+	enum.OrderStatusProcessed: {enum.OrderStatusDone, enum.OrderStatusCancelled},
+	enum.OrderStatusDone:      {enum.OrderStatusTaken},
+	enum.OrderStatusTaken:     {},
+	enum.OrderStatusCancelled: {},
+}
+
+// IsValidStatus reports whether status is one of the known order statuses.
+func IsValidStatus(status string) bool {
+	_, ok := statusTransitions[status]
+	return ok
+}
+
+// CanTransition reports whether an order in status from may move to status to.
+func CanTransition(from, to string) bool {
+	for _, next := range statusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEditable reports whether items and pricing of an order in the given status may still change.
+func IsEditable(status string) bool {
+	return status == enum.OrderStatusPending || status == enum.OrderStatusProcessed
+}
+
+// statusEvents maps the status an order moves into to the customer notification event it triggers.
+var statusEvents = map[string]string{
+	enum.OrderStatusPending:   enum.NotificationEventOrderReceived,
+	enum.OrderStatusProcessed: enum.NotificationEventOrderProcessed,
+	enum.OrderStatusDone:      enum.NotificationEventOrderReady,
+	enum.OrderStatusTaken:     enum.NotificationEventOrderTaken,
+	enum.OrderStatusCancelled: enum.NotificationEventOrderCancelled,
+}
@@ -0,0 +1,38 @@
+package order
+
+import (
+	"testing"
+
+	"sumunar-pos-core/internal/enum"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{enum.OrderStatusPending, enum.OrderStatusProcessed, true},
+		{enum.OrderStatusPending, enum.OrderStatusCancelled, true},
+		{enum.OrderStatusPending, enum.OrderStatusDone, false},
+		{enum.OrderStatusPending, enum.OrderStatusTaken, false},
+		{enum.OrderStatusPending, enum.OrderStatusPending, false},
+		{enum.OrderStatusProcessed, enum.OrderStatusDone, true},
+		{enum.OrderStatusProcessed, enum.OrderStatusCancelled, true},
+		{enum.OrderStatusProcessed, enum.OrderStatusPending, false},
+		{enum.OrderStatusProcessed, enum.OrderStatusTaken, false},
+		{enum.OrderStatusDone, enum.OrderStatusTaken, true},
+		{enum.OrderStatusDone, enum.OrderStatusCancelled, false}, // sudah selesai, tidak bisa dibatalkan
+		{enum.OrderStatusDone, enum.OrderStatusProcessed, false},
+		{enum.OrderStatusTaken, enum.OrderStatusDone, false},
+		{enum.OrderStatusTaken, enum.OrderStatusCancelled, false},
+		{enum.OrderStatusCancelled, enum.OrderStatusPending, false},
+		{enum.OrderStatusCancelled, enum.OrderStatusProcessed, false},
+		{"unknown", enum.OrderStatusProcessed, false},
+		{enum.OrderStatusPending, "unknown", false},
+	}
+	for _, tt := range tests {
+		if got := CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package paymentmethod
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"sumunar-pos-core/internal/base"
+	"sumunar-pos-core/internal/enum"
+
+	"github.com/google/uuid"
+)
+
+// builtinNames are the methods every store has; the code doubles as the type.
+var builtinNames = map[string]string{
+	enum.PaymentMethodCash:    "Tunai",
+	enum.PaymentMethodPrepaid: "Paket Prabayar",
+}
+
+// FindOrCreateBuiltin returns the store's cash or prepaid method, creating it on first use: stores
+// opened after the payment methods migration start without them.
+func FindOrCreateBuiltin(ctx context.Context, repo PaymentMethodRepository, storeID, code, by string) (*PaymentMethod, error) {
+	method, err := repo.FindByCode(ctx, storeID, code)
+	if !errors.Is(err, ErrPaymentMethodNotFound) {
+		return method, err
+	}
+
+	now := time.Now()
+	method = &PaymentMethod{
+		ID:      uuid.New().String(),
+		StoreID: storeID,
+		Code:    code,
+		Name:    builtinNames[code],
+		Type:    code,
+		BaseModel: base.BaseModel{
+			IsActive:  true,
+			CreatedAt: now,
+			CreatedBy: by,
+		},
+	}
+	if err := repo.Create(ctx, method); err != nil {
+		// request lain bisa saja membuatnya lebih dulu
+		return repo.FindByCode(ctx, storeID, code)
+	}
+	return method, nil
+}
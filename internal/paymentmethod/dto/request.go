@@ -0,0 +1,16 @@
+package dto
+
+type PaymentMethodRequest struct {
+	StoreID  string `json:"store_id" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+	Name     string `json:"name" validate:"required"`
+	Type     string `json:"type" validate:"required,oneof=cash bank_transfer ewallet qris"`
+	IsActive *bool  `json:"is_active"`
+}
+
+// ListRequest is the query string of the list endpoint.
+type ListRequest struct {
+	StoreID string `query:"store_id" validate:"omitempty,uuid"`
+	Limit   int    `query:"limit"`
+	Offset  int    `query:"offset"`
+}
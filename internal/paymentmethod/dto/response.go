@@ -0,0 +1,14 @@
+package dto
+
+type PaymentMethodResponse struct {
+	ID       string `json:"id"`
+	StoreID  string `json:"store_id"`
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	IsActive bool   `json:"is_active"`
+}
+
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
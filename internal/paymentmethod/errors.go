@@ -0,0 +1,7 @@
+package paymentmethod
+
+import "errors"
+
+var (
+	ErrPaymentMethodNotFound = errors.New("payment method not found")
+)
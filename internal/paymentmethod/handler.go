@@ -0,0 +1,104 @@
+package paymentmethod
+
+import (
+	"errors"
+	"net/http"
+
+	"sumunar-pos-core/internal/paymentmethod/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service PaymentMethodService
+}
+
+func NewHandler(service PaymentMethodService) *Handler {
+	return &Handler{service}
+}
+
+func (h *Handler) Create(c echo.Context) error {
+	var req dto.PaymentMethodRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	method, err := h.service.Create(c.Request().Context(), &req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, ToPaymentMethodResponse(method))
+}
+
+func (h *Handler) FindByID(c echo.Context) error {
+	id := c.Param("id")
+
+	method, err := h.service.FindByID(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToPaymentMethodResponse(method))
+}
+
+func (h *Handler) FindAll(c echo.Context) error {
+	var req dto.ListRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	storeID, limit, offset := req.StoreID, req.Limit, req.Offset
+	if limit <= 0 {
+		limit = 50
+	}
+
+	methods, total, err := h.service.FindAll(c.Request().Context(), storeID, limit, offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data":   ToPaymentMethodListResponse(methods),
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+func (h *Handler) Update(c echo.Context) error {
+	id := c.Param("id")
+	var req dto.PaymentMethodRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	method, err := h.service.Update(c.Request().Context(), id, &req)
+	if errors.Is(err, ErrPaymentMethodNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToPaymentMethodResponse(method))
+}
+
+func (h *Handler) Delete(c echo.Context) error {
+	id := c.Param("id")
+
+	if err := h.service.Delete(c.Request().Context(), id); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,52 @@
+package paymentmethod
+
+import (
+	"time"
+
+	"sumunar-pos-core/internal/base"
+	"sumunar-pos-core/internal/paymentmethod/dto"
+
+	"github.com/google/uuid"
+)
+
+func ToPaymentMethodModel(req *dto.PaymentMethodRequest, createdBy string) *PaymentMethod {
+	now := time.Now()
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	return &PaymentMethod{
+		ID:      uuid.New().String(),
+		StoreID: req.StoreID,
+		Code:    req.Code,
+		Name:    req.Name,
+		Type:    req.Type,
+		BaseModel: base.BaseModel{
+			IsActive:  isActive,
+			CreatedAt: now,
+			CreatedBy: createdBy,
+			UpdatedAt: now,
+			UpdatedBy: createdBy,
+		},
+	}
+}
+
+func ToPaymentMethodResponse(method *PaymentMethod) *dto.PaymentMethodResponse {
+	return &dto.PaymentMethodResponse{
+		ID:       method.ID,
+		StoreID:  method.StoreID,
+		Code:     method.Code,
+		Name:     method.Name,
+		Type:     method.Type,
+		IsActive: method.IsActive,
+	}
+}
+
+func ToPaymentMethodListResponse(methods []*PaymentMethod) []*dto.PaymentMethodResponse {
+	res := make([]*dto.PaymentMethodResponse, 0)
+	for _, m := range methods {
+		res = append(res, ToPaymentMethodResponse(m))
+	}
+	return res
+}
@@ -0,0 +1,14 @@
+package paymentmethod
+
+import (
+	"sumunar-pos-core/internal/base"
+)
+
+type PaymentMethod struct {
+	ID      string `db:"id"`
+	StoreID string `db:"store_id"`
+	Code    string `db:"code"` // "cash", "bca", "gopay", dll (unik per store)
+	Name    string `db:"name"`
+	Type    string `db:"type"` // cash, bank_transfer, ewallet, qris
+	base.BaseModel
+}
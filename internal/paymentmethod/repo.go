@@ -0,0 +1,146 @@
+package paymentmethod
+
+import (
+	"context"
+	"errors"
+
+	"sumunar-pos-core/pkg/db"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type PaymentMethodRepository interface {
+	Create(ctx context.Context, method *PaymentMethod) error
+	FindByID(ctx context.Context, id string) (*PaymentMethod, error)
+	FindByCode(ctx context.Context, storeID, code string) (*PaymentMethod, error)
+	FindAll(ctx context.Context, storeID string, limit, offset int) ([]*PaymentMethod, int, error)
+	Update(ctx context.Context, method *PaymentMethod) error
+	Delete(ctx context.Context, id string) error
+}
+
+type paymentMethodRepo struct {
+	db db.DBTX
+}
+
+func NewPaymentMethodRepository(db db.DBTX) PaymentMethodRepository {
+	return &paymentMethodRepo{db}
+}
+
+func (r *paymentMethodRepo) Create(ctx context.Context, method *PaymentMethod) error {
+	query := `
+		INSERT INTO payment_methods (id, store_id, code, name, type, is_active, created_at, created_by, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $7, $8)
+	`
+	_, err := r.db.Exec(ctx, query,
+		method.ID,
+		method.StoreID,
+		method.Code,
+		method.Name,
+		method.Type,
+		method.IsActive,
+		method.CreatedAt,
+		method.CreatedBy,
+	)
+	return err
+}
+
+func (r *paymentMethodRepo) FindByID(ctx context.Context, id string) (*PaymentMethod, error) {
+	query := `SELECT id, store_id, code, name, type, is_active, created_at, created_by, updated_at, updated_by FROM payment_methods WHERE id = $1`
+	return r.scanOne(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *paymentMethodRepo) FindByCode(ctx context.Context, storeID, code string) (*PaymentMethod, error) {
+	query := `SELECT id, store_id, code, name, type, is_active, created_at, created_by, updated_at, updated_by FROM payment_methods WHERE store_id = $1 AND code = $2`
+	return r.scanOne(r.db.QueryRow(ctx, query, storeID, code))
+}
+
+func (r *paymentMethodRepo) scanOne(row pgx.Row) (*PaymentMethod, error) {
+	var m PaymentMethod
+	err := row.Scan(
+		&m.ID,
+		&m.StoreID,
+		&m.Code,
+		&m.Name,
+		&m.Type,
+		&m.IsActive,
+		&m.CreatedAt,
+		&m.CreatedBy,
+		&m.UpdatedAt,
+		&m.UpdatedBy,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPaymentMethodNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// FindAll lists payment methods, optionally limited to one store when storeID is not empty.
+func (r *paymentMethodRepo) FindAll(ctx context.Context, storeID string, limit, offset int) ([]*PaymentMethod, int, error) {
+	query := `
+		SELECT id, store_id, code, name, type, is_active, created_at, created_by, updated_at, updated_by
+		FROM payment_methods
+		WHERE ($1::uuid IS NULL OR store_id = $1)
+		ORDER BY name
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Query(ctx, query, db.NullID(storeID), limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var methods []*PaymentMethod
+	for rows.Next() {
+		var m PaymentMethod
+		if err := rows.Scan(
+			&m.ID,
+			&m.StoreID,
+			&m.Code,
+			&m.Name,
+			&m.Type,
+			&m.IsActive,
+			&m.CreatedAt,
+			&m.CreatedBy,
+			&m.UpdatedAt,
+			&m.UpdatedBy,
+		); err != nil {
+			return nil, 0, err
+		}
+		methods = append(methods, &m)
+	}
+
+	// total count
+	var total int
+	err = r.db.QueryRow(ctx, `SELECT COUNT(*) FROM payment_methods WHERE ($1::uuid IS NULL OR store_id = $1)`, db.NullID(storeID)).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return methods, total, nil
+}
+
+func (r *paymentMethodRepo) Update(ctx context.Context, method *PaymentMethod) error {
+	query := `
+		UPDATE payment_methods SET code = $1, name = $2, type = $3, is_active = $4,
+		updated_at = $5, updated_by = $6
+		WHERE id = $7
+	`
+	_, err := r.db.Exec(ctx, query,
+		method.Code,
+		method.Name,
+		method.Type,
+		method.IsActive,
+		method.UpdatedAt,
+		method.UpdatedBy,
+		method.ID,
+	)
+	return err
+}
+
+func (r *paymentMethodRepo) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM payment_methods WHERE id = $1`, id)
+	return err
+}
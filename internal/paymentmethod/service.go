@@ -0,0 +1,76 @@
+package paymentmethod
+
+import (
+	"context"
+	"log"
+	"sumunar-pos-core/internal/paymentmethod/dto"
+	"sumunar-pos-core/middleware"
+	"time"
+)
+
+type PaymentMethodService interface {
+	Create(ctx context.Context, req *dto.PaymentMethodRequest) (*PaymentMethod, error)
+	FindByID(ctx context.Context, id string) (*PaymentMethod, error)
+	FindAll(ctx context.Context, storeID string, limit, offset int) ([]*PaymentMethod, int, error)
+	Update(ctx context.Context, id string, req *dto.PaymentMethodRequest) (*PaymentMethod, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type service struct {
+	repo PaymentMethodRepository
+}
+
+func NewService(repo PaymentMethodRepository) PaymentMethodService {
+	return &service{repo: repo}
+}
+
+func (s *service) Create(ctx context.Context, req *dto.PaymentMethodRequest) (*PaymentMethod, error) {
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("failed to get user id from context:", err)
+	}
+
+	method := ToPaymentMethodModel(req, userID)
+
+	if err := s.repo.Create(ctx, method); err != nil {
+		return nil, err
+	}
+
+	return method, nil
+}
+
+func (s *service) FindByID(ctx context.Context, id string) (*PaymentMethod, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *service) FindAll(ctx context.Context, storeID string, limit, offset int) ([]*PaymentMethod, int, error) {
+	return s.repo.FindAll(ctx, storeID, limit, offset)
+}
+
+func (s *service) Update(ctx context.Context, id string, req *dto.PaymentMethodRequest) (*PaymentMethod, error) {
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("failed to get user id from context:", err)
+	}
+
+	method, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// store_id tidak bisa dipindah, pembayaran lama tetap menunjuk ke store asal
+	method.Code = req.Code
+	method.Name = req.Name
+	method.Type = req.Type
+	if req.IsActive != nil {
+		method.IsActive = *req.IsActive
+	}
+	method.UpdatedAt = time.Now()
+	method.UpdatedBy = userID
+
+	return method, s.repo.Update(ctx, method)
+}
+
+func (s *service) Delete(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
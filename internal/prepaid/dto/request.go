@@ -0,0 +1,39 @@
+package dto
+
+import "sumunar-pos-core/pkg/money"
+
+type PackageRequest struct {
+	StoreID           string       `json:"store_id" validate:"required"`
+	Name              string       `json:"name" validate:"required,max=100"`
+	Type              string       `json:"type" validate:"required,oneof=quota balance"`
+	Unit              string       `json:"unit" validate:"max=20"` // wajib untuk quota, misal kg atau pcs
+	Quota             float64      `json:"quota" validate:"gte=0"`
+	Balance           money.Amount `json:"balance" validate:"gte=0"`
+	Price             money.Amount `json:"price" validate:"gte=0"`
+	ValidityDays      int          `json:"validity_days" validate:"gte=0"` // 0 = tidak kedaluwarsa
+	ProductServiceIDs []string     `json:"product_service_ids" validate:"omitempty,dive,uuid"`
+	IsActive          *bool        `json:"is_active"`
+}
+
+// PurchaseRequest sells a package to a customer; the price is paid in full with the given method.
+type PurchaseRequest struct {
+	CustomerID      string `json:"customer_id" validate:"required"`
+	PackageID       string `json:"package_id" validate:"required"`
+	PaymentMethodID string `json:"payment_method_id"` // kosong = tunai
+	Reference       string `json:"reference"`
+}
+
+// WalletListRequest is the query string of GET /api/prepaid/wallets.
+type WalletListRequest struct {
+	StoreID    string `query:"store_id" validate:"omitempty,uuid"`
+	CustomerID string `query:"customer_id" validate:"omitempty,uuid"`
+	PackageID  string `query:"package_id" validate:"omitempty,uuid"`
+	Status     string `query:"status" validate:"omitempty,oneof=active expired used_up"`
+	Limit      int    `query:"limit"`
+	Offset     int    `query:"offset"`
+}
+
+// EntryListRequest is the query string of a customer's wallet ledger.
+type EntryListRequest struct {
+	WalletID string `query:"wallet_id" validate:"omitempty,uuid"`
+}
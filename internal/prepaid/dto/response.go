@@ -0,0 +1,62 @@
+package dto
+
+import "sumunar-pos-core/pkg/money"
+
+type PackageResponse struct {
+	ID                string       `json:"id"`
+	StoreID           string       `json:"store_id"`
+	Name              string       `json:"name"`
+	Type              string       `json:"type"`
+	Unit              string       `json:"unit"`
+	Quota             float64      `json:"quota"`
+	Balance           money.Amount `json:"balance"`
+	Price             money.Amount `json:"price"`
+	ValidityDays      int          `json:"validity_days"`
+	ProductServiceIDs []string     `json:"product_service_ids"`
+	IsActive          bool         `json:"is_active"`
+}
+
+type WalletResponse struct {
+	ID                string       `json:"id"`
+	CustomerID        string       `json:"customer_id"`
+	StoreID           string       `json:"store_id"`
+	PackageID         string       `json:"package_id"`
+	PackageName       string       `json:"package_name"`
+	Type              string       `json:"type"`
+	Unit              string       `json:"unit,omitempty"`
+	ProductServiceIDs []string     `json:"product_service_ids"`
+	Quota             float64      `json:"quota"`
+	QuotaRemaining    float64      `json:"quota_remaining"`
+	Balance           money.Amount `json:"balance"`
+	BalanceRemaining  money.Amount `json:"balance_remaining"`
+	Price             money.Amount `json:"price"`
+	Method            string       `json:"method"`
+	Reference         string       `json:"reference"`
+	Status            string       `json:"status"` // active, expired, used_up
+	PurchasedAt       string       `json:"purchased_at"`
+	ExpiresAt         string       `json:"expires_at,omitempty"` // ISO8601
+}
+
+type EntryResponse struct {
+	ID               string       `json:"id"`
+	WalletID         string       `json:"wallet_id"`
+	OrderID          string       `json:"order_id,omitempty"`
+	PaymentID        string       `json:"payment_id,omitempty"`
+	ProductServiceID string       `json:"product_service_id,omitempty"`
+	Type             string       `json:"type"` // purchase, usage, refund, expire
+	Quantity         float64      `json:"quantity"`
+	Amount           money.Amount `json:"amount"`
+	Description      string       `json:"description"`
+	CreatedAt        string       `json:"created_at"`
+}
+
+type PackageSummaryResponse struct {
+	PackageID        string       `json:"package_id"`
+	PackageName      string       `json:"package_name"`
+	Type             string       `json:"type"`
+	Unit             string       `json:"unit,omitempty"`
+	ActiveWallets    int          `json:"active_wallets"`
+	QuotaRemaining   float64      `json:"quota_remaining"`
+	BalanceRemaining money.Amount `json:"balance_remaining"`
+	ExpiringSoon     int          `json:"expiring_soon"`
+}
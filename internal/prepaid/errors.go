@@ -0,0 +1,14 @@
+package prepaid
+
+import "errors"
+
+var (
+	ErrPackageNotFound      = errors.New("prepaid package not found")
+	ErrInvalidPackage       = errors.New("quota package needs unit and quota, balance package needs balance")
+	ErrPackageInactive      = errors.New("prepaid package is not for sale")
+	ErrWalletNotFound       = errors.New("prepaid wallet not found")
+	ErrWalletExpired        = errors.New("prepaid wallet has expired")
+	ErrWalletUsedUp         = errors.New("prepaid wallet has no quota or balance left")
+	ErrInvalidPaymentMethod = errors.New("payment method is not available for this store")
+	ErrCustomerNotFound     = errors.New("customer not found")
+)
@@ -0,0 +1,220 @@
+package prepaid
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"sumunar-pos-core/internal/prepaid/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service PrepaidService
+}
+
+func NewHandler(service PrepaidService) *Handler {
+	return &Handler{service}
+}
+
+func (h *Handler) CreatePackage(c echo.Context) error {
+	var req dto.PackageRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Get("user_id").(string)
+	p, err := h.service.CreatePackage(c.Request().Context(), &req, userID)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, ToPackageResponse(p))
+}
+
+func (h *Handler) FindPackageByID(c echo.Context) error {
+	p, err := h.service.FindPackageByID(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToPackageResponse(p))
+}
+
+func (h *Handler) FindPackages(c echo.Context) error {
+	limit, offset := pagination(c)
+
+	packages, total, err := h.service.FindPackages(c.Request().Context(), c.QueryParam("store_id"), limit, offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data":   ToPackageListResponse(packages),
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+func (h *Handler) UpdatePackage(c echo.Context) error {
+	var req dto.PackageRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Get("user_id").(string)
+	p, err := h.service.UpdatePackage(c.Request().Context(), c.Param("id"), &req, userID)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToPackageResponse(p))
+}
+
+func (h *Handler) DeletePackage(c echo.Context) error {
+	if err := h.service.DeletePackage(c.Request().Context(), c.Param("id")); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Purchase godoc
+// @Summary Jual paket prabayar ke pelanggan
+// @Tags prepaid
+// @Accept json
+// @Produce json
+// @Param body body dto.PurchaseRequest true "Paket dan pembayaran"
+// @Success 201 {object} dto.WalletResponse
+// @Router /prepaid/purchases [post]
+func (h *Handler) Purchase(c echo.Context) error {
+	var req dto.PurchaseRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Get("user_id").(string)
+	w, err := h.service.Purchase(c.Request().Context(), &req, userID)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, ToWalletResponse(w, w.PurchasedAt))
+}
+
+func (h *Handler) FindWalletByID(c echo.Context) error {
+	w, err := h.service.FindWalletByID(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToWalletResponse(w, time.Now()))
+}
+
+// Wallets lists the wallets sold, filtered by store, customer, package and status.
+func (h *Handler) Wallets(c echo.Context) error {
+	var req dto.WalletListRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if req.Limit <= 0 {
+		req.Limit = 50
+	}
+
+	filter := &WalletFilter{
+		StoreID:    req.StoreID,
+		CustomerID: req.CustomerID,
+		PackageID:  req.PackageID,
+		Status:     req.Status,
+		At:         time.Now(),
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+	}
+	wallets, total, err := h.service.Wallets(c.Request().Context(), filter)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data":   ToWalletListResponse(wallets, filter.At),
+		"total":  total,
+		"limit":  req.Limit,
+		"offset": req.Offset,
+	})
+}
+
+// Entries returns the wallet ledger of a customer; ?wallet_id= narrows it to one wallet.
+func (h *Handler) Entries(c echo.Context) error {
+	var req dto.EntryListRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	limit, offset := pagination(c)
+
+	entries, total, err := h.service.Entries(c.Request().Context(), c.Param("id"), req.WalletID, limit, offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data":   ToEntryResponses(entries),
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// Summary is the remaining-quota report of a store: what is still owed to customers per package.
+func (h *Handler) Summary(c echo.Context) error {
+	storeID := c.QueryParam("store_id")
+	if storeID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "store_id is required")
+	}
+
+	rows, err := h.service.Summary(c.Request().Context(), storeID, time.Now())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"data": ToPackageSummaryResponses(rows)})
+}
+
+func pagination(c echo.Context) (limit, offset int) {
+	limit, _ = strconv.Atoi(c.QueryParam("limit"))
+	offset, _ = strconv.Atoi(c.QueryParam("offset"))
+	if limit <= 0 {
+		limit = 50
+	}
+	return limit, offset
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrPackageNotFound), errors.Is(err, ErrWalletNotFound), errors.Is(err, ErrCustomerNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidPackage), errors.Is(err, ErrInvalidPaymentMethod):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrPackageInactive), errors.Is(err, ErrWalletExpired), errors.Is(err, ErrWalletUsedUp):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
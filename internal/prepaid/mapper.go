@@ -0,0 +1,176 @@
+package prepaid
+
+import (
+	"strings"
+	"time"
+
+	"sumunar-pos-core/internal/base"
+	"sumunar-pos-core/internal/prepaid/dto"
+
+	"github.com/google/uuid"
+)
+
+func ToPackageModel(req *dto.PackageRequest, createdBy string) (*Package, error) {
+	now := time.Now()
+	p := &Package{
+		ID:      uuid.New().String(),
+		StoreID: req.StoreID,
+		BaseModel: base.BaseModel{
+			IsActive:  true,
+			CreatedAt: now,
+			CreatedBy: createdBy,
+			UpdatedAt: now,
+			UpdatedBy: createdBy,
+		},
+	}
+	if err := applyRequest(p, req); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// applyRequest copies the editable fields of req onto p; store_id is not touched.
+func applyRequest(p *Package, req *dto.PackageRequest) error {
+	unit := strings.TrimSpace(req.Unit)
+	switch {
+	case req.Type == TypeQuota && (unit == "" || req.Quota <= 0):
+		return ErrInvalidPackage
+	case req.Type == TypeBalance && req.Balance <= 0:
+		return ErrInvalidPackage
+	}
+
+	p.Name = req.Name
+	p.Type = req.Type
+	p.Unit = unit
+	p.Quota = req.Quota
+	p.Balance = req.Balance
+	if req.Type == TypeQuota {
+		p.Balance = 0
+	} else {
+		p.Unit, p.Quota = "", 0
+	}
+	p.Price = req.Price
+	p.ValidityDays = req.ValidityDays
+	p.ProductServiceIDs = req.ProductServiceIDs
+	if p.ProductServiceIDs == nil {
+		p.ProductServiceIDs = []string{}
+	}
+	if req.IsActive != nil {
+		p.IsActive = *req.IsActive
+	}
+	return nil
+}
+
+// ToWalletModel sells pkg to a customer: the package terms are copied onto the wallet.
+func ToWalletModel(pkg *Package, customerID, paymentMethodID, method, reference, createdBy string, at time.Time) *Wallet {
+	w := &Wallet{
+		ID:                uuid.New().String(),
+		CustomerID:        customerID,
+		StoreID:           pkg.StoreID,
+		PackageID:         pkg.ID,
+		PackageName:       pkg.Name,
+		Type:              pkg.Type,
+		Unit:              pkg.Unit,
+		ProductServiceIDs: pkg.ProductServiceIDs,
+		Quota:             pkg.Quota,
+		QuotaRemaining:    pkg.Quota,
+		Balance:           pkg.Balance,
+		BalanceRemaining:  pkg.Balance,
+		Price:             pkg.Price,
+		PaymentMethodID:   paymentMethodID,
+		Method:            method,
+		Reference:         reference,
+		PurchasedAt:       at,
+		CreatedBy:         createdBy,
+	}
+	if pkg.ValidityDays > 0 {
+		expiry := at.AddDate(0, 0, pkg.ValidityDays)
+		w.ExpiresAt = &expiry
+	}
+	return w
+}
+
+func ToPackageResponse(p *Package) *dto.PackageResponse {
+	return &dto.PackageResponse{
+		ID:                p.ID,
+		StoreID:           p.StoreID,
+		Name:              p.Name,
+		Type:              p.Type,
+		Unit:              p.Unit,
+		Quota:             p.Quota,
+		Balance:           p.Balance,
+		Price:             p.Price,
+		ValidityDays:      p.ValidityDays,
+		ProductServiceIDs: p.ProductServiceIDs,
+		IsActive:          p.IsActive,
+	}
+}
+
+func ToPackageListResponse(packages []*Package) []*dto.PackageResponse {
+	res := make([]*dto.PackageResponse, 0, len(packages))
+	for _, p := range packages {
+		res = append(res, ToPackageResponse(p))
+	}
+	return res
+}
+
+func ToWalletResponse(w *Wallet, at time.Time) *dto.WalletResponse {
+	res := &dto.WalletResponse{
+		ID:                w.ID,
+		CustomerID:        w.CustomerID,
+		StoreID:           w.StoreID,
+		PackageID:         w.PackageID,
+		PackageName:       w.PackageName,
+		Type:              w.Type,
+		Unit:              w.Unit,
+		ProductServiceIDs: w.ProductServiceIDs,
+		Quota:             w.Quota,
+		QuotaRemaining:    w.QuotaRemaining,
+		Balance:           w.Balance,
+		BalanceRemaining:  w.BalanceRemaining,
+		Price:             w.Price,
+		Method:            w.Method,
+		Reference:         w.Reference,
+		Status:            w.Status(at),
+		PurchasedAt:       w.PurchasedAt.Format(time.RFC3339),
+	}
+	if w.ExpiresAt != nil {
+		res.ExpiresAt = w.ExpiresAt.Format(time.RFC3339)
+	}
+	return res
+}
+
+func ToWalletListResponse(wallets []*Wallet, at time.Time) []*dto.WalletResponse {
+	res := make([]*dto.WalletResponse, 0, len(wallets))
+	for _, w := range wallets {
+		res = append(res, ToWalletResponse(w, at))
+	}
+	return res
+}
+
+func ToEntryResponses(entries []*Entry) []dto.EntryResponse {
+	res := make([]dto.EntryResponse, 0, len(entries))
+	for _, e := range entries {
+		res = append(res, dto.EntryResponse{
+			ID:               e.ID,
+			WalletID:         e.WalletID,
+			OrderID:          e.OrderID,
+			PaymentID:        e.PaymentID,
+			ProductServiceID: e.ProductServiceID,
+			Type:             e.Type,
+			Quantity:         e.Quantity,
+			Amount:           e.Amount,
+			Description:      e.Description,
+			CreatedAt:        e.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return res
+}
+
+func ToPackageSummaryResponses(rows []*PackageSummary) []dto.PackageSummaryResponse {
+	res := make([]dto.PackageSummaryResponse, 0, len(rows))
+	for _, r := range rows {
+		res = append(res, dto.PackageSummaryResponse(*r))
+	}
+	return res
+}
@@ -0,0 +1,145 @@
+package prepaid
+
+import (
+	"time"
+
+	"sumunar-pos-core/internal/base"
+	"sumunar-pos-core/pkg/money"
+)
+
+// Jenis paket prabayar.
+const (
+	TypeQuota   = "quota"   // kuota dalam satuan item, misal 30 kg
+	TypeBalance = "balance" // saldo deposit rupiah
+)
+
+// Jenis mutasi wallet.
+const (
+	EntryPurchase = "purchase"
+	EntryUsage    = "usage"
+	EntryRefund   = "refund" // order yang dibayar dari wallet dibatalkan
+	EntryExpire   = "expire"
+)
+
+// Package is a prepaid package a store sells, e.g. "30 kg per bulan" or "Deposit Rp 500.000".
+type Package struct {
+	ID                string
+	StoreID           string
+	Name              string
+	Type              string       // quota, balance
+	Unit              string       // satuan kuota, harus sama dengan unit product service, misal kg
+	Quota             float64      // untuk quota
+	Balance           money.Amount // untuk balance: saldo yang didapat, bisa lebih dari harga (bonus)
+	Price             money.Amount // harga jual paket
+	ValidityDays      int          // 0 = tidak kedaluwarsa
+	ProductServiceIDs []string     // product service yang bisa dibayar dengan paket ini, kosong = semua
+	base.BaseModel
+}
+
+// Wallet is a package bought by a customer, with what is left of it. Package terms are copied
+// at purchase so later package edits do not change wallets already sold.
+type Wallet struct {
+	ID                string
+	CustomerID        string
+	StoreID           string
+	PackageID         string
+	PackageName       string
+	Type              string
+	Unit              string
+	ProductServiceIDs []string
+	Quota             float64
+	QuotaRemaining    float64
+	Balance           money.Amount
+	BalanceRemaining  money.Amount
+	Price             money.Amount // harga yang dibayar pelanggan
+	PaymentMethodID   string
+	Method            string // kode metode pembayaran pembelian
+	Reference         string
+	PurchasedAt       time.Time
+	ExpiresAt         *time.Time // nil = tidak kedaluwarsa
+	CreatedBy         string
+}
+
+// Entry is one row of a customer's wallet ledger. Quantity and Amount are signed: purchases add,
+// usage and expiry take away. Usage of a quota wallet is recorded per product service of the order.
+type Entry struct {
+	ID               string
+	WalletID         string
+	CustomerID       string
+	StoreID          string
+	OrderID          string
+	PaymentID        string
+	ProductServiceID string
+	Type             string
+	Quantity         float64      // mutasi kuota
+	Amount           money.Amount // mutasi saldo, atau nilai order yang ditutup kuota
+	Description      string
+	CreatedAt        time.Time
+	CreatedBy        string
+}
+
+// WalletFilter is the query for the wallet list and remaining-quota report.
+type WalletFilter struct {
+	StoreID    string
+	CustomerID string
+	PackageID  string
+	Status     string // active, expired, used_up; kosong = semua
+	At         time.Time
+	Limit      int
+	Offset     int
+}
+
+// Status wallet.
+const (
+	StatusActive  = "active"
+	StatusExpired = "expired"
+	StatusUsedUp  = "used_up"
+)
+
+// PackageSummary is one row of the remaining-quota report: what is still owed to customers per package.
+type PackageSummary struct {
+	PackageID        string
+	PackageName      string
+	Type             string
+	Unit             string
+	ActiveWallets    int
+	QuotaRemaining   float64
+	BalanceRemaining money.Amount
+	ExpiringSoon     int // wallet aktif yang kedaluwarsa dalam 7 hari
+}
+
+// Status reports whether the wallet can still be used at the given time.
+func (w *Wallet) Status(at time.Time) string {
+	switch {
+	case w.ExpiresAt != nil && !at.Before(*w.ExpiresAt):
+		return StatusExpired
+	case w.Type == TypeQuota && w.QuotaRemaining <= 0, w.Type == TypeBalance && w.BalanceRemaining <= 0:
+		return StatusUsedUp
+	default:
+		return StatusActive
+	}
+}
+
+// Usable returns why the wallet cannot pay at the given time, or nil.
+func (w *Wallet) Usable(at time.Time) error {
+	switch w.Status(at) {
+	case StatusExpired:
+		return ErrWalletExpired
+	case StatusUsedUp:
+		return ErrWalletUsedUp
+	}
+	return nil
+}
+
+// Covers reports whether the wallet may pay for the product service.
+func (w *Wallet) Covers(productServiceID string) bool {
+	if len(w.ProductServiceIDs) == 0 {
+		return true
+	}
+	for _, id := range w.ProductServiceIDs {
+		if id == productServiceID {
+			return true
+		}
+	}
+	return false
+}
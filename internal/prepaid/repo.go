@@ -0,0 +1,451 @@
+package prepaid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"sumunar-pos-core/pkg/db"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type PrepaidRepository interface {
+	CreatePackage(ctx context.Context, p *Package) error
+	FindPackageByID(ctx context.Context, id string) (*Package, error)
+	FindPackages(ctx context.Context, storeID string, limit, offset int) ([]*Package, int, error)
+	UpdatePackage(ctx context.Context, p *Package) error
+	DeletePackage(ctx context.Context, id string) error
+
+	CreateWallet(ctx context.Context, tx db.DBTX, w *Wallet) error
+	FindWalletByID(ctx context.Context, id string) (*Wallet, error)
+	FindWalletForUpdate(ctx context.Context, tx db.DBTX, id string) (*Wallet, error)
+	FindWallets(ctx context.Context, filter *WalletFilter) ([]*Wallet, int, error)
+	UpdateWalletRemaining(ctx context.Context, tx db.DBTX, w *Wallet) error
+	ExpireWallets(ctx context.Context, tx db.DBTX, customerID string, at time.Time) error
+
+	CreateEntry(ctx context.Context, tx db.DBTX, e *Entry) error
+	FindEntries(ctx context.Context, customerID, walletID string, limit, offset int) ([]*Entry, int, error)
+	FindOrderEntries(ctx context.Context, tx db.DBTX, orderID string) ([]*Entry, error)
+	Summary(ctx context.Context, storeID string, at time.Time) ([]*PackageSummary, error)
+}
+
+type prepaidRepo struct {
+	db db.DBTX
+}
+
+func NewPrepaidRepository(db db.DBTX) PrepaidRepository {
+	return &prepaidRepo{db}
+}
+
+const packageColumns = `id, store_id, name, type, unit, quota, balance, price, validity_days, product_service_ids,
+	is_active, created_at, created_by, updated_at, updated_by`
+
+func (r *prepaidRepo) CreatePackage(ctx context.Context, p *Package) error {
+	query := `
+		INSERT INTO prepaid_packages (` + packageColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $12, $13)
+	`
+	_, err := r.db.Exec(ctx, query,
+		p.ID,
+		p.StoreID,
+		p.Name,
+		p.Type,
+		p.Unit,
+		p.Quota,
+		p.Balance,
+		p.Price,
+		p.ValidityDays,
+		p.ProductServiceIDs,
+		p.IsActive,
+		p.CreatedAt,
+		p.CreatedBy,
+	)
+	return err
+}
+
+func scanPackage(row pgx.Row) (*Package, error) {
+	var p Package
+	err := row.Scan(
+		&p.ID,
+		&p.StoreID,
+		&p.Name,
+		&p.Type,
+		&p.Unit,
+		&p.Quota,
+		&p.Balance,
+		&p.Price,
+		&p.ValidityDays,
+		&p.ProductServiceIDs,
+		&p.IsActive,
+		&p.CreatedAt,
+		&p.CreatedBy,
+		&p.UpdatedAt,
+		&p.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *prepaidRepo) FindPackageByID(ctx context.Context, id string) (*Package, error) {
+	p, err := scanPackage(r.db.QueryRow(ctx, `SELECT `+packageColumns+` FROM prepaid_packages WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPackageNotFound
+	}
+	return p, err
+}
+
+func (r *prepaidRepo) FindPackages(ctx context.Context, storeID string, limit, offset int) ([]*Package, int, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+packageColumns+` FROM prepaid_packages
+		WHERE store_id = $1
+		ORDER BY name
+		LIMIT $2 OFFSET $3
+	`, storeID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var packages []*Package
+	for rows.Next() {
+		p, err := scanPackage(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		packages = append(packages, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM prepaid_packages WHERE store_id = $1`, storeID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	return packages, total, nil
+}
+
+func (r *prepaidRepo) UpdatePackage(ctx context.Context, p *Package) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE prepaid_packages SET name = $1, type = $2, unit = $3, quota = $4, balance = $5, price = $6,
+			validity_days = $7, product_service_ids = $8, is_active = $9, updated_at = $10, updated_by = $11
+		WHERE id = $12
+	`,
+		p.Name,
+		p.Type,
+		p.Unit,
+		p.Quota,
+		p.Balance,
+		p.Price,
+		p.ValidityDays,
+		p.ProductServiceIDs,
+		p.IsActive,
+		p.UpdatedAt,
+		p.UpdatedBy,
+		p.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPackageNotFound
+	}
+	return nil
+}
+
+func (r *prepaidRepo) DeletePackage(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM prepaid_packages WHERE id = $1`, id)
+	return err
+}
+
+const walletColumns = `id, customer_id, store_id, COALESCE(package_id::text, ''), package_name, type, unit, product_service_ids,
+	quota, quota_remaining, balance, balance_remaining, price, payment_method_id, method, reference, purchased_at, expires_at,
+	COALESCE(created_by::text, '')`
+
+func (r *prepaidRepo) CreateWallet(ctx context.Context, tx db.DBTX, w *Wallet) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO prepaid_wallets (id, customer_id, store_id, package_id, package_name, type, unit, product_service_ids,
+			quota, quota_remaining, balance, balance_remaining, price, payment_method_id, method, reference, purchased_at, expires_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, NULLIF($19, '')::uuid)
+	`,
+		w.ID,
+		w.CustomerID,
+		w.StoreID,
+		w.PackageID,
+		w.PackageName,
+		w.Type,
+		w.Unit,
+		w.ProductServiceIDs,
+		w.Quota,
+		w.QuotaRemaining,
+		w.Balance,
+		w.BalanceRemaining,
+		w.Price,
+		w.PaymentMethodID,
+		w.Method,
+		w.Reference,
+		w.PurchasedAt,
+		w.ExpiresAt,
+		w.CreatedBy,
+	)
+	return err
+}
+
+func scanWallet(row pgx.Row) (*Wallet, error) {
+	var w Wallet
+	err := row.Scan(
+		&w.ID,
+		&w.CustomerID,
+		&w.StoreID,
+		&w.PackageID,
+		&w.PackageName,
+		&w.Type,
+		&w.Unit,
+		&w.ProductServiceIDs,
+		&w.Quota,
+		&w.QuotaRemaining,
+		&w.Balance,
+		&w.BalanceRemaining,
+		&w.Price,
+		&w.PaymentMethodID,
+		&w.Method,
+		&w.Reference,
+		&w.PurchasedAt,
+		&w.ExpiresAt,
+		&w.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+func (r *prepaidRepo) FindWalletByID(ctx context.Context, id string) (*Wallet, error) {
+	w, err := scanWallet(r.db.QueryRow(ctx, `SELECT `+walletColumns+` FROM prepaid_wallets WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrWalletNotFound
+	}
+	return w, err
+}
+
+// FindWalletForUpdate reads the wallet and locks it until tx ends.
+func (r *prepaidRepo) FindWalletForUpdate(ctx context.Context, tx db.DBTX, id string) (*Wallet, error) {
+	w, err := scanWallet(tx.QueryRow(ctx, `SELECT `+walletColumns+` FROM prepaid_wallets WHERE id = $1 FOR UPDATE`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrWalletNotFound
+	}
+	return w, err
+}
+
+// where builds the WHERE clause of the wallet list; status is derived from remaining and expiry at f.At.
+func (f *WalletFilter) where() (string, []any) {
+	var conds []string
+	args := []any{}
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.StoreID != "" {
+		add("store_id = $%d", f.StoreID)
+	}
+	if f.CustomerID != "" {
+		add("customer_id = $%d", f.CustomerID)
+	}
+	if f.PackageID != "" {
+		add("package_id = $%d", f.PackageID)
+	}
+
+	const left = `(CASE WHEN type = 'quota' THEN quota_remaining > 0 ELSE balance_remaining > 0 END)`
+	switch f.Status {
+	case StatusActive:
+		add("(expires_at IS NULL OR expires_at > $%d) AND "+left, f.At)
+	case StatusExpired:
+		add("expires_at <= $%d", f.At)
+	case StatusUsedUp:
+		add("(expires_at IS NULL OR expires_at > $%d) AND NOT "+left, f.At)
+	}
+
+	if len(conds) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+func (r *prepaidRepo) FindWallets(ctx context.Context, filter *WalletFilter) ([]*Wallet, int, error) {
+	where, args := filter.where()
+	query := `SELECT ` + walletColumns + ` FROM prepaid_wallets` + where +
+		fmt.Sprintf(` ORDER BY purchased_at DESC LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+
+	rows, err := r.db.Query(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var wallets []*Wallet
+	for rows.Next() {
+		w, err := scanWallet(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		wallets = append(wallets, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM prepaid_wallets`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	return wallets, total, nil
+}
+
+func (r *prepaidRepo) UpdateWalletRemaining(ctx context.Context, tx db.DBTX, w *Wallet) error {
+	_, err := tx.Exec(ctx, `UPDATE prepaid_wallets SET quota_remaining = $1, balance_remaining = $2 WHERE id = $3`,
+		w.QuotaRemaining, w.BalanceRemaining, w.ID)
+	return err
+}
+
+// ExpireWallets writes off what is left of the customer's expired wallets, in one statement.
+func (r *prepaidRepo) ExpireWallets(ctx context.Context, tx db.DBTX, customerID string, at time.Time) error {
+	_, err := tx.Exec(ctx, `
+		WITH due AS (
+			SELECT id, store_id, quota_remaining, balance_remaining FROM prepaid_wallets
+			WHERE customer_id = $1 AND expires_at <= $2 AND (quota_remaining > 0 OR balance_remaining > 0)
+			FOR UPDATE
+		), logged AS (
+			INSERT INTO prepaid_entries (id, wallet_id, customer_id, store_id, type, quantity, amount, description, created_at)
+			SELECT gen_random_uuid(), id, $1, store_id, 'expire', -quota_remaining, -balance_remaining, 'Paket kedaluwarsa', $2 FROM due
+		)
+		UPDATE prepaid_wallets w SET quota_remaining = 0, balance_remaining = 0 FROM due WHERE w.id = due.id
+	`, customerID, at)
+	return err
+}
+
+const entryColumns = `id, wallet_id, customer_id, store_id, COALESCE(order_id::text, ''), COALESCE(payment_id::text, ''),
+	COALESCE(product_service_id::text, ''), type, quantity, amount, description, created_at, COALESCE(created_by::text, '')`
+
+func (r *prepaidRepo) CreateEntry(ctx context.Context, tx db.DBTX, e *Entry) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO prepaid_entries (id, wallet_id, customer_id, store_id, order_id, payment_id, product_service_id,
+			type, quantity, amount, description, created_at, created_by)
+		VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid, NULLIF($6, '')::uuid, NULLIF($7, '')::uuid, $8, $9, $10, $11, $12, NULLIF($13, '')::uuid)
+	`,
+		e.ID,
+		e.WalletID,
+		e.CustomerID,
+		e.StoreID,
+		e.OrderID,
+		e.PaymentID,
+		e.ProductServiceID,
+		e.Type,
+		e.Quantity,
+		e.Amount,
+		e.Description,
+		e.CreatedAt,
+		e.CreatedBy,
+	)
+	return err
+}
+
+func scanEntries(rows pgx.Rows) ([]*Entry, error) {
+	defer rows.Close()
+
+	entries := []*Entry{}
+	for rows.Next() {
+		var e Entry
+		if err := rows.Scan(
+			&e.ID,
+			&e.WalletID,
+			&e.CustomerID,
+			&e.StoreID,
+			&e.OrderID,
+			&e.PaymentID,
+			&e.ProductServiceID,
+			&e.Type,
+			&e.Quantity,
+			&e.Amount,
+			&e.Description,
+			&e.CreatedAt,
+			&e.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
+
+// FindEntries returns the wallet ledger of a customer, optionally of one wallet, newest first.
+func (r *prepaidRepo) FindEntries(ctx context.Context, customerID, walletID string, limit, offset int) ([]*Entry, int, error) {
+	where := ` WHERE customer_id = $1 AND ($2::uuid IS NULL OR wallet_id = $2)`
+	rows, err := r.db.Query(ctx, `SELECT `+entryColumns+` FROM prepaid_entries`+where+`
+		ORDER BY created_at DESC LIMIT $3 OFFSET $4`, customerID, db.NullID(walletID), limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	entries, err := scanEntries(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM prepaid_entries`+where, customerID, db.NullID(walletID)).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// FindOrderEntries returns the usage and refund entries of an order.
+func (r *prepaidRepo) FindOrderEntries(ctx context.Context, tx db.DBTX, orderID string) ([]*Entry, error) {
+	rows, err := tx.Query(ctx, `SELECT `+entryColumns+` FROM prepaid_entries WHERE order_id = $1 ORDER BY created_at`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return scanEntries(rows)
+}
+
+// Summary is the remaining-quota report: per package, the active wallets and what is left on them.
+func (r *prepaidRepo) Summary(ctx context.Context, storeID string, at time.Time) ([]*PackageSummary, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT COALESCE(package_id::text, ''), package_name, type, unit, COUNT(*),
+			COALESCE(SUM(quota_remaining), 0), COALESCE(SUM(balance_remaining), 0),
+			COUNT(*) FILTER (WHERE expires_at <= $2::timestamptz + INTERVAL '7 days')
+		FROM prepaid_wallets
+		WHERE store_id = $1
+			AND (expires_at IS NULL OR expires_at > $2)
+			AND (CASE WHEN type = 'quota' THEN quota_remaining > 0 ELSE balance_remaining > 0 END)
+		GROUP BY package_id, package_name, type, unit
+		ORDER BY package_name
+	`, storeID, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summary := []*PackageSummary{}
+	for rows.Next() {
+		var s PackageSummary
+		if err := rows.Scan(
+			&s.PackageID,
+			&s.PackageName,
+			&s.Type,
+			&s.Unit,
+			&s.ActiveWallets,
+			&s.QuotaRemaining,
+			&s.BalanceRemaining,
+			&s.ExpiringSoon,
+		); err != nil {
+			return nil, err
+		}
+		summary = append(summary, &s)
+	}
+	return summary, rows.Err()
+}
@@ -0,0 +1,186 @@
+package prepaid
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"sumunar-pos-core/internal/customer"
+	"sumunar-pos-core/internal/enum"
+	"sumunar-pos-core/internal/paymentmethod"
+	"sumunar-pos-core/internal/prepaid/dto"
+	"sumunar-pos-core/pkg/db"
+
+	"github.com/google/uuid"
+)
+
+type PrepaidService interface {
+	CreatePackage(ctx context.Context, req *dto.PackageRequest, userID string) (*Package, error)
+	FindPackageByID(ctx context.Context, id string) (*Package, error)
+	FindPackages(ctx context.Context, storeID string, limit, offset int) ([]*Package, int, error)
+	UpdatePackage(ctx context.Context, id string, req *dto.PackageRequest, userID string) (*Package, error)
+	DeletePackage(ctx context.Context, id string) error
+	Purchase(ctx context.Context, req *dto.PurchaseRequest, userID string) (*Wallet, error)
+	FindWalletByID(ctx context.Context, id string) (*Wallet, error)
+	Wallets(ctx context.Context, filter *WalletFilter) ([]*Wallet, int, error)
+	Entries(ctx context.Context, customerID, walletID string, limit, offset int) ([]*Entry, int, error)
+	Summary(ctx context.Context, storeID string, at time.Time) ([]*PackageSummary, error)
+}
+
+type service struct {
+	repo              PrepaidRepository
+	customerRepo      customer.CustomerRepository
+	paymentMethodRepo paymentmethod.PaymentMethodRepository
+	db                db.TxBeginner
+}
+
+func NewService(repo PrepaidRepository, customerRepo customer.CustomerRepository,
+	paymentMethodRepo paymentmethod.PaymentMethodRepository, db db.TxBeginner) PrepaidService {
+	return &service{repo, customerRepo, paymentMethodRepo, db}
+}
+
+func (s *service) CreatePackage(ctx context.Context, req *dto.PackageRequest, userID string) (*Package, error) {
+	p, err := ToPackageModel(req, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreatePackage(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *service) FindPackageByID(ctx context.Context, id string) (*Package, error) {
+	return s.repo.FindPackageByID(ctx, id)
+}
+
+func (s *service) FindPackages(ctx context.Context, storeID string, limit, offset int) ([]*Package, int, error) {
+	return s.repo.FindPackages(ctx, storeID, limit, offset)
+}
+
+func (s *service) UpdatePackage(ctx context.Context, id string, req *dto.PackageRequest, userID string) (*Package, error) {
+	p, err := s.repo.FindPackageByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// wallet yang sudah terjual menyimpan salinan syarat paket, jadi edit hanya berlaku untuk penjualan berikutnya
+	if err := applyRequest(p, req); err != nil {
+		return nil, err
+	}
+	p.UpdatedAt = time.Now()
+	p.UpdatedBy = userID
+
+	return p, s.repo.UpdatePackage(ctx, p)
+}
+
+func (s *service) DeletePackage(ctx context.Context, id string) error {
+	return s.repo.DeletePackage(ctx, id)
+}
+
+// Purchase sells a package to a customer. The price is paid in full, so the sale shows up in
+// the cash reconciliation of the payment method used.
+func (s *service) Purchase(ctx context.Context, req *dto.PurchaseRequest, userID string) (*Wallet, error) {
+	pkg, err := s.repo.FindPackageByID(ctx, req.PackageID)
+	if err != nil {
+		return nil, err
+	}
+	if !pkg.IsActive {
+		return nil, ErrPackageInactive
+	}
+	if _, err := s.customerRepo.FindByID(ctx, req.CustomerID); err != nil {
+		if errors.Is(err, customer.ErrCustomerNotFound) {
+			return nil, ErrCustomerNotFound
+		}
+		return nil, err
+	}
+
+	method, err := s.resolvePaymentMethod(ctx, pkg.StoreID, req.PaymentMethodID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	wallet := ToWalletModel(pkg, req.CustomerID, method.ID, method.Code, req.Reference, userID, now)
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.repo.CreateWallet(ctx, tx, wallet); err != nil {
+		return nil, err
+	}
+	entry := &Entry{
+		ID:          uuid.New().String(),
+		WalletID:    wallet.ID,
+		CustomerID:  wallet.CustomerID,
+		StoreID:     wallet.StoreID,
+		Type:        EntryPurchase,
+		Quantity:    wallet.Quota,
+		Amount:      wallet.Balance,
+		Description: "Beli paket " + wallet.PackageName,
+		CreatedAt:   now,
+		CreatedBy:   userID,
+	}
+	if err := s.repo.CreateEntry(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+// resolvePaymentMethod: kosong berarti tunai. Paket tidak bisa dibeli dengan saldo paket lain.
+func (s *service) resolvePaymentMethod(ctx context.Context, storeID, methodID, by string) (*paymentmethod.PaymentMethod, error) {
+	var (
+		method *paymentmethod.PaymentMethod
+		err    error
+	)
+	if methodID == "" {
+		method, err = paymentmethod.FindOrCreateBuiltin(ctx, s.paymentMethodRepo, storeID, enum.PaymentMethodCash, by)
+	} else {
+		method, err = s.paymentMethodRepo.FindByID(ctx, methodID)
+	}
+	if errors.Is(err, paymentmethod.ErrPaymentMethodNotFound) {
+		return nil, ErrInvalidPaymentMethod
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if method.StoreID != storeID || !method.IsActive || method.Type == enum.PaymentMethodPrepaid {
+		return nil, ErrInvalidPaymentMethod
+	}
+	return method, nil
+}
+
+func (s *service) FindWalletByID(ctx context.Context, id string) (*Wallet, error) {
+	return s.repo.FindWalletByID(ctx, id)
+}
+
+// Wallets lists wallets; for a single customer the expired ones are written off first.
+func (s *service) Wallets(ctx context.Context, filter *WalletFilter) ([]*Wallet, int, error) {
+	if filter.CustomerID != "" {
+		if err := s.repo.ExpireWallets(ctx, s.db, filter.CustomerID, filter.At); err != nil {
+			return nil, 0, err
+		}
+	}
+	return s.repo.FindWallets(ctx, filter)
+}
+
+// Entries returns the customer's wallet ledger, newest first.
+func (s *service) Entries(ctx context.Context, customerID, walletID string, limit, offset int) ([]*Entry, int, error) {
+	if err := s.repo.ExpireWallets(ctx, s.db, customerID, time.Now()); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.FindEntries(ctx, customerID, walletID, limit, offset)
+}
+
+func (s *service) Summary(ctx context.Context, storeID string, at time.Time) ([]*PackageSummary, error) {
+	return s.repo.Summary(ctx, storeID, at)
+}
@@ -1,6 +1,14 @@
 package dto
 
 type ProductRequest struct {
-	Name    string `json:"name" validate:"required"`
-	StoreID string `json:"store_id" validate:"required"`
+	Name     string `json:"name" validate:"required"`
+	StoreID  string `json:"store_id" validate:"required"`
+	IsActive *bool  `json:"is_active"`
+}
+
+// ListRequest is the query string of the list endpoint.
+type ListRequest struct {
+	StoreID string `query:"store_id" validate:"omitempty,uuid"`
+	Limit   int    `query:"limit"`
+	Offset  int    `query:"offset"`
 }
@@ -1,9 +1,10 @@
 package dto
 
 type ProductResponse struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	StoreID string `json:"store_id"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	StoreID  string `json:"store_id"`
+	IsActive bool   `json:"is_active"`
 }
 
 type ErrorResponse struct {
@@ -0,0 +1,5 @@
+package product
+
+import "errors"
+
+var ErrStoreChange = errors.New("product cannot be moved to another store, create it in that store instead")
@@ -1,8 +1,8 @@
 package product
 
 import (
+	"errors"
 	"net/http"
-	"strconv"
 
 	"sumunar-pos-core/internal/product/dto"
 
@@ -58,10 +58,16 @@ func (h *Handler) FindByID(c echo.Context) error {
 }
 
 func (h *Handler) FindAll(c echo.Context) error {
-	limit, _ := strconv.Atoi(c.QueryParam("limit"))
-	offset, _ := strconv.Atoi(c.QueryParam("offset"))
+	var req dto.ListRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	limit, offset := req.Limit, req.Offset
 
-	products, total, err := h.service.FindAll(c.Request().Context(), limit, offset)
+	products, total, err := h.service.FindAll(c.Request().Context(), req.StoreID, limit, offset)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
@@ -88,7 +94,7 @@ func (h *Handler) Update(c echo.Context) error {
 
 	product, err := h.service.Update(c.Request().Context(), id, &req)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return echo.NewHTTPError(updateStatus(err), err.Error())
 	}
 
 	return c.JSON(http.StatusOK, ToProductResponse(product))
@@ -103,3 +109,10 @@ func (h *Handler) Delete(c echo.Context) error {
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+func updateStatus(err error) int {
+	if errors.Is(err, ErrStoreChange) {
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
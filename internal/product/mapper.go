@@ -1,6 +1,8 @@
 package product
 
 import (
+	"time"
+
 	"sumunar-pos-core/internal/base"
 	"sumunar-pos-core/internal/product/dto"
 
@@ -8,21 +10,28 @@ import (
 )
 
 func ToProductModel(req *dto.ProductRequest, createdBy string) *Product {
-	return &Product{
+	product := &Product{
 		ID:      uuid.New().String(),
 		Name:    req.Name,
 		StoreID: req.StoreID,
 		BaseModel: base.BaseModel{
+			IsActive:  true,
+			CreatedAt: time.Now(),
 			CreatedBy: createdBy,
 		},
 	}
+	if req.IsActive != nil {
+		product.IsActive = *req.IsActive
+	}
+	return product
 }
 
 func ToProductResponse(product *Product) *dto.ProductResponse {
 	return &dto.ProductResponse{
-		ID:      product.ID,
-		Name:    product.Name,
-		StoreID: product.StoreID,
+		ID:       product.ID,
+		Name:     product.Name,
+		StoreID:  product.StoreID,
+		IsActive: product.IsActive,
 	}
 }
 
@@ -8,7 +8,7 @@ import (
 type ProductRepository interface {
 	Create(ctx context.Context, product *Product) error
 	FindByID(ctx context.Context, id string) (*Product, error)
-	FindAll(ctx context.Context, limit, offset int) ([]*Product, int, error)
+	FindAll(ctx context.Context, storeID string, limit, offset int) ([]*Product, int, error)
 	Update(ctx context.Context, product *Product) error
 	Delete(ctx context.Context, id string) error
 }
@@ -58,9 +58,14 @@ func (r *productRepo) FindByID(ctx context.Context, id string) (*Product, error)
 	return &product, nil
 }
 
-func (r *productRepo) FindAll(ctx context.Context, limit, offset int) ([]*Product, int, error) {
-	query := `SELECT id, name, store_id, is_active, created_at, created_by, updated_at, updated_by FROM products LIMIT $1 OFFSET $2`
-	rows, err := r.db.Query(ctx, query, limit, offset)
+func (r *productRepo) FindAll(ctx context.Context, storeID string, limit, offset int) ([]*Product, int, error) {
+	query := `
+		SELECT id, name, store_id, is_active, created_at, created_by, updated_at, updated_by FROM products
+		WHERE ($1::uuid IS NULL OR store_id = $1)
+		ORDER BY name
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Query(ctx, query, db.NullID(storeID), limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -86,7 +91,7 @@ func (r *productRepo) FindAll(ctx context.Context, limit, offset int) ([]*Produc
 
 	// total count
 	var total int
-	err = r.db.QueryRow(ctx, `SELECT COUNT(*) FROM products`).Scan(&total)
+	err = r.db.QueryRow(ctx, `SELECT COUNT(*) FROM products WHERE ($1::uuid IS NULL OR store_id = $1)`, db.NullID(storeID)).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -12,7 +12,7 @@ import (
 type ProductService interface {
 	Create(ctx context.Context, req *dto.ProductRequest) (*Product, error)
 	FindByID(ctx context.Context, id string) (*Product, error)
-	FindAll(ctx context.Context, limit, offset int) ([]*Product, int, error)
+	FindAll(ctx context.Context, storeID string, limit, offset int) ([]*Product, int, error)
 	Update(ctx context.Context, id string, req *dto.ProductRequest) (*Product, error)
 	Delete(ctx context.Context, id string) error
 }
@@ -45,8 +45,8 @@ func (s *service) FindByID(ctx context.Context, id string) (*Product, error) {
 	return s.repo.FindByID(ctx, id)
 }
 
-func (s *service) FindAll(ctx context.Context, limit, offset int) ([]*Product, int, error) {
-	return s.repo.FindAll(ctx, limit, offset)
+func (s *service) FindAll(ctx context.Context, storeID string, limit, offset int) ([]*Product, int, error) {
+	return s.repo.FindAll(ctx, storeID, limit, offset)
 }
 
 func (s *service) Update(ctx context.Context, id string, req *dto.ProductRequest) (*Product, error) {
@@ -60,8 +60,15 @@ func (s *service) Update(ctx context.Context, id string, req *dto.ProductRequest
 		return nil, err
 	}
 
+	// product service ikut toko produk dan jenis layanannya, jadi tokonya tidak bisa dipindah
+	if req.StoreID != product.StoreID {
+		return nil, ErrStoreChange
+	}
+
 	product.Name = req.Name
-	product.StoreID = req.StoreID
+	if req.IsActive != nil {
+		product.IsActive = *req.IsActive
+	}
 	product.UpdatedAt = time.Now()
 	product.UpdatedBy = userID
 
@@ -0,0 +1,82 @@
+package productservice
+
+import (
+	"slices"
+	"time"
+
+	"sumunar-pos-core/pkg/money"
+)
+
+// Jenis penyesuaian harga massal.
+const (
+	AdjustPercent = "percent"
+	AdjustFixed   = "fixed"
+)
+
+// PriceAdjustment is a bulk price change over the product services matching a filter. Applying it
+// writes one price version per changed line, all with the same effective date; the adjustment itself
+// is kept as the audit entry.
+type PriceAdjustment struct {
+	ID            string
+	StoreID       string // kosong = harga umum semua toko
+	ServiceTypeID string // filter, kosong = semua
+	ProductID     string // filter, kosong = semua
+	Type          string // percent, fixed
+	Percent       float64
+	Amount        money.Amount // untuk fixed, negatif = turun harga
+	RoundTo       money.Amount // misal 500; 0 = tidak dibulatkan
+	RoundingMode  money.RoundingMode
+	EffectiveFrom time.Time
+	ItemCount     int // jumlah product service yang harganya berubah
+	Lines         []*PriceAdjustmentLine
+	CreatedAt     time.Time
+	CreatedBy     string
+}
+
+// PriceAdjustmentLine is the old and new price of one product service, with its minimum charge and tiers.
+type PriceAdjustmentLine struct {
+	ID               string
+	AdjustmentID     string
+	ProductServiceID string
+	ProductName      string
+	ServiceTypeName  string
+	Unit             string
+	OldPrice         money.Amount
+	NewPrice         money.Amount
+	OldMinCharge     money.Amount
+	NewMinCharge     money.Amount
+	OldPriceTiers    []PriceTier
+	NewPriceTiers    []PriceTier
+}
+
+// Changed reports whether the adjustment changes anything on the line after rounding.
+func (l *PriceAdjustmentLine) Changed() bool {
+	return l.NewPrice != l.OldPrice || l.NewMinCharge != l.OldMinCharge || !slices.Equal(l.NewPriceTiers, l.OldPriceTiers)
+}
+
+// NewPrice applies the adjustment to a price and rounds the result; a price never goes below zero.
+func (a *PriceAdjustment) NewPrice(old money.Amount) money.Amount {
+	price := old + a.Amount
+	if a.Type == AdjustPercent {
+		price = old + old.Percent(a.Percent, money.RoundHalfUp)
+	}
+	price = price.Round(a.RoundTo, a.RoundingMode)
+	return money.Max(price, 0)
+}
+
+// NewMinCharge adjusts a minimum charge the same way as a price; zero means no minimum and stays zero.
+func (a *PriceAdjustment) NewMinCharge(old money.Amount) money.Amount {
+	if old == 0 {
+		return 0
+	}
+	return a.NewPrice(old)
+}
+
+// NewTiers adjusts the price of every tier the same way as the base price.
+func (a *PriceAdjustment) NewTiers(old []PriceTier) []PriceTier {
+	tiers := make([]PriceTier, 0, len(old))
+	for _, t := range old {
+		tiers = append(tiers, PriceTier{MinQuantity: t.MinQuantity, Price: a.NewPrice(t.Price)})
+	}
+	return tiers
+}
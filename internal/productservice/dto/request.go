@@ -1,8 +1,53 @@
 package dto
 
+import "sumunar-pos-core/pkg/money"
+
 type ProductServiceRequest struct {
-	ProductID     string  `json:"product_id" validate:"required"`
-	ServiceTypeID string  `json:"service_type_id" validate:"required"`
-	Unit          string  `json:"unit" validate:"required"`
-	Price         float64 `json:"price" validate:"required"`
+	ProductID     string       `json:"product_id" validate:"required,uuid"`
+	ServiceTypeID string       `json:"service_type_id" validate:"required,uuid"`
+	Unit          string       `json:"unit" validate:"required"`
+	Price         money.Amount `json:"price" validate:"required"`
+	MinQuantity   float64      `json:"min_quantity" validate:"gte=0"`  // misal minimal 3 kg
+	MinCharge     money.Amount `json:"min_charge" validate:"gte=0"`    // tagihan minimum per item
+	QuantityStep  float64      `json:"quantity_step" validate:"gte=0"` // misal 0.5: 2.3 kg ditagih 2.5 kg
+	PriceTiers    []PriceTier  `json:"price_tiers" validate:"omitempty,dive"`
+	IsActive      *bool        `json:"is_active"`
+}
+
+// PriceTier: mulai MinQuantity, seluruh kuantitas dihitung dengan Price.
+type PriceTier struct {
+	MinQuantity float64      `json:"min_quantity" validate:"gt=0"`
+	Price       money.Amount `json:"price" validate:"gte=0"`
+}
+
+type QuoteRequest struct {
+	StoreID  string  `json:"store_id" validate:"omitempty,uuid"` // harga yang berlaku di toko ini, kosong = harga umum
+	Quantity float64 `json:"quantity" validate:"gt=0"`
+}
+
+// PriceRequest schedules a price change.
+type PriceRequest struct {
+	StoreID       string       `json:"store_id" validate:"omitempty,uuid"` // kosong = semua toko
+	Price         money.Amount `json:"price" validate:"gte=0"`
+	EffectiveFrom string       `json:"effective_from" validate:"required"` // ISO8601 atau YYYY-MM-DD (mulai pukul 00:00)
+}
+
+// PriceAdjustmentRequest changes the price of every product service matching the filter at once.
+type PriceAdjustmentRequest struct {
+	StoreID       string       `json:"store_id" validate:"omitempty,uuid"` // kosong = harga umum semua toko
+	ServiceTypeID string       `json:"service_type_id" validate:"omitempty,uuid"`
+	ProductID     string       `json:"product_id" validate:"omitempty,uuid"`
+	Type          string       `json:"type" validate:"required,oneof=percent fixed"`
+	Percent       float64      `json:"percent" validate:"gte=-100"` // untuk percent, misal 10 = naik 10%
+	Amount        money.Amount `json:"amount"`                      // untuk fixed, negatif = turun harga
+	RoundTo       money.Amount `json:"round_to" validate:"gte=0"`   // misal 500
+	RoundingMode  string       `json:"rounding_mode" validate:"omitempty,oneof=half_up down up"`
+	EffectiveFrom string       `json:"effective_from"` // ISO8601 atau YYYY-MM-DD, kosong = langsung berlaku
+}
+
+// ListRequest is the query string of the list endpoints.
+type ListRequest struct {
+	StoreID string `query:"store_id" validate:"omitempty,uuid"`
+	Limit   int    `query:"limit"`
+	Offset  int    `query:"offset"`
 }
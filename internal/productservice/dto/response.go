@@ -1,11 +1,75 @@
 package dto
 
+import "sumunar-pos-core/pkg/money"
+
 type ProductServiceResponse struct {
-	ID            string  `json:"id"`
-	ProductID     string  `json:"product_id"`
-	ServiceTypeID string  `json:"service_type_id"`
-	Unit          string  `json:"unit"`
-	Price         float64 `json:"price"`
+	ID            string       `json:"id"`
+	ProductID     string       `json:"product_id"`
+	StoreID       string       `json:"store_id"`
+	ServiceTypeID string       `json:"service_type_id"`
+	Unit          string       `json:"unit"`
+	Price         money.Amount `json:"price"`
+	MinQuantity   float64      `json:"min_quantity"`
+	MinCharge     money.Amount `json:"min_charge"`
+	QuantityStep  float64      `json:"quantity_step"`
+	PriceTiers    []PriceTier  `json:"price_tiers"`
+	IsActive      bool         `json:"is_active"`
+}
+
+type QuoteResponse struct {
+	ProductServiceID string       `json:"product_service_id"`
+	Unit             string       `json:"unit"`
+	Quantity         float64      `json:"quantity"`
+	ChargedQuantity  float64      `json:"charged_quantity"` // setelah pembulatan dan minimum kuantitas
+	UnitPrice        money.Amount `json:"unit_price"`
+	Amount           money.Amount `json:"amount"`
+	MinChargeApplied bool         `json:"min_charge_applied"`
+}
+
+type PriceResponse struct {
+	ID               string        `json:"id"`
+	ProductServiceID string        `json:"product_service_id"`
+	StoreID          string        `json:"store_id,omitempty"` // kosong = semua toko
+	Price            money.Amount  `json:"price"`
+	MinCharge        *money.Amount `json:"min_charge,omitempty"`  // kosong = ikut katalog
+	PriceTiers       []PriceTier   `json:"price_tiers,omitempty"` // kosong = ikut katalog
+	EffectiveFrom    string        `json:"effective_from"`        // ISO8601
+	Status           string        `json:"status"`                // scheduled, effective, cancelled
+	CancelledAt      string        `json:"cancelled_at,omitempty"`
+	CancelledBy      string        `json:"cancelled_by,omitempty"`
+	CreatedAt        string        `json:"created_at"`
+	CreatedBy        string        `json:"created_by"`
+}
+
+type PriceAdjustmentResponse struct {
+	ID            string                        `json:"id,omitempty"` // kosong pada preview
+	StoreID       string                        `json:"store_id,omitempty"`
+	ServiceTypeID string                        `json:"service_type_id,omitempty"`
+	ProductID     string                        `json:"product_id,omitempty"`
+	Type          string                        `json:"type"`
+	Percent       float64                       `json:"percent"`
+	Amount        money.Amount                  `json:"amount"`
+	RoundTo       money.Amount                  `json:"round_to"`
+	RoundingMode  string                        `json:"rounding_mode"`
+	EffectiveFrom string                        `json:"effective_from"`
+	ItemCount     int                           `json:"item_count"`
+	Lines         []PriceAdjustmentLineResponse `json:"lines,omitempty"`
+	CreatedAt     string                        `json:"created_at,omitempty"`
+	CreatedBy     string                        `json:"created_by,omitempty"`
+}
+
+type PriceAdjustmentLineResponse struct {
+	ProductServiceID string       `json:"product_service_id"`
+	ProductName      string       `json:"product_name"`
+	ServiceTypeName  string       `json:"service_type_name"`
+	Unit             string       `json:"unit"`
+	OldPrice         money.Amount `json:"old_price"`
+	NewPrice         money.Amount `json:"new_price"`
+	Difference       money.Amount `json:"difference"`
+	OldMinCharge     money.Amount `json:"old_min_charge"`
+	NewMinCharge     money.Amount `json:"new_min_charge"`
+	OldPriceTiers    []PriceTier  `json:"old_price_tiers"`
+	NewPriceTiers    []PriceTier  `json:"new_price_tiers"`
 }
 
 type ErrorResponse struct {
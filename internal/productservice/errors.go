@@ -0,0 +1,15 @@
+package productservice
+
+import "errors"
+
+var (
+	ErrPriceNotFound         = errors.New("price change not found")
+	ErrInvalidEffectiveDate  = errors.New("effective_from must be an ISO8601 time or YYYY-MM-DD date, not in the past")
+	ErrPriceAlreadyEffective = errors.New("price change is already in effect and cannot be cancelled")
+	ErrPriceAlreadyCancelled = errors.New("price change is already cancelled")
+	ErrAdjustmentNotFound    = errors.New("price adjustment not found")
+	ErrNothingToAdjust       = errors.New("no product service price changes with this filter and adjustment")
+	ErrProductNotFound       = errors.New("product not found")
+	ErrServiceTypeNotFound   = errors.New("service type not found")
+	ErrStoreMismatch         = errors.New("product, service type and price must belong to the same store")
+)
@@ -1,11 +1,13 @@
 package productservice
 
 import (
+	"errors"
 	"net/http"
-	"strconv"
+	"time"
 
 	"sumunar-pos-core/internal/productservice/dto"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
 )
 
@@ -40,7 +42,7 @@ func (h *Handler) Create(c echo.Context) error {
 
 	productService, err := h.service.Create(c.Request().Context(), &req)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return echo.NewHTTPError(errorStatus(err), err.Error())
 	}
 
 	return c.JSON(http.StatusCreated, ToProductServiceResponse(productService))
@@ -58,10 +60,16 @@ func (h *Handler) FindByID(c echo.Context) error {
 }
 
 func (h *Handler) FindAll(c echo.Context) error {
-	limit, _ := strconv.Atoi(c.QueryParam("limit"))
-	offset, _ := strconv.Atoi(c.QueryParam("offset"))
+	var req dto.ListRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	limit, offset := req.Limit, req.Offset
 
-	productServices, total, err := h.service.FindAll(c.Request().Context(), limit, offset)
+	productServices, total, err := h.service.FindAll(c.Request().Context(), req.StoreID, limit, offset)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
@@ -88,7 +96,7 @@ func (h *Handler) Update(c echo.Context) error {
 
 	service, err := h.service.Update(c.Request().Context(), id, &req)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return echo.NewHTTPError(errorStatus(err), err.Error())
 	}
 
 	return c.JSON(http.StatusOK, ToProductServiceResponse(service))
@@ -103,3 +111,200 @@ func (h *Handler) Delete(c echo.Context) error {
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+// Quote godoc
+// @Summary Preview the price of a quantity
+// @Tags productservice
+// @Accept json
+// @Produce json
+// @Param id path string true "Product service ID"
+// @Param request body dto.QuoteRequest true "Quantity"
+// @Success 200 {object} dto.QuoteResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /productservice/{id}/quote [post]
+func (h *Handler) Quote(c echo.Context) error {
+	var req dto.QuoteRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	productService, quote, err := h.service.Quote(c.Request().Context(), c.Param("id"), req.StoreID, req.Quantity)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToQuoteResponse(productService, quote))
+}
+
+// SchedulePrice godoc
+// @Summary Schedule a price change
+// @Tags productservice
+// @Accept json
+// @Produce json
+// @Param id path string true "Product service ID"
+// @Param request body dto.PriceRequest true "New price and effective date"
+// @Success 201 {object} dto.PriceResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /productservice/{id}/prices [post]
+func (h *Handler) SchedulePrice(c echo.Context) error {
+	var req dto.PriceRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	price, err := h.service.SchedulePrice(c.Request().Context(), c.Param("id"), &req)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, ToPriceResponses([]*Price{price}, time.Now())[0])
+}
+
+// Prices lists the price history of a product service, upcoming changes included.
+func (h *Handler) Prices(c echo.Context) error {
+	var req dto.ListRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if req.Limit <= 0 {
+		req.Limit = 50
+	}
+
+	prices, total, err := h.service.Prices(c.Request().Context(), c.Param("id"), req.StoreID, req.Limit, req.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data":   ToPriceResponses(prices, time.Now()),
+		"total":  total,
+		"limit":  req.Limit,
+		"offset": req.Offset,
+	})
+}
+
+func (h *Handler) CancelPrice(c echo.Context) error {
+	price, err := h.service.CancelPrice(c.Request().Context(), c.Param("id"), c.Param("priceId"))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToPriceResponses([]*Price{price}, time.Now())[0])
+}
+
+// PreviewAdjustment godoc
+// @Summary Preview a bulk price adjustment
+// @Tags productservice
+// @Accept json
+// @Produce json
+// @Param request body dto.PriceAdjustmentRequest true "Filter and adjustment"
+// @Success 200 {object} dto.PriceAdjustmentResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /productservice/price-adjustments/preview [post]
+func (h *Handler) PreviewAdjustment(c echo.Context) error {
+	var req dto.PriceAdjustmentRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	adj, err := h.service.PreviewAdjustment(c.Request().Context(), &req)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToPriceAdjustmentResponse(adj, false))
+}
+
+// ApplyAdjustment godoc
+// @Summary Apply a bulk price adjustment
+// @Tags productservice
+// @Accept json
+// @Produce json
+// @Param request body dto.PriceAdjustmentRequest true "Filter and adjustment"
+// @Success 201 {object} dto.PriceAdjustmentResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /productservice/price-adjustments [post]
+func (h *Handler) ApplyAdjustment(c echo.Context) error {
+	var req dto.PriceAdjustmentRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	adj, err := h.service.ApplyAdjustment(c.Request().Context(), &req)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, ToPriceAdjustmentResponse(adj, true))
+}
+
+func (h *Handler) FindAdjustmentByID(c echo.Context) error {
+	adj, err := h.service.FindAdjustmentByID(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToPriceAdjustmentResponse(adj, true))
+}
+
+func (h *Handler) FindAdjustments(c echo.Context) error {
+	var req dto.ListRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	limit, offset := req.Limit, req.Offset
+	if limit <= 0 {
+		limit = 50
+	}
+
+	adjustments, total, err := h.service.FindAdjustments(c.Request().Context(), req.StoreID, limit, offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data":   ToPriceAdjustmentListResponse(adjustments),
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrPriceNotFound), errors.Is(err, ErrAdjustmentNotFound), errors.Is(err, pgx.ErrNoRows):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidEffectiveDate), errors.Is(err, ErrProductNotFound), errors.Is(err, ErrServiceTypeNotFound),
+		errors.Is(err, ErrStoreMismatch):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrPriceAlreadyEffective), errors.Is(err, ErrNothingToAdjust):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ErrPriceAlreadyCancelled):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -1,33 +1,71 @@
 package productservice
 
 import (
+	"time"
+
 	"sumunar-pos-core/internal/base"
 	"sumunar-pos-core/internal/productservice/dto"
+	"sumunar-pos-core/pkg/money"
 
 	"github.com/google/uuid"
 )
 
 func ToProductServiceModel(req *dto.ProductServiceRequest, createdBy string) *ProductService {
-	return &ProductService{
+	productService := &ProductService{
 		ID:            uuid.New().String(),
 		ProductID:     req.ProductID,
 		ServiceTypeID: req.ServiceTypeID,
 		Unit:          req.Unit,
 		Price:         req.Price,
+		PricingRule:   ToPricingRule(req),
 		BaseModel: base.BaseModel{
+			IsActive:  true,
+			CreatedAt: time.Now(),
 			CreatedBy: createdBy,
 		},
 	}
+	if req.IsActive != nil {
+		productService.IsActive = *req.IsActive
+	}
+	return productService
+}
+
+func ToPricingRule(req *dto.ProductServiceRequest) PricingRule {
+	rule := PricingRule{
+		MinQuantity:  req.MinQuantity,
+		MinCharge:    req.MinCharge,
+		QuantityStep: req.QuantityStep,
+	}
+	for _, t := range req.PriceTiers {
+		rule.PriceTiers = append(rule.PriceTiers, PriceTier(t))
+	}
+	rule.Normalize()
+	return rule
 }
 
 func ToProductServiceResponse(service *ProductService) *dto.ProductServiceResponse {
-	return &dto.ProductServiceResponse{
+	res := &dto.ProductServiceResponse{
 		ID:            service.ID,
 		ProductID:     service.ProductID,
+		StoreID:       service.StoreID,
 		ServiceTypeID: service.ServiceTypeID,
 		Unit:          service.Unit,
 		Price:         service.Price,
+		MinQuantity:   service.MinQuantity,
+		MinCharge:     service.MinCharge,
+		QuantityStep:  service.QuantityStep,
+		PriceTiers:    ToPriceTierResponses(service.PriceTiers),
+		IsActive:      service.IsActive,
 	}
+	return res
+}
+
+func ToPriceTierResponses(tiers []PriceTier) []dto.PriceTier {
+	res := make([]dto.PriceTier, 0, len(tiers))
+	for _, t := range tiers {
+		res = append(res, dto.PriceTier(t))
+	}
+	return res
 }
 
 func ToProductServiceListResponse(services []*ProductService) []*dto.ProductServiceResponse {
@@ -37,3 +75,175 @@ func ToProductServiceListResponse(services []*ProductService) []*dto.ProductServ
 	}
 	return res
 }
+
+func ToQuoteResponse(service *ProductService, q Quote) *dto.QuoteResponse {
+	return &dto.QuoteResponse{
+		ProductServiceID: service.ID,
+		Unit:             service.Unit,
+		Quantity:         q.Quantity,
+		ChargedQuantity:  q.ChargedQuantity,
+		UnitPrice:        q.UnitPrice,
+		Amount:           q.Amount,
+		MinChargeApplied: q.MinChargeApplied,
+	}
+}
+
+// parseEffectiveFrom: tanggal saja berarti mulai pukul 00:00 di zona waktu loc, tanggal hari ini atau kosong
+// berarti langsung berlaku. Waktu yang sudah lewat ditolak supaya riwayat harga tidak berubah.
+func parseEffectiveFrom(value string, now time.Time, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		return now, nil
+	}
+	effective, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		d, derr := time.ParseInLocation("2006-01-02", value, loc)
+		if derr != nil {
+			return time.Time{}, ErrInvalidEffectiveDate
+		}
+		effective = d
+		if d.Format("2006-01-02") == now.In(loc).Format("2006-01-02") {
+			effective = now
+		}
+	}
+	if effective.Before(now.Add(-time.Minute)) {
+		return time.Time{}, ErrInvalidEffectiveDate
+	}
+	return effective, nil
+}
+
+// ToPriceModel builds a scheduled price version.
+func ToPriceModel(productServiceID string, req *dto.PriceRequest, createdBy string, now time.Time, loc *time.Location) (*Price, error) {
+	effective, err := parseEffectiveFrom(req.EffectiveFrom, now, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Price{
+		ID:               uuid.New().String(),
+		ProductServiceID: productServiceID,
+		StoreID:          req.StoreID,
+		Price:            req.Price,
+		EffectiveFrom:    effective,
+		CreatedAt:        now,
+		CreatedBy:        createdBy,
+	}, nil
+}
+
+func ToPriceResponses(prices []*Price, at time.Time) []dto.PriceResponse {
+	res := make([]dto.PriceResponse, 0, len(prices))
+	for _, p := range prices {
+		r := dto.PriceResponse{
+			ID:               p.ID,
+			ProductServiceID: p.ProductServiceID,
+			StoreID:          p.StoreID,
+			Price:            p.Price,
+			MinCharge:        p.MinCharge,
+			EffectiveFrom:    p.EffectiveFrom.Format(time.RFC3339),
+			Status:           p.Status(at),
+			CancelledBy:      p.CancelledBy,
+			CreatedAt:        p.CreatedAt.Format(time.RFC3339),
+			CreatedBy:        p.CreatedBy,
+		}
+		if p.PriceTiers != nil {
+			r.PriceTiers = ToPriceTierResponses(p.PriceTiers)
+		}
+		if p.CancelledAt != nil {
+			r.CancelledAt = p.CancelledAt.Format(time.RFC3339)
+		}
+		res = append(res, r)
+	}
+	return res
+}
+
+func ToPriceAdjustmentModel(req *dto.PriceAdjustmentRequest, createdBy string, now time.Time, loc *time.Location) (*PriceAdjustment, error) {
+	effective, err := parseEffectiveFrom(req.EffectiveFrom, now, loc)
+	if err != nil {
+		return nil, err
+	}
+	mode := money.RoundingMode(req.RoundingMode)
+	if mode == "" {
+		mode = money.RoundHalfUp
+	}
+
+	adj := &PriceAdjustment{
+		ID:            uuid.New().String(),
+		StoreID:       req.StoreID,
+		ServiceTypeID: req.ServiceTypeID,
+		ProductID:     req.ProductID,
+		Type:          req.Type,
+		RoundTo:       req.RoundTo,
+		RoundingMode:  mode,
+		EffectiveFrom: effective,
+		CreatedAt:     now,
+		CreatedBy:     createdBy,
+	}
+	if req.Type == AdjustPercent {
+		adj.Percent = req.Percent
+	} else {
+		adj.Amount = req.Amount
+	}
+	return adj, nil
+}
+
+// ToPriceAdjustmentLine adjusts the price, minimum charge and tiers currently valid for d.
+func ToPriceAdjustmentLine(adj *PriceAdjustment, d *ProductServiceDetail, current *ProductService) *PriceAdjustmentLine {
+	return &PriceAdjustmentLine{
+		ID:               uuid.New().String(),
+		AdjustmentID:     adj.ID,
+		ProductServiceID: d.ID,
+		ProductName:      d.ProductName,
+		ServiceTypeName:  d.ServiceTypeName,
+		Unit:             d.Unit,
+		OldPrice:         current.Price,
+		NewPrice:         adj.NewPrice(current.Price),
+		OldMinCharge:     current.MinCharge,
+		NewMinCharge:     adj.NewMinCharge(current.MinCharge),
+		OldPriceTiers:    append([]PriceTier{}, current.PriceTiers...),
+		NewPriceTiers:    adj.NewTiers(current.PriceTiers),
+	}
+}
+
+// ToPriceAdjustmentResponse; preview leaves the id and audit fields out since nothing was saved.
+func ToPriceAdjustmentResponse(adj *PriceAdjustment, saved bool) *dto.PriceAdjustmentResponse {
+	res := &dto.PriceAdjustmentResponse{
+		StoreID:       adj.StoreID,
+		ServiceTypeID: adj.ServiceTypeID,
+		ProductID:     adj.ProductID,
+		Type:          adj.Type,
+		Percent:       adj.Percent,
+		Amount:        adj.Amount,
+		RoundTo:       adj.RoundTo,
+		RoundingMode:  string(adj.RoundingMode),
+		EffectiveFrom: adj.EffectiveFrom.Format(time.RFC3339),
+		ItemCount:     adj.ItemCount,
+	}
+	if saved {
+		res.ID = adj.ID
+		res.CreatedAt = adj.CreatedAt.Format(time.RFC3339)
+		res.CreatedBy = adj.CreatedBy
+	}
+	for _, l := range adj.Lines {
+		res.Lines = append(res.Lines, dto.PriceAdjustmentLineResponse{
+			ProductServiceID: l.ProductServiceID,
+			ProductName:      l.ProductName,
+			ServiceTypeName:  l.ServiceTypeName,
+			Unit:             l.Unit,
+			OldPrice:         l.OldPrice,
+			NewPrice:         l.NewPrice,
+			Difference:       l.NewPrice - l.OldPrice,
+			OldMinCharge:     l.OldMinCharge,
+			NewMinCharge:     l.NewMinCharge,
+			OldPriceTiers:    ToPriceTierResponses(l.OldPriceTiers),
+			NewPriceTiers:    ToPriceTierResponses(l.NewPriceTiers),
+		})
+	}
+	return res
+}
+
+func ToPriceAdjustmentListResponse(adjustments []*PriceAdjustment) []*dto.PriceAdjustmentResponse {
+	res := make([]*dto.PriceAdjustmentResponse, 0, len(adjustments))
+	for _, a := range adjustments {
+		res = append(res, ToPriceAdjustmentResponse(a, true))
+	}
+	return res
+}
@@ -2,13 +2,30 @@ package productservice
 
 import (
 	"sumunar-pos-core/internal/base"
+	"sumunar-pos-core/pkg/money"
 )
 
 type ProductService struct {
-	ID            string  `db:"id"`
-	ProductID     string  `db:"product_id"`
-	ServiceTypeID string  `db:"service_type_id"`
-	Unit          string  `db:"unit"`  // "kg", "pcs", "m2", dll
-	Price         float64 `db:"price"` // harga per unit
+	ID            string       `db:"id"`
+	ProductID     string       `db:"product_id"`
+	StoreID       string       `db:"store_id"` // toko produknya
+	ServiceTypeID string       `db:"service_type_id"`
+	Unit          string       `db:"unit"`  // "kg", "pcs", "m2", dll
+	Price         money.Amount `db:"price"` // harga per unit
+	PricingRule
 	base.BaseModel
 }
+
+// ProductServiceDetail adds the product and service type names, e.g. for snapshotting on an order.
+type ProductServiceDetail struct {
+	ProductService
+	ProductName       string `db:"product_name"`
+	ServiceTypeName   string `db:"service_type_name"`
+	ProductActive     bool   `db:"product_active"`
+	ServiceTypeActive bool   `db:"service_type_active"`
+}
+
+// Sellable: product service, produk, dan jenis layanannya semuanya aktif.
+func (d *ProductServiceDetail) Sellable() bool {
+	return d.IsActive && d.ProductActive && d.ServiceTypeActive
+}
@@ -0,0 +1,57 @@
+package productservice
+
+import (
+	"time"
+
+	"sumunar-pos-core/pkg/money"
+)
+
+// Status versi harga.
+const (
+	PriceScheduled = "scheduled"
+	PriceEffective = "effective"
+	PriceCancelled = "cancelled"
+)
+
+// Price is one version of a product service's price list, valid from EffectiveFrom until the next
+// version. At the same EffectiveFrom a store's own version wins over one for all stores (StoreID kosong);
+// without any version the catalog Price applies. A version from a bulk adjustment also carries the
+// adjusted minimum charge and tiers.
+type Price struct {
+	ID               string
+	ProductServiceID string
+	StoreID          string // kosong = semua toko
+	Price            money.Amount
+	MinCharge        *money.Amount // nil = ikut tagihan minimum katalog
+	PriceTiers       []PriceTier   // nil = ikut tier katalog
+	EffectiveFrom    time.Time
+	CancelledAt      *time.Time
+	CancelledBy      string
+	AdjustmentID     string // diisi jika berasal dari penyesuaian harga massal
+	CreatedAt        time.Time
+	CreatedBy        string
+}
+
+// Status reports whether the version is still upcoming at the given time.
+func (p *Price) Status(at time.Time) string {
+	switch {
+	case p.CancelledAt != nil:
+		return PriceCancelled
+	case p.EffectiveFrom.After(at):
+		return PriceScheduled
+	default:
+		return PriceEffective
+	}
+}
+
+// ApplyTo prices the product service with this version: its price, and its minimum charge and tiers
+// when it has them.
+func (p *Price) ApplyTo(ps *ProductService) {
+	ps.Price = p.Price
+	if p.MinCharge != nil {
+		ps.MinCharge = *p.MinCharge
+	}
+	if p.PriceTiers != nil {
+		ps.PriceTiers = p.PriceTiers
+	}
+}
@@ -0,0 +1,86 @@
+package productservice
+
+import (
+	"math"
+	"sort"
+
+	"sumunar-pos-core/pkg/money"
+)
+
+// PriceTier is a price break: from MinQuantity up, every unit is charged at Price.
+type PriceTier struct {
+	MinQuantity float64      `json:"min_quantity"`
+	Price       money.Amount `json:"price"`
+}
+
+// PricingRule is how a quantity is charged, on top of the flat price per unit.
+type PricingRule struct {
+	MinQuantity  float64      `json:"min_quantity"`  // kuantitas minimum yang ditagih, misal 3 kg; 0 = tanpa minimum
+	MinCharge    money.Amount `json:"min_charge"`    // tagihan minimum per item; 0 = tanpa minimum
+	QuantityStep float64      `json:"quantity_step"` // kuantitas dibulatkan ke atas ke kelipatan ini, misal 0.5; 0 = tidak dibulatkan
+	PriceTiers   []PriceTier  `json:"price_tiers"`   // urut naik menurut MinQuantity
+}
+
+// Quote is the price of a quantity of a product service.
+type Quote struct {
+	Quantity         float64      // kuantitas yang ditimbang/dihitung
+	ChargedQuantity  float64      // kuantitas yang ditagih: setelah pembulatan dan minimum kuantitas
+	UnitPrice        money.Amount // harga per unit sesuai tier
+	Amount           money.Amount // ChargedQuantity x UnitPrice, minimal MinCharge
+	MinChargeApplied bool
+}
+
+// Normalize sorts the tiers and drops the ones that cannot apply.
+func (r *PricingRule) Normalize() {
+	tiers := make([]PriceTier, 0, len(r.PriceTiers))
+	for _, t := range r.PriceTiers {
+		if t.MinQuantity > 0 {
+			tiers = append(tiers, t)
+		}
+	}
+	sort.SliceStable(tiers, func(i, j int) bool { return tiers[i].MinQuantity < tiers[j].MinQuantity })
+	r.PriceTiers = tiers
+}
+
+// ChargedQuantity rounds qty up to the quantity step and raises it to the minimum quantity.
+func (r *PricingRule) ChargedQuantity(qty float64) float64 {
+	if qty <= 0 {
+		return 0
+	}
+	if r.QuantityStep > 0 {
+		// toleransi kecil supaya 1.5 / 0.5 tidak jadi 3.0000001 lalu dibulatkan ke 3.5
+		qty = math.Ceil(qty/r.QuantityStep-1e-9) * r.QuantityStep
+	}
+	qty = math.Round(qty*1000) / 1000
+	return math.Max(qty, r.MinQuantity)
+}
+
+// UnitPrice returns the price per unit for the charged quantity: the highest tier reached, else base.
+func (r *PricingRule) UnitPrice(base money.Amount, charged float64) money.Amount {
+	price := base
+	for _, t := range r.PriceTiers {
+		if charged < t.MinQuantity {
+			break
+		}
+		price = t.Price
+	}
+	return price
+}
+
+// Charge prices qty at unitPrice, applying the quantity rules and the minimum charge. The unit price
+// is passed in so a manual price override on an order still follows the quantity rules.
+func (r *PricingRule) Charge(qty float64, unitPrice money.Amount) Quote {
+	q := Quote{Quantity: qty, ChargedQuantity: r.ChargedQuantity(qty), UnitPrice: unitPrice}
+	q.Amount = unitPrice.Mul(q.ChargedQuantity, money.RoundHalfUp)
+	if qty > 0 && q.Amount < r.MinCharge {
+		q.Amount = r.MinCharge
+		q.MinChargeApplied = true
+	}
+	return q
+}
+
+// Quote prices qty with the catalog price and the pricing rules of the product service.
+func (ps *ProductService) Quote(qty float64) Quote {
+	charged := ps.ChargedQuantity(qty)
+	return ps.Charge(qty, ps.UnitPrice(ps.Price, charged))
+}
@@ -0,0 +1,96 @@
+package productservice
+
+import (
+	"testing"
+
+	"sumunar-pos-core/pkg/money"
+)
+
+func TestChargedQuantity(t *testing.T) {
+	tests := []struct {
+		rule PricingRule
+		qty  float64
+		want float64
+	}{
+		{PricingRule{}, 2.3333, 2.333},
+		{PricingRule{}, 0, 0},
+		{PricingRule{}, -1, 0},
+		{PricingRule{QuantityStep: 0.5}, 1.5, 1.5}, // pas kelipatan, tidak naik ke 2
+		{PricingRule{QuantityStep: 0.5}, 1.51, 2},
+		{PricingRule{QuantityStep: 0.5}, 2.25, 2.5},
+		{PricingRule{QuantityStep: 0.1}, 0.3, 0.3},
+		{PricingRule{MinQuantity: 3}, 1, 3},
+		{PricingRule{MinQuantity: 3}, 4.2, 4.2},
+		{PricingRule{MinQuantity: 3, QuantityStep: 0.5}, 3.2, 3.5},
+		{PricingRule{MinQuantity: 3, QuantityStep: 0.5}, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.rule.ChargedQuantity(tt.qty); got != tt.want {
+			t.Errorf("%+v.ChargedQuantity(%v) = %v, want %v", tt.rule, tt.qty, got, tt.want)
+		}
+	}
+}
+
+func TestUnitPrice(t *testing.T) {
+	rule := PricingRule{PriceTiers: []PriceTier{
+		{MinQuantity: 10, Price: money.Rupiah(5000)},
+		{MinQuantity: 0, Price: money.Rupiah(1000)}, // dibuang Normalize
+		{MinQuantity: 5, Price: money.Rupiah(6000)},
+	}}
+	rule.Normalize()
+	tests := []struct {
+		charged float64
+		want    money.Amount
+	}{
+		{3, money.Rupiah(7000)},
+		{4.999, money.Rupiah(7000)},
+		{5, money.Rupiah(6000)},
+		{9.5, money.Rupiah(6000)},
+		{10, money.Rupiah(5000)},
+		{25, money.Rupiah(5000)},
+	}
+	for _, tt := range tests {
+		if got := rule.UnitPrice(money.Rupiah(7000), tt.charged); got != tt.want {
+			t.Errorf("UnitPrice(%v) = %v, want %v", tt.charged, got, tt.want)
+		}
+	}
+}
+
+func TestCharge(t *testing.T) {
+	rule := PricingRule{MinCharge: money.Rupiah(20000), QuantityStep: 0.5}
+	tests := []struct {
+		qty       float64
+		unitPrice money.Amount
+		want      Quote
+	}{
+		{2.2, money.Rupiah(7000), Quote{Quantity: 2.2, ChargedQuantity: 2.5, UnitPrice: money.Rupiah(7000), Amount: money.Rupiah(20000), MinChargeApplied: true}},
+		{3.1, money.Rupiah(7000), Quote{Quantity: 3.1, ChargedQuantity: 3.5, UnitPrice: money.Rupiah(7000), Amount: money.Rupiah(24500)}},
+		{2.9, money.Rupiah(7000), Quote{Quantity: 2.9, ChargedQuantity: 3, UnitPrice: money.Rupiah(7000), Amount: money.Rupiah(21000)}},
+		{0, money.Rupiah(7000), Quote{UnitPrice: money.Rupiah(7000)}},
+	}
+	for _, tt := range tests {
+		if got := rule.Charge(tt.qty, tt.unitPrice); got != tt.want {
+			t.Errorf("Charge(%v, %v) = %+v, want %+v", tt.qty, tt.unitPrice, got, tt.want)
+		}
+	}
+}
+
+func TestQuote(t *testing.T) {
+	ps := &ProductService{Price: money.Rupiah(7000)}
+	ps.MinQuantity = 3
+	ps.QuantityStep = 0.5
+	ps.PriceTiers = []PriceTier{{MinQuantity: 5, Price: money.Rupiah(6000)}}
+	tests := []struct {
+		qty  float64
+		want money.Amount
+	}{
+		{1, money.Rupiah(21000)},   // minimum 3 kg
+		{4.8, money.Rupiah(30000)}, // dibulatkan ke 5 kg, masuk tier
+		{4.5, money.Rupiah(31500)},
+	}
+	for _, tt := range tests {
+		if got := ps.Quote(tt.qty).Amount; got != tt.want {
+			t.Errorf("Quote(%v).Amount = %v, want %v", tt.qty, got, tt.want)
+		}
+	}
+}
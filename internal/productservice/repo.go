@@ -2,15 +2,30 @@ package productservice
 
 import (
 	"context"
+	"errors"
+	"time"
+
 	"sumunar-pos-core/pkg/db"
+
+	"github.com/jackc/pgx/v5"
 )
 
 type ProductServiceRepository interface {
-	Create(ctx context.Context, service *ProductService) error
+	Create(ctx context.Context, tx db.DBTX, service *ProductService) error
 	FindByID(ctx context.Context, id string) (*ProductService, error)
-	FindAll(ctx context.Context, limit, offset int) ([]*ProductService, int, error)
-	Update(ctx context.Context, service *ProductService) error
+	FindDetailByID(ctx context.Context, id string) (*ProductServiceDetail, error)
+	FindAll(ctx context.Context, storeID string, limit, offset int) ([]*ProductService, int, error)
+	Update(ctx context.Context, tx db.DBTX, service *ProductService) error
 	Delete(ctx context.Context, id string) error
+	FindDetails(ctx context.Context, storeID, serviceTypeID, productID string) ([]*ProductServiceDetail, error)
+	CreatePrice(ctx context.Context, tx db.DBTX, price *Price) error
+	FindPriceByID(ctx context.Context, id string) (*Price, error)
+	FindPrices(ctx context.Context, productServiceID, storeID string, limit, offset int) ([]*Price, int, error)
+	CancelPrice(ctx context.Context, price *Price) error
+	PriceAt(ctx context.Context, productServiceID, storeID string, at time.Time) (*Price, error)
+	CreateAdjustment(ctx context.Context, tx db.DBTX, adj *PriceAdjustment) error
+	FindAdjustmentByID(ctx context.Context, id string) (*PriceAdjustment, error)
+	FindAdjustments(ctx context.Context, storeID string, limit, offset int) ([]*PriceAdjustment, int, error)
 }
 
 type productServiceRepo struct {
@@ -21,17 +36,23 @@ func NewProductServiceRepository(db db.DBTX) ProductServiceRepository {
 	return &productServiceRepo{db}
 }
 
-func (r *productServiceRepo) Create(ctx context.Context, productservice *ProductService) error {
+func (r *productServiceRepo) Create(ctx context.Context, tx db.DBTX, productservice *ProductService) error {
 	query := `
-		INSERT INTO product_service (id, product_id, service_type_id, unit, price, is_active, created_at, created_by, updated_at, updated_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $7, $8)
+		INSERT INTO product_service (id, product_id, store_id, service_type_id, unit, price, min_quantity, min_charge, quantity_step, price_tiers,
+			is_active, created_at, created_by, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $12, $13)
 	`
-	_, err := r.db.Exec(ctx, query,
+	_, err := tx.Exec(ctx, query,
 		productservice.ID,
 		productservice.ProductID,
+		productservice.StoreID,
 		productservice.ServiceTypeID,
 		productservice.Unit,
 		productservice.Price,
+		productservice.MinQuantity,
+		productservice.MinCharge,
+		productservice.QuantityStep,
+		productservice.PriceTiers,
 		productservice.IsActive,
 		productservice.CreatedAt,
 		productservice.CreatedBy,
@@ -39,32 +60,66 @@ func (r *productServiceRepo) Create(ctx context.Context, productservice *Product
 	return err
 }
 
+const productServiceColumns = `ps.id, ps.product_id, ps.store_id, ps.service_type_id, ps.unit, ps.price, ps.min_quantity, ps.min_charge, ps.quantity_step, ps.price_tiers,
+	ps.is_active, ps.created_at, ps.created_by, ps.updated_at, ps.updated_by`
+
+func productServiceFields(s *ProductService) []any {
+	return []any{
+		&s.ID,
+		&s.ProductID,
+		&s.StoreID,
+		&s.ServiceTypeID,
+		&s.Unit,
+		&s.Price,
+		&s.MinQuantity,
+		&s.MinCharge,
+		&s.QuantityStep,
+		&s.PriceTiers,
+		&s.IsActive,
+		&s.CreatedAt,
+		&s.CreatedBy,
+		&s.UpdatedAt,
+		&s.UpdatedBy,
+	}
+}
+
 func (r *productServiceRepo) FindByID(ctx context.Context, id string) (*ProductService, error) {
-	query := `SELECT id, product_id, service_type_id, unit, price, is_active, created_at, created_by, updated_at, updated_by FROM product_service WHERE id = $1`
+	query := `SELECT ` + productServiceColumns + ` FROM product_service ps WHERE ps.id = $1`
 	row := r.db.QueryRow(ctx, query, id)
 
 	var productservice ProductService
-	err := row.Scan(
-		&productservice.ID,
-		&productservice.ProductID,
-		&productservice.ServiceTypeID,
-		&productservice.Unit,
-		&productservice.Price,
-		&productservice.IsActive,
-		&productservice.CreatedAt,
-		&productservice.CreatedBy,
-		&productservice.UpdatedAt,
-		&productservice.UpdatedBy,
-	)
-	if err != nil {
+	if err := row.Scan(productServiceFields(&productservice)...); err != nil {
 		return nil, err
 	}
 	return &productservice, nil
 }
 
-func (r *productServiceRepo) FindAll(ctx context.Context, limit, offset int) ([]*ProductService, int, error) {
-	query := `SELECT id, product_id, service_type_id, unit, price, is_active, created_at, created_by, updated_at, updated_by FROM product_service LIMIT $1 OFFSET $2`
-	rows, err := r.db.Query(ctx, query, limit, offset)
+func (r *productServiceRepo) FindDetailByID(ctx context.Context, id string) (*ProductServiceDetail, error) {
+	query := `
+		SELECT ` + productServiceColumns + `,
+			COALESCE(p.name, ''), COALESCE(st.name, ''), COALESCE(p.is_active, FALSE), COALESCE(st.is_active, FALSE)
+		FROM product_service ps
+		LEFT JOIN products p ON p.id = ps.product_id
+		LEFT JOIN service_types st ON st.id = ps.service_type_id
+		WHERE ps.id = $1
+	`
+	row := r.db.QueryRow(ctx, query, id)
+
+	var detail ProductServiceDetail
+	fields := append(productServiceFields(&detail.ProductService), &detail.ProductName, &detail.ServiceTypeName,
+		&detail.ProductActive, &detail.ServiceTypeActive)
+	if err := row.Scan(fields...); err != nil {
+		return nil, err
+	}
+	return &detail, nil
+}
+
+func (r *productServiceRepo) FindAll(ctx context.Context, storeID string, limit, offset int) ([]*ProductService, int, error) {
+	query := `SELECT ` + productServiceColumns + ` FROM product_service ps
+		WHERE ($1::uuid IS NULL OR ps.store_id = $1)
+		ORDER BY ps.created_at
+		LIMIT $2 OFFSET $3`
+	rows, err := r.db.Query(ctx, query, db.NullID(storeID), limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -73,18 +128,7 @@ func (r *productServiceRepo) FindAll(ctx context.Context, limit, offset int) ([]
 	var productServices []*ProductService
 	for rows.Next() {
 		var s ProductService
-		if err := rows.Scan(
-			&s.ID,
-			&s.ProductID,
-			&s.ServiceTypeID,
-			&s.Unit,
-			&s.Price,
-			&s.IsActive,
-			&s.CreatedAt,
-			&s.CreatedBy,
-			&s.UpdatedAt,
-			&s.UpdatedBy,
-		); err != nil {
+		if err := rows.Scan(productServiceFields(&s)...); err != nil {
 			return nil, 0, err
 		}
 		productServices = append(productServices, &s)
@@ -92,7 +136,7 @@ func (r *productServiceRepo) FindAll(ctx context.Context, limit, offset int) ([]
 
 	// total count
 	var total int
-	err = r.db.QueryRow(ctx, `SELECT COUNT(*) FROM product_service`).Scan(&total)
+	err = r.db.QueryRow(ctx, `SELECT COUNT(*) FROM product_service WHERE ($1::uuid IS NULL OR store_id = $1)`, db.NullID(storeID)).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -100,17 +144,22 @@ func (r *productServiceRepo) FindAll(ctx context.Context, limit, offset int) ([]
 	return productServices, total, nil
 }
 
-func (r *productServiceRepo) Update(ctx context.Context, productService *ProductService) error {
+func (r *productServiceRepo) Update(ctx context.Context, tx db.DBTX, productService *ProductService) error {
 	query := `
-		UPDATE product_service SET product_id = $1, service_type_id = $2, unit = $3, price = $4, is_active = $5,
-		updated_at = $6, updated_by = $7
-		WHERE id = $8
+		UPDATE product_service SET product_id = $1, store_id = $2, service_type_id = $3, unit = $4, price = $5, min_quantity = $6,
+		min_charge = $7, quantity_step = $8, price_tiers = $9, is_active = $10, updated_at = $11, updated_by = $12
+		WHERE id = $13
 	`
-	_, err := r.db.Exec(ctx, query,
+	_, err := tx.Exec(ctx, query,
 		productService.ProductID,
+		productService.StoreID,
 		productService.ServiceTypeID,
 		productService.Unit,
 		productService.Price,
+		productService.MinQuantity,
+		productService.MinCharge,
+		productService.QuantityStep,
+		productService.PriceTiers,
 		productService.IsActive,
 		productService.UpdatedAt,
 		productService.UpdatedBy,
@@ -123,3 +172,298 @@ func (r *productServiceRepo) Delete(ctx context.Context, id string) error {
 	_, err := r.db.Exec(ctx, `DELETE FROM product_service WHERE id = $1`, id)
 	return err
 }
+
+// FindDetails returns the active product services matching the filter, with their names.
+func (r *productServiceRepo) FindDetails(ctx context.Context, storeID, serviceTypeID, productID string) ([]*ProductServiceDetail, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+productServiceColumns+`,
+			COALESCE(p.name, ''), COALESCE(st.name, ''), COALESCE(p.is_active, FALSE), COALESCE(st.is_active, FALSE)
+		FROM product_service ps
+		LEFT JOIN products p ON p.id = ps.product_id
+		LEFT JOIN service_types st ON st.id = ps.service_type_id
+		WHERE ps.is_active AND ($1::uuid IS NULL OR ps.store_id = $1)
+			AND ($2::uuid IS NULL OR ps.service_type_id = $2) AND ($3::uuid IS NULL OR ps.product_id = $3)
+		ORDER BY p.name, st.name
+	`, db.NullID(storeID), db.NullID(serviceTypeID), db.NullID(productID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	details := []*ProductServiceDetail{}
+	for rows.Next() {
+		var d ProductServiceDetail
+		fields := append(productServiceFields(&d.ProductService), &d.ProductName, &d.ServiceTypeName,
+			&d.ProductActive, &d.ServiceTypeActive)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		details = append(details, &d)
+	}
+	return details, rows.Err()
+}
+
+func (r *productServiceRepo) CreatePrice(ctx context.Context, tx db.DBTX, price *Price) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO product_service_prices (id, product_service_id, store_id, price, min_charge, price_tiers, effective_from,
+			adjustment_id, created_at, created_by)
+		VALUES ($1, $2, NULLIF($3, '')::uuid, $4, $5, NULLIF($6::jsonb, 'null'), $7, NULLIF($8, '')::uuid, $9, NULLIF($10, '')::uuid)
+	`,
+		price.ID,
+		price.ProductServiceID,
+		price.StoreID,
+		price.Price,
+		price.MinCharge,
+		price.PriceTiers,
+		price.EffectiveFrom,
+		price.AdjustmentID,
+		price.CreatedAt,
+		price.CreatedBy,
+	)
+	return err
+}
+
+const priceColumns = `id, product_service_id, COALESCE(store_id::text, ''), price, min_charge, price_tiers, effective_from, cancelled_at,
+	COALESCE(cancelled_by::text, ''), COALESCE(adjustment_id::text, ''), created_at, COALESCE(created_by::text, '')`
+
+func scanPrice(row pgx.Row) (*Price, error) {
+	var p Price
+	err := row.Scan(
+		&p.ID,
+		&p.ProductServiceID,
+		&p.StoreID,
+		&p.Price,
+		&p.MinCharge,
+		&p.PriceTiers,
+		&p.EffectiveFrom,
+		&p.CancelledAt,
+		&p.CancelledBy,
+		&p.AdjustmentID,
+		&p.CreatedAt,
+		&p.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *productServiceRepo) FindPriceByID(ctx context.Context, id string) (*Price, error) {
+	p, err := scanPrice(r.db.QueryRow(ctx, `SELECT `+priceColumns+` FROM product_service_prices WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPriceNotFound
+	}
+	return p, err
+}
+
+// FindPrices returns the price history of a product service, newest version first. With storeID only
+// that store's versions and the ones for all stores are listed.
+func (r *productServiceRepo) FindPrices(ctx context.Context, productServiceID, storeID string, limit, offset int) ([]*Price, int, error) {
+	where := ` WHERE product_service_id = $1 AND ($2::uuid IS NULL OR store_id IS NULL OR store_id = $2)`
+	rows, err := r.db.Query(ctx, `SELECT `+priceColumns+` FROM product_service_prices`+where+`
+		ORDER BY effective_from DESC, created_at DESC LIMIT $3 OFFSET $4`, productServiceID, db.NullID(storeID), limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	prices := []*Price{}
+	for rows.Next() {
+		p, err := scanPrice(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		prices = append(prices, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM product_service_prices`+where, productServiceID, db.NullID(storeID)).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	return prices, total, nil
+}
+
+// CancelPrice marks an upcoming version as cancelled; it stays in the history.
+func (r *productServiceRepo) CancelPrice(ctx context.Context, price *Price) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE product_service_prices SET cancelled_at = $2, cancelled_by = NULLIF($3, '')::uuid
+		WHERE id = $1 AND cancelled_at IS NULL AND effective_from > $2
+	`, price.ID, price.CancelledAt, price.CancelledBy)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPriceAlreadyEffective
+	}
+	return nil
+}
+
+// PriceAt returns the price version valid at the given time for the store, or nil when the product service
+// has no version yet and the catalog price applies. The latest version wins; at the same effective time
+// the store's own version wins over one for all stores.
+func (r *productServiceRepo) PriceAt(ctx context.Context, productServiceID, storeID string, at time.Time) (*Price, error) {
+	p, err := scanPrice(r.db.QueryRow(ctx, `
+		SELECT `+priceColumns+` FROM product_service_prices
+		WHERE product_service_id = $1 AND (store_id IS NULL OR store_id = $2::uuid)
+			AND effective_from <= $3 AND cancelled_at IS NULL
+		ORDER BY effective_from DESC, store_id IS NULL, created_at DESC
+		LIMIT 1
+	`, productServiceID, db.NullID(storeID), at))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return p, err
+}
+
+// CreateAdjustment stores the audit entry of a bulk price change with its lines.
+func (r *productServiceRepo) CreateAdjustment(ctx context.Context, tx db.DBTX, adj *PriceAdjustment) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO price_adjustments (id, store_id, service_type_id, product_id, type, percent, amount, round_to, rounding_mode,
+			effective_from, item_count, created_at, created_by)
+		VALUES ($1, NULLIF($2, '')::uuid, NULLIF($3, '')::uuid, NULLIF($4, '')::uuid, $5, $6, $7, $8, $9, $10, $11, $12, NULLIF($13, '')::uuid)
+	`,
+		adj.ID,
+		adj.StoreID,
+		adj.ServiceTypeID,
+		adj.ProductID,
+		adj.Type,
+		adj.Percent,
+		adj.Amount,
+		adj.RoundTo,
+		adj.RoundingMode,
+		adj.EffectiveFrom,
+		adj.ItemCount,
+		adj.CreatedAt,
+		adj.CreatedBy,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, l := range adj.Lines {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO price_adjustment_lines (id, adjustment_id, product_service_id, product_name, service_type_name, unit, old_price, new_price,
+				old_min_charge, new_min_charge, old_price_tiers, new_price_tiers)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		`,
+			l.ID,
+			l.AdjustmentID,
+			l.ProductServiceID,
+			l.ProductName,
+			l.ServiceTypeName,
+			l.Unit,
+			l.OldPrice,
+			l.NewPrice,
+			l.OldMinCharge,
+			l.NewMinCharge,
+			l.OldPriceTiers,
+			l.NewPriceTiers,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const adjustmentColumns = `id, COALESCE(store_id::text, ''), COALESCE(service_type_id::text, ''), COALESCE(product_id::text, ''),
+	type, percent, amount, round_to, rounding_mode, effective_from, item_count, created_at, COALESCE(created_by::text, '')`
+
+func scanAdjustment(row pgx.Row) (*PriceAdjustment, error) {
+	var a PriceAdjustment
+	err := row.Scan(
+		&a.ID,
+		&a.StoreID,
+		&a.ServiceTypeID,
+		&a.ProductID,
+		&a.Type,
+		&a.Percent,
+		&a.Amount,
+		&a.RoundTo,
+		&a.RoundingMode,
+		&a.EffectiveFrom,
+		&a.ItemCount,
+		&a.CreatedAt,
+		&a.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *productServiceRepo) FindAdjustmentByID(ctx context.Context, id string) (*PriceAdjustment, error) {
+	adj, err := scanAdjustment(r.db.QueryRow(ctx, `SELECT `+adjustmentColumns+` FROM price_adjustments WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAdjustmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, adjustment_id, product_service_id, product_name, service_type_name, unit, old_price, new_price,
+			old_min_charge, new_min_charge, old_price_tiers, new_price_tiers
+		FROM price_adjustment_lines
+		WHERE adjustment_id = $1
+		ORDER BY product_name, service_type_name
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l PriceAdjustmentLine
+		if err := rows.Scan(
+			&l.ID,
+			&l.AdjustmentID,
+			&l.ProductServiceID,
+			&l.ProductName,
+			&l.ServiceTypeName,
+			&l.Unit,
+			&l.OldPrice,
+			&l.NewPrice,
+			&l.OldMinCharge,
+			&l.NewMinCharge,
+			&l.OldPriceTiers,
+			&l.NewPriceTiers,
+		); err != nil {
+			return nil, err
+		}
+		adj.Lines = append(adj.Lines, &l)
+	}
+	return adj, rows.Err()
+}
+
+// FindAdjustments lists bulk price changes, newest first, without their lines.
+func (r *productServiceRepo) FindAdjustments(ctx context.Context, storeID string, limit, offset int) ([]*PriceAdjustment, int, error) {
+	where := ` WHERE ($1::uuid IS NULL OR store_id = $1)`
+	rows, err := r.db.Query(ctx, `SELECT `+adjustmentColumns+` FROM price_adjustments`+where+`
+		ORDER BY created_at DESC LIMIT $2 OFFSET $3`, db.NullID(storeID), limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	adjustments := []*PriceAdjustment{}
+	for rows.Next() {
+		a, err := scanAdjustment(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		adjustments = append(adjustments, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM price_adjustments`+where, db.NullID(storeID)).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	return adjustments, total, nil
+}
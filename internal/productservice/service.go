@@ -2,28 +2,48 @@ package productservice
 
 import (
 	"context"
+	"errors"
 	"log"
+	"slices"
+	"sumunar-pos-core/internal/product"
 	"sumunar-pos-core/internal/productservice/dto"
+	"sumunar-pos-core/internal/servicetype"
+	"sumunar-pos-core/internal/store"
 	"sumunar-pos-core/middleware"
 	"sumunar-pos-core/pkg/db"
 	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
 type ProductServiceService interface {
 	Create(ctx context.Context, req *dto.ProductServiceRequest) (*ProductService, error)
 	FindByID(ctx context.Context, id string) (*ProductService, error)
-	FindAll(ctx context.Context, limit, offset int) ([]*ProductService, int, error)
+	FindAll(ctx context.Context, storeID string, limit, offset int) ([]*ProductService, int, error)
 	Update(ctx context.Context, id string, req *dto.ProductServiceRequest) (*ProductService, error)
 	Delete(ctx context.Context, id string) error
+	Quote(ctx context.Context, id, storeID string, quantity float64) (*ProductService, Quote, error)
+	SchedulePrice(ctx context.Context, id string, req *dto.PriceRequest) (*Price, error)
+	Prices(ctx context.Context, id, storeID string, limit, offset int) ([]*Price, int, error)
+	CancelPrice(ctx context.Context, id, priceID string) (*Price, error)
+	PreviewAdjustment(ctx context.Context, req *dto.PriceAdjustmentRequest) (*PriceAdjustment, error)
+	ApplyAdjustment(ctx context.Context, req *dto.PriceAdjustmentRequest) (*PriceAdjustment, error)
+	FindAdjustmentByID(ctx context.Context, id string) (*PriceAdjustment, error)
+	FindAdjustments(ctx context.Context, storeID string, limit, offset int) ([]*PriceAdjustment, int, error)
 }
 
 type service struct {
-	repo ProductServiceRepository
-	db   db.DBTX
+	repo            ProductServiceRepository
+	productRepo     product.ProductRepository
+	serviceTypeRepo servicetype.ServiceRepository
+	storeRepo       store.StoreRepository
+	db              db.TxBeginner
 }
 
-func NewService(repo ProductServiceRepository, db db.DBTX) ProductServiceService {
-	return &service{repo: repo, db: db}
+func NewService(repo ProductServiceRepository, productRepo product.ProductRepository, serviceTypeRepo servicetype.ServiceRepository,
+	storeRepo store.StoreRepository, db db.TxBeginner) ProductServiceService {
+	return &service{repo: repo, productRepo: productRepo, serviceTypeRepo: serviceTypeRepo, storeRepo: storeRepo, db: db}
 }
 
 func (s *service) Create(ctx context.Context, req *dto.ProductServiceRequest) (*ProductService, error) {
@@ -33,11 +53,26 @@ func (s *service) Create(ctx context.Context, req *dto.ProductServiceRequest) (*
 	}
 
 	productService := ToProductServiceModel(req, userID)
+	if productService.StoreID, err = s.storeOf(ctx, req); err != nil {
+		return nil, err
+	}
 
-	if err := s.repo.Create(ctx, productService); err != nil {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.repo.Create(ctx, tx, productService); err != nil {
+		return nil, err
+	}
+	if err := s.logPrice(ctx, tx, productService, userID); err != nil {
 		return nil, err
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
 	return productService, nil
 }
 
@@ -45,8 +80,8 @@ func (s *service) FindByID(ctx context.Context, id string) (*ProductService, err
 	return s.repo.FindByID(ctx, id)
 }
 
-func (s *service) FindAll(ctx context.Context, limit, offset int) ([]*ProductService, int, error) {
-	return s.repo.FindAll(ctx, limit, offset)
+func (s *service) FindAll(ctx context.Context, storeID string, limit, offset int) ([]*ProductService, int, error) {
+	return s.repo.FindAll(ctx, storeID, limit, offset)
 }
 
 func (s *service) Update(ctx context.Context, id string, req *dto.ProductServiceRequest) (*ProductService, error) {
@@ -60,16 +95,269 @@ func (s *service) Update(ctx context.Context, id string, req *dto.ProductService
 		return nil, err
 	}
 
+	storeID, err := s.storeOf(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// tagihan minimum dan tier juga dicatat, supaya versi dari penyesuaian massal tidak menutupi editan katalog
+	rule := ToPricingRule(req)
+	priceChanged := productService.Price != req.Price || productService.MinCharge != rule.MinCharge ||
+		!slices.Equal(productService.PriceTiers, rule.PriceTiers)
 	productService.ProductID = req.ProductID
+	productService.StoreID = storeID
 	productService.ServiceTypeID = req.ServiceTypeID
 	productService.Unit = req.Unit
 	productService.Price = req.Price
+	productService.PricingRule = rule
+	if req.IsActive != nil {
+		productService.IsActive = *req.IsActive
+	}
 	productService.UpdatedAt = time.Now()
 	productService.UpdatedBy = userID
 
-	return productService, s.repo.Update(ctx, productService)
+	// harga katalog dan riwayatnya ditulis bersama, supaya versi lama tidak menang atas editan yang tidak tercatat
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.repo.Update(ctx, tx, productService); err != nil {
+		return nil, err
+	}
+	if priceChanged {
+		if err := s.logPrice(ctx, tx, productService, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return productService, nil
 }
 
 func (s *service) Delete(ctx context.Context, id string) error {
 	return s.repo.Delete(ctx, id)
 }
+
+// storeOf returns the store of the request's product, which must also own the service type.
+func (s *service) storeOf(ctx context.Context, req *dto.ProductServiceRequest) (string, error) {
+	p, err := s.productRepo.FindByID(ctx, req.ProductID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrProductNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	st, err := s.serviceTypeRepo.FindByID(ctx, req.ServiceTypeID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrServiceTypeNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	if st.StoreID != p.StoreID {
+		return "", ErrStoreMismatch
+	}
+	return p.StoreID, nil
+}
+
+// Quote previews the price of a quantity with the same rules orders use, at the price valid now.
+func (s *service) Quote(ctx context.Context, id, storeID string, quantity float64) (*ProductService, Quote, error) {
+	productService, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, Quote{}, err
+	}
+	price, err := s.repo.PriceAt(ctx, id, storeID, time.Now())
+	if err != nil {
+		return nil, Quote{}, err
+	}
+	if price != nil {
+		price.ApplyTo(productService)
+	}
+	return productService, productService.Quote(quantity), nil
+}
+
+// location is the time zone plain effective dates are read in: the store's, or the server's when the
+// change is for all stores.
+func (s *service) location(ctx context.Context, storeID string) (*time.Location, error) {
+	if storeID == "" {
+		return time.Local, nil
+	}
+	settings, err := s.storeRepo.FindSettings(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+	return settings.Location(), nil
+}
+
+// logPrice records a catalog price edit in the price history for the product service's store, effective right away.
+func (s *service) logPrice(ctx context.Context, tx db.DBTX, productService *ProductService, userID string) error {
+	now := time.Now()
+	return s.repo.CreatePrice(ctx, tx, &Price{
+		ID:               uuid.New().String(),
+		ProductServiceID: productService.ID,
+		StoreID:          productService.StoreID,
+		Price:            productService.Price,
+		EffectiveFrom:    now,
+		CreatedAt:        now,
+		CreatedBy:        userID,
+	})
+}
+
+// SchedulePrice adds a price version that orders pick up from its effective date.
+func (s *service) SchedulePrice(ctx context.Context, id string, req *dto.PriceRequest) (*Price, error) {
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("failed to get user id from context:", err)
+	}
+
+	productService, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.StoreID != "" && req.StoreID != productService.StoreID {
+		return nil, ErrStoreMismatch
+	}
+	loc, err := s.location(ctx, productService.StoreID)
+	if err != nil {
+		return nil, err
+	}
+	price, err := ToPriceModel(id, req, userID, time.Now(), loc)
+	if err != nil {
+		return nil, err
+	}
+
+	// harga terjadwal hanya mengganti harga dasar; tagihan minimum dan tier versi sebelumnya tetap berlaku
+	current, err := s.repo.PriceAt(ctx, id, productService.StoreID, price.EffectiveFrom)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil {
+		price.MinCharge = current.MinCharge
+		price.PriceTiers = current.PriceTiers
+	}
+	return price, s.repo.CreatePrice(ctx, s.db, price)
+}
+
+func (s *service) Prices(ctx context.Context, id, storeID string, limit, offset int) ([]*Price, int, error) {
+	return s.repo.FindPrices(ctx, id, storeID, limit, offset)
+}
+
+// CancelPrice cancels a price change that has not taken effect yet.
+func (s *service) CancelPrice(ctx context.Context, id, priceID string) (*Price, error) {
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("failed to get user id from context:", err)
+	}
+
+	price, err := s.repo.FindPriceByID(ctx, priceID)
+	if err != nil {
+		return nil, err
+	}
+	if price.ProductServiceID != id {
+		return nil, ErrPriceNotFound
+	}
+	if price.CancelledAt != nil {
+		return nil, ErrPriceAlreadyCancelled
+	}
+
+	now := time.Now()
+	price.CancelledAt = &now
+	price.CancelledBy = userID
+	return price, s.repo.CancelPrice(ctx, price)
+}
+
+// PreviewAdjustment computes the old and new price, minimum charge and tiers of every matching product
+// service without saving. Old values are the ones valid for the store at the effective date.
+func (s *service) PreviewAdjustment(ctx context.Context, req *dto.PriceAdjustmentRequest) (*PriceAdjustment, error) {
+	loc, err := s.location(ctx, req.StoreID)
+	if err != nil {
+		return nil, err
+	}
+	adj, err := ToPriceAdjustmentModel(req, "", time.Now(), loc)
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := s.repo.FindDetails(ctx, adj.StoreID, adj.ServiceTypeID, adj.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range details {
+		current := d.ProductService
+		price, err := s.repo.PriceAt(ctx, d.ID, adj.StoreID, adj.EffectiveFrom)
+		if err != nil {
+			return nil, err
+		}
+		if price != nil {
+			price.ApplyTo(&current)
+		}
+
+		// yang tidak berubah (misal karena pembulatan) tidak dibuatkan versi baru
+		if line := ToPriceAdjustmentLine(adj, d, &current); line.Changed() {
+			adj.Lines = append(adj.Lines, line)
+		}
+	}
+	adj.ItemCount = len(adj.Lines)
+	return adj, nil
+}
+
+// ApplyAdjustment writes the new prices as price versions and the audit entry in one transaction.
+func (s *service) ApplyAdjustment(ctx context.Context, req *dto.PriceAdjustmentRequest) (*PriceAdjustment, error) {
+	userID, err := middleware.GetUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("failed to get user id from context:", err)
+	}
+
+	adj, err := s.PreviewAdjustment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if adj.ItemCount == 0 {
+		return nil, ErrNothingToAdjust
+	}
+	adj.CreatedBy = userID
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.repo.CreateAdjustment(ctx, tx, adj); err != nil {
+		return nil, err
+	}
+	for _, l := range adj.Lines {
+		price := &Price{
+			ID:               uuid.New().String(),
+			ProductServiceID: l.ProductServiceID,
+			StoreID:          adj.StoreID,
+			Price:            l.NewPrice,
+			MinCharge:        &l.NewMinCharge,
+			PriceTiers:       l.NewPriceTiers,
+			EffectiveFrom:    adj.EffectiveFrom,
+			AdjustmentID:     adj.ID,
+			CreatedAt:        adj.CreatedAt,
+			CreatedBy:        userID,
+		}
+		if err := s.repo.CreatePrice(ctx, tx, price); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return adj, nil
+}
+
+func (s *service) FindAdjustmentByID(ctx context.Context, id string) (*PriceAdjustment, error) {
+	return s.repo.FindAdjustmentByID(ctx, id)
+}
+
+func (s *service) FindAdjustments(ctx context.Context, storeID string, limit, offset int) ([]*PriceAdjustment, int, error) {
+	return s.repo.FindAdjustments(ctx, storeID, limit, offset)
+}
@@ -0,0 +1,26 @@
+package dto
+
+import "sumunar-pos-core/pkg/money"
+
+type PromoRequest struct {
+	StoreID        string       `json:"store_id" validate:"required"`
+	Code           string       `json:"code" validate:"required,max=30"`
+	Description    string       `json:"description"`
+	Type           string       `json:"type" validate:"required,oneof=percentage fixed"`
+	Percent        float64      `json:"percent" validate:"gte=0,lte=100"`
+	Amount         money.Amount `json:"amount" validate:"gte=0"`
+	MaxDiscount    money.Amount `json:"max_discount" validate:"gte=0"`
+	MinSpend       money.Amount `json:"min_spend" validate:"gte=0"`
+	ServiceTypeIDs []string     `json:"service_type_ids" validate:"omitempty,dive,uuid"`
+	StartsAt       string       `json:"starts_at"` // ISO8601, kosong = langsung berlaku
+	EndsAt         string       `json:"ends_at"`   // ISO8601, kosong = tidak kedaluwarsa
+	UsageLimit     int          `json:"usage_limit" validate:"gte=0"`
+	IsActive       *bool        `json:"is_active"`
+}
+
+// ListRequest is the query string of the list endpoint.
+type ListRequest struct {
+	StoreID string `query:"store_id" validate:"omitempty,uuid"`
+	Limit   int    `query:"limit"`
+	Offset  int    `query:"offset"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "sumunar-pos-core/pkg/money"
+
+type PromoResponse struct {
+	ID             string       `json:"id"`
+	StoreID        string       `json:"store_id"`
+	Code           string       `json:"code"`
+	Description    string       `json:"description"`
+	Type           string       `json:"type"`
+	Percent        float64      `json:"percent"`
+	Amount         money.Amount `json:"amount"`
+	MaxDiscount    money.Amount `json:"max_discount"`
+	MinSpend       money.Amount `json:"min_spend"`
+	ServiceTypeIDs []string     `json:"service_type_ids"`
+	StartsAt       string       `json:"starts_at,omitempty"`
+	EndsAt         string       `json:"ends_at,omitempty"`
+	UsageLimit     int          `json:"usage_limit"`
+	UsageCount     int          `json:"usage_count"`
+	IsActive       bool         `json:"is_active"`
+}
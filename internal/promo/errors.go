@@ -0,0 +1,15 @@
+package promo
+
+import "errors"
+
+var (
+	ErrPromoNotFound      = errors.New("promo code not found")
+	ErrPromoCodeExists    = errors.New("promo code already exists in this store")
+	ErrInvalidPromo       = errors.New("percentage promo needs percent between 0 and 100, fixed promo needs amount")
+	ErrInvalidPeriod      = errors.New("starts_at and ends_at must be RFC3339 and starts_at must be before ends_at")
+	ErrPromoInactive      = errors.New("promo code is not active")
+	ErrPromoNotValid      = errors.New("promo code is not valid at this time")
+	ErrPromoUsageLimit    = errors.New("promo code usage limit reached")
+	ErrPromoMinSpend      = errors.New("order does not reach the promo minimum spend")
+	ErrPromoNotApplicable = errors.New("promo code does not apply to any item in the order")
+)
@@ -0,0 +1,115 @@
+package promo
+
+import (
+	"errors"
+	"net/http"
+
+	"sumunar-pos-core/internal/promo/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service PromoService
+}
+
+func NewHandler(service PromoService) *Handler {
+	return &Handler{service}
+}
+
+func (h *Handler) Create(c echo.Context) error {
+	var req dto.PromoRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Get("user_id").(string)
+	p, err := h.service.Create(c.Request().Context(), &req, userID)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, ToPromoResponse(p))
+}
+
+func (h *Handler) FindByID(c echo.Context) error {
+	id := c.Param("id")
+
+	p, err := h.service.FindByID(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToPromoResponse(p))
+}
+
+func (h *Handler) FindAll(c echo.Context) error {
+	var req dto.ListRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	storeID, limit, offset := req.StoreID, req.Limit, req.Offset
+	if limit <= 0 {
+		limit = 50
+	}
+
+	promos, total, err := h.service.FindAll(c.Request().Context(), storeID, limit, offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data":   ToPromoListResponse(promos),
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+func (h *Handler) Update(c echo.Context) error {
+	id := c.Param("id")
+	var req dto.PromoRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Get("user_id").(string)
+	p, err := h.service.Update(c.Request().Context(), id, &req, userID)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToPromoResponse(p))
+}
+
+func (h *Handler) Delete(c echo.Context) error {
+	id := c.Param("id")
+
+	if err := h.service.Delete(c.Request().Context(), id); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrPromoNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidPromo), errors.Is(err, ErrInvalidPeriod):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrPromoCodeExists):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,115 @@
+package promo
+
+import (
+	"strings"
+	"time"
+
+	"sumunar-pos-core/internal/base"
+	"sumunar-pos-core/internal/promo/dto"
+
+	"github.com/google/uuid"
+)
+
+func ToPromoModel(req *dto.PromoRequest, createdBy string) (*Promo, error) {
+	now := time.Now()
+	p := &Promo{
+		ID:      uuid.New().String(),
+		StoreID: req.StoreID,
+		BaseModel: base.BaseModel{
+			IsActive:  true,
+			CreatedAt: now,
+			CreatedBy: createdBy,
+			UpdatedAt: now,
+			UpdatedBy: createdBy,
+		},
+	}
+	if err := applyRequest(p, req); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// applyRequest copies the editable fields of req onto p; store_id and usage_count are not touched.
+func applyRequest(p *Promo, req *dto.PromoRequest) error {
+	switch {
+	case req.Type == TypePercentage && (req.Percent <= 0 || req.Percent > 100):
+		return ErrInvalidPromo
+	case req.Type == TypeFixed && req.Amount <= 0:
+		return ErrInvalidPromo
+	}
+
+	startsAt, err := parseOptionalTime(req.StartsAt)
+	if err != nil {
+		return ErrInvalidPeriod
+	}
+	endsAt, err := parseOptionalTime(req.EndsAt)
+	if err != nil {
+		return ErrInvalidPeriod
+	}
+	if startsAt != nil && endsAt != nil && !startsAt.Before(*endsAt) {
+		return ErrInvalidPeriod
+	}
+
+	p.Code = strings.ToUpper(strings.TrimSpace(req.Code))
+	p.Description = req.Description
+	p.Type = req.Type
+	p.Percent = req.Percent
+	p.Amount = req.Amount
+	p.MaxDiscount = req.MaxDiscount
+	p.MinSpend = req.MinSpend
+	p.ServiceTypeIDs = req.ServiceTypeIDs
+	if p.ServiceTypeIDs == nil {
+		p.ServiceTypeIDs = []string{}
+	}
+	p.StartsAt = startsAt
+	p.EndsAt = endsAt
+	p.UsageLimit = req.UsageLimit
+	if req.IsActive != nil {
+		p.IsActive = *req.IsActive
+	}
+	return nil
+}
+
+func parseOptionalTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func ToPromoResponse(p *Promo) *dto.PromoResponse {
+	res := &dto.PromoResponse{
+		ID:             p.ID,
+		StoreID:        p.StoreID,
+		Code:           p.Code,
+		Description:    p.Description,
+		Type:           p.Type,
+		Percent:        p.Percent,
+		Amount:         p.Amount,
+		MaxDiscount:    p.MaxDiscount,
+		MinSpend:       p.MinSpend,
+		ServiceTypeIDs: p.ServiceTypeIDs,
+		UsageLimit:     p.UsageLimit,
+		UsageCount:     p.UsageCount,
+		IsActive:       p.IsActive,
+	}
+	if p.StartsAt != nil {
+		res.StartsAt = p.StartsAt.Format(time.RFC3339)
+	}
+	if p.EndsAt != nil {
+		res.EndsAt = p.EndsAt.Format(time.RFC3339)
+	}
+	return res
+}
+
+func ToPromoListResponse(promos []*Promo) []*dto.PromoResponse {
+	res := make([]*dto.PromoResponse, 0, len(promos))
+	for _, p := range promos {
+		res = append(res, ToPromoResponse(p))
+	}
+	return res
+}
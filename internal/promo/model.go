@@ -0,0 +1,93 @@
+package promo
+
+import (
+	"time"
+
+	"sumunar-pos-core/internal/base"
+	"sumunar-pos-core/pkg/money"
+)
+
+// Jenis potongan promo.
+const (
+	TypePercentage = "percentage"
+	TypeFixed      = "fixed"
+)
+
+// Promo is a discount code customers can use on orders at one store.
+type Promo struct {
+	ID             string
+	StoreID        string
+	Code           string // huruf besar, unik per store
+	Description    string
+	Type           string       // percentage, fixed
+	Percent        float64      // untuk percentage, misal 15 untuk 15%
+	Amount         money.Amount // untuk fixed
+	MaxDiscount    money.Amount // batas potongan untuk percentage, 0 = tanpa batas
+	MinSpend       money.Amount // subtotal order minimal, 0 = tanpa minimum
+	ServiceTypeIDs []string     // jenis layanan yang mendapat potongan, kosong = semua
+	StartsAt       *time.Time   // nil = berlaku sejak dibuat
+	EndsAt         *time.Time   // nil = tidak kedaluwarsa
+	UsageLimit     int          // jumlah order maksimal, 0 = tanpa batas
+	UsageCount     int
+	base.BaseModel
+}
+
+// Line is an order line as seen by a promo: its service type and its total after item discounts.
+type Line struct {
+	ServiceTypeID string
+	Amount        money.Amount
+}
+
+// Check reports whether the promo can be used at time at, without looking at the order.
+func (p *Promo) Check(at time.Time) error {
+	switch {
+	case !p.IsActive:
+		return ErrPromoInactive
+	case p.StartsAt != nil && at.Before(*p.StartsAt), p.EndsAt != nil && !at.Before(*p.EndsAt):
+		return ErrPromoNotValid
+	case p.UsageLimit > 0 && p.UsageCount >= p.UsageLimit:
+		return ErrPromoUsageLimit
+	}
+	return nil
+}
+
+// Discount returns the promo discount on lines. The minimum spend is checked against all lines,
+// the discount itself only applies to lines of eligible service types.
+func (p *Promo) Discount(lines []Line) (money.Amount, error) {
+	var subtotal, eligible money.Amount
+	for _, l := range lines {
+		subtotal += l.Amount
+		if p.appliesTo(l.ServiceTypeID) {
+			eligible += l.Amount
+		}
+	}
+	if subtotal < p.MinSpend {
+		return 0, ErrPromoMinSpend
+	}
+	if eligible <= 0 {
+		return 0, ErrPromoNotApplicable
+	}
+
+	var discount money.Amount
+	if p.Type == TypeFixed {
+		discount = p.Amount
+	} else {
+		discount = eligible.Percent(p.Percent, money.RoundHalfUp)
+		if p.MaxDiscount > 0 {
+			discount = money.Min(discount, p.MaxDiscount)
+		}
+	}
+	return money.Min(discount, eligible), nil
+}
+
+func (p *Promo) appliesTo(serviceTypeID string) bool {
+	if len(p.ServiceTypeIDs) == 0 {
+		return true
+	}
+	for _, id := range p.ServiceTypeIDs {
+		if id == serviceTypeID {
+			return true
+		}
+	}
+	return false
+}
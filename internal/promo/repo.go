@@ -0,0 +1,206 @@
+package promo
+
+import (
+	"context"
+	"errors"
+
+	"sumunar-pos-core/pkg/db"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type PromoRepository interface {
+	Create(ctx context.Context, p *Promo) error
+	FindByID(ctx context.Context, id string) (*Promo, error)
+	FindByCode(ctx context.Context, storeID, code string) (*Promo, error)
+	FindAll(ctx context.Context, storeID string, limit, offset int) ([]*Promo, int, error)
+	Update(ctx context.Context, p *Promo) error
+	Delete(ctx context.Context, id string) error
+	Redeem(ctx context.Context, tx db.DBTX, id string) error
+	Release(ctx context.Context, tx db.DBTX, id string) error
+}
+
+type promoRepo struct {
+	db db.DBTX
+}
+
+func NewPromoRepository(db db.DBTX) PromoRepository {
+	return &promoRepo{db}
+}
+
+const promoColumns = `id, store_id, code, description, type, percent, amount, max_discount, min_spend, service_type_ids,
+	starts_at, ends_at, usage_limit, usage_count, is_active, created_at, created_by, updated_at, updated_by`
+
+func (r *promoRepo) Create(ctx context.Context, p *Promo) error {
+	query := `
+		INSERT INTO promos (` + promoColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $16, $17)
+	`
+	_, err := r.db.Exec(ctx, query,
+		p.ID,
+		p.StoreID,
+		p.Code,
+		p.Description,
+		p.Type,
+		p.Percent,
+		p.Amount,
+		p.MaxDiscount,
+		p.MinSpend,
+		p.ServiceTypeIDs,
+		p.StartsAt,
+		p.EndsAt,
+		p.UsageLimit,
+		p.UsageCount,
+		p.IsActive,
+		p.CreatedAt,
+		p.CreatedBy,
+	)
+	return uniqueErr(err)
+}
+
+func (r *promoRepo) FindByID(ctx context.Context, id string) (*Promo, error) {
+	query := `SELECT ` + promoColumns + ` FROM promos WHERE id = $1`
+	return scanPromo(r.db.QueryRow(ctx, query, id))
+}
+
+// FindByCode looks the code up case-insensitively; codes are stored in upper case.
+func (r *promoRepo) FindByCode(ctx context.Context, storeID, code string) (*Promo, error) {
+	query := `SELECT ` + promoColumns + ` FROM promos WHERE store_id = $1 AND code = UPPER($2)`
+	return scanPromo(r.db.QueryRow(ctx, query, storeID, code))
+}
+
+func scanPromo(row pgx.Row) (*Promo, error) {
+	var p Promo
+	err := row.Scan(
+		&p.ID,
+		&p.StoreID,
+		&p.Code,
+		&p.Description,
+		&p.Type,
+		&p.Percent,
+		&p.Amount,
+		&p.MaxDiscount,
+		&p.MinSpend,
+		&p.ServiceTypeIDs,
+		&p.StartsAt,
+		&p.EndsAt,
+		&p.UsageLimit,
+		&p.UsageCount,
+		&p.IsActive,
+		&p.CreatedAt,
+		&p.CreatedBy,
+		&p.UpdatedAt,
+		&p.UpdatedBy,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPromoNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// FindAll lists promos, optionally limited to one store when storeID is not empty.
+func (r *promoRepo) FindAll(ctx context.Context, storeID string, limit, offset int) ([]*Promo, int, error) {
+	query := `
+		SELECT ` + promoColumns + `
+		FROM promos
+		WHERE ($1::uuid IS NULL OR store_id = $1)
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Query(ctx, query, db.NullID(storeID), limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	promos := []*Promo{}
+	for rows.Next() {
+		p, err := scanPromo(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		promos = append(promos, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	err = r.db.QueryRow(ctx, `SELECT COUNT(*) FROM promos WHERE ($1::uuid IS NULL OR store_id = $1)`, db.NullID(storeID)).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return promos, total, nil
+}
+
+func (r *promoRepo) Update(ctx context.Context, p *Promo) error {
+	query := `
+		UPDATE promos SET code = $1, description = $2, type = $3, percent = $4, amount = $5, max_discount = $6,
+			min_spend = $7, service_type_ids = $8, starts_at = $9, ends_at = $10, usage_limit = $11, is_active = $12,
+			updated_at = $13, updated_by = $14
+		WHERE id = $15
+	`
+	tag, err := r.db.Exec(ctx, query,
+		p.Code,
+		p.Description,
+		p.Type,
+		p.Percent,
+		p.Amount,
+		p.MaxDiscount,
+		p.MinSpend,
+		p.ServiceTypeIDs,
+		p.StartsAt,
+		p.EndsAt,
+		p.UsageLimit,
+		p.IsActive,
+		p.UpdatedAt,
+		p.UpdatedBy,
+		p.ID,
+	)
+	if err != nil {
+		return uniqueErr(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPromoNotFound
+	}
+	return nil
+}
+
+func (r *promoRepo) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM promos WHERE id = $1`, id)
+	return err
+}
+
+// Redeem counts one use of the promo, failing when the usage limit has been reached in the meantime.
+func (r *promoRepo) Redeem(ctx context.Context, tx db.DBTX, id string) error {
+	tag, err := tx.Exec(ctx, `
+		UPDATE promos SET usage_count = usage_count + 1
+		WHERE id = $1 AND (usage_limit = 0 OR usage_count < usage_limit)
+	`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPromoUsageLimit
+	}
+	return nil
+}
+
+// Release gives back a use, e.g. when an order switches to another promo code.
+func (r *promoRepo) Release(ctx context.Context, tx db.DBTX, id string) error {
+	_, err := tx.Exec(ctx, `UPDATE promos SET usage_count = GREATEST(usage_count - 1, 0) WHERE id = $1`, id)
+	return err
+}
+
+func uniqueErr(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrPromoCodeExists
+	}
+	return err
+}
@@ -0,0 +1,64 @@
+package promo
+
+import (
+	"context"
+	"time"
+
+	"sumunar-pos-core/internal/promo/dto"
+)
+
+type PromoService interface {
+	Create(ctx context.Context, req *dto.PromoRequest, userID string) (*Promo, error)
+	FindByID(ctx context.Context, id string) (*Promo, error)
+	FindAll(ctx context.Context, storeID string, limit, offset int) ([]*Promo, int, error)
+	Update(ctx context.Context, id string, req *dto.PromoRequest, userID string) (*Promo, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type service struct {
+	repo PromoRepository
+}
+
+func NewService(repo PromoRepository) PromoService {
+	return &service{repo: repo}
+}
+
+func (s *service) Create(ctx context.Context, req *dto.PromoRequest, userID string) (*Promo, error) {
+	p, err := ToPromoModel(req, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *service) FindByID(ctx context.Context, id string) (*Promo, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *service) FindAll(ctx context.Context, storeID string, limit, offset int) ([]*Promo, int, error) {
+	return s.repo.FindAll(ctx, storeID, limit, offset)
+}
+
+func (s *service) Update(ctx context.Context, id string, req *dto.PromoRequest, userID string) (*Promo, error) {
+	p, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// store_id tidak bisa dipindah, order lama tetap menunjuk ke promo ini
+	if err := applyRequest(p, req); err != nil {
+		return nil, err
+	}
+	p.UpdatedAt = time.Now()
+	p.UpdatedBy = userID
+
+	return p, s.repo.Update(ctx, p)
+}
+
+func (s *service) Delete(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
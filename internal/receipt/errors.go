@@ -0,0 +1,9 @@
+package receipt
+
+import "errors"
+
+var (
+	ErrInvalidReceiptType   = errors.New("receipt type must be receipt or tag")
+	ErrPrinterNotConfigured = errors.New("printer address is not configured for this store")
+	ErrPrintFailed          = errors.New("failed to send receipt to printer")
+)
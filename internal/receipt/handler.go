@@ -0,0 +1,55 @@
+package receipt
+
+import (
+	"errors"
+	"net/http"
+
+	"sumunar-pos-core/internal/order"
+	"sumunar-pos-core/internal/store"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service ReceiptService
+}
+
+func NewHandler(service ReceiptService) *Handler {
+	return &Handler{service}
+}
+
+// Download returns the raw ESC/POS stream, ?type=receipt|tag&paper=58|80.
+func (h *Handler) Download(c echo.Context) error {
+	data, o, err := h.service.Render(c.Request().Context(), c.Param("id"), c.QueryParam("type"), c.QueryParam("paper"))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+o.InvoiceNumber+`.bin"`)
+	return c.Blob(http.StatusOK, echo.MIMEOctetStream, data)
+}
+
+// Print sends the receipt to the store's network printer.
+func (h *Handler) Print(c echo.Context) error {
+	err := h.service.Print(c.Request().Context(), c.Param("id"), c.QueryParam("type"), c.QueryParam("paper"))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "Receipt sent to printer"})
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, order.ErrOrderNotFound), errors.Is(err, store.ErrStoreNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidReceiptType):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrPrinterNotConfigured):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ErrPrintFailed):
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
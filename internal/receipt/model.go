@@ -0,0 +1,46 @@
+package receipt
+
+import (
+	"time"
+
+	"sumunar-pos-core/internal/order"
+	"sumunar-pos-core/pkg/money"
+)
+
+// Receipt is everything printed on a customer receipt or garment tag for one order.
+type Receipt struct {
+	StoreName      string
+	StoreAddress   string
+	StorePhone     string
+	LogoPath       string // path lokal file logo, kosong jika toko tidak punya logo
+	Header         string // teks dari pengaturan toko, dicetak di bawah alamat
+	Footer         string // kosong = "Terima kasih"
+	Currency       money.Currency
+	InvoiceNumber  string
+	CustomerName   string
+	CustomerPhone  string
+	Items          []*order.OrderItem
+	Discount       float64 // persen
+	Subtotal       money.Amount
+	DiscountAmount money.Amount // diskon order: persen + nominal
+	PromoCode      string
+	PromoDiscount  money.Amount
+	TierName       string
+	TierDiscount   money.Amount
+	PointsRedeemed int
+	PointsDiscount money.Amount
+	PointsEarned   int     // poin yang didapat dari order ini
+	TaxRate        float64 // persen, 0 = tanpa PPN
+	TaxInclusive   bool
+	TaxBase        money.Amount
+	TaxAmount      money.Amount
+	DeliveryFee    money.Amount // ongkos antar-jemput
+	Rounding       money.Amount // pembulatan kas, sudah termasuk di Total
+	Total          money.Amount
+	Paid           money.Amount
+	Change         money.Amount
+	Outstanding    money.Amount
+	PaymentStatus  string
+	CreatedAt      time.Time // dalam zona waktu toko
+	PickupDate     time.Time
+}
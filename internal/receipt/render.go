@@ -0,0 +1,201 @@
+package receipt
+
+import (
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"strconv"
+	"strings"
+
+	"sumunar-pos-core/internal/addon"
+	"sumunar-pos-core/internal/order"
+	"sumunar-pos-core/pkg/escpos"
+)
+
+const (
+	TypeReceipt = "receipt"
+	TypeTag     = "tag"
+)
+
+const dateLayout = "02/01/2006 15:04"
+
+// RenderReceipt builds the customer receipt.
+func RenderReceipt(r *Receipt, paper escpos.Paper) []byte {
+	w := escpos.NewWriter(paper)
+
+	w.Align(escpos.AlignCenter)
+	if logo := loadLogo(r.LogoPath); logo != nil {
+		w.Image(logo, paper.Dots()/2)
+	}
+	w.Bold(true).Size(2, 2).Wrap(r.StoreName).Size(1, 1).Bold(false)
+	if r.StoreAddress != "" {
+		w.Wrap(r.StoreAddress)
+	}
+	if r.StorePhone != "" {
+		w.Line("Telp. " + r.StorePhone)
+	}
+	for _, line := range textLines(r.Header) {
+		w.Wrap(line)
+	}
+
+	w.Align(escpos.AlignLeft).Separator()
+	w.Columns("No", r.InvoiceNumber)
+	w.Columns("Tanggal", r.CreatedAt.Format(dateLayout))
+	w.Columns("Pelanggan", r.CustomerName)
+	if r.CustomerPhone != "" {
+		w.Columns("Telp", r.CustomerPhone)
+	}
+	w.Separator()
+
+	for _, item := range r.Items {
+		w.Wrap(item.Name())
+		w.Columns(fmt.Sprintf("  %s %s x %s", formatQuantity(item.ChargedQuantity), item.Unit, r.Currency.Number(item.UnitPrice)), r.Currency.Number(item.TotalPrice))
+		if item.ChargedQuantity != item.Quantity {
+			w.Line(fmt.Sprintf("  Aktual %s %s", formatQuantity(item.Quantity), item.Unit))
+		}
+		if item.MinChargeApplied() {
+			w.Line("  Tagihan minimum")
+		}
+		if item.ExpressTier != "" {
+			w.Columns("  Express "+item.ExpressTier+" +"+formatQuantity(item.ExpressRate)+"%", r.Currency.Number(item.ExpressSurcharge))
+		}
+		for _, a := range item.Addons {
+			w.Columns("  + "+addonLabel(a), r.Currency.Number(a.Amount))
+		}
+		if item.DiscountAmount > 0 {
+			w.Columns("  Diskon", "-"+r.Currency.Number(item.DiscountAmount))
+		}
+		if item.Notes != "" {
+			w.Wrap("  * " + item.Notes)
+		}
+	}
+	w.Separator()
+
+	w.Columns("Subtotal", r.Currency.Number(r.Subtotal))
+	if r.Discount > 0 {
+		w.Columns(fmt.Sprintf("Diskon (%s%%)", formatQuantity(r.Discount)), "-"+r.Currency.Number(r.DiscountAmount))
+	} else if r.DiscountAmount > 0 {
+		w.Columns("Diskon", "-"+r.Currency.Number(r.DiscountAmount))
+	}
+	if r.PromoDiscount > 0 {
+		w.Columns("Promo "+r.PromoCode, "-"+r.Currency.Number(r.PromoDiscount))
+	}
+	if r.TierDiscount > 0 {
+		w.Columns("Member "+r.TierName, "-"+r.Currency.Number(r.TierDiscount))
+	}
+	if r.PointsDiscount > 0 {
+		w.Columns(fmt.Sprintf("Tukar %d poin", r.PointsRedeemed), "-"+r.Currency.Number(r.PointsDiscount))
+	}
+	if r.TaxRate > 0 && !r.TaxInclusive {
+		w.Columns("DPP", r.Currency.Number(r.TaxBase))
+		w.Columns(fmt.Sprintf("PPN %s%%", formatQuantity(r.TaxRate)), r.Currency.Number(r.TaxAmount))
+	}
+	if r.DeliveryFee > 0 {
+		w.Columns("Antar-jemput", r.Currency.Number(r.DeliveryFee))
+	}
+	if r.Rounding != 0 {
+		w.Columns("Pembulatan", r.Currency.Number(r.Rounding))
+	}
+	w.Bold(true).Columns("TOTAL", r.Currency.Format(r.Total)).Bold(false)
+	if r.TaxRate > 0 && r.TaxInclusive {
+		w.Columns(fmt.Sprintf("Termasuk PPN %s%%", formatQuantity(r.TaxRate)), r.Currency.Number(r.TaxAmount))
+		w.Columns("DPP", r.Currency.Number(r.TaxBase))
+	}
+	w.Columns("Dibayar", r.Currency.Number(r.Paid+r.Change))
+	if r.Change > 0 {
+		w.Columns("Kembali", r.Currency.Number(r.Change))
+	}
+	if r.Outstanding > 0 {
+		w.Bold(true).Columns("Sisa", r.Currency.Number(r.Outstanding)).Bold(false)
+	}
+	w.Columns("Status", strings.ToUpper(r.PaymentStatus))
+	if r.PointsEarned > 0 {
+		w.Columns("Poin didapat", fmt.Sprintf("%d", r.PointsEarned))
+	}
+	w.Separator()
+
+	if !r.PickupDate.IsZero() {
+		w.Bold(true).Columns("Ambil", r.PickupDate.Format(dateLayout)).Bold(false)
+	}
+	w.Align(escpos.AlignCenter).Feed(1)
+	footer := textLines(r.Footer)
+	if len(footer) == 0 {
+		footer = []string{"Terima kasih"}
+	}
+	for _, line := range footer {
+		w.Wrap(line)
+	}
+
+	return w.Feed(3).Cut().Bytes()
+}
+
+// RenderTags builds one garment tag per item, each cut separately so it can be attached to the laundry.
+func RenderTags(r *Receipt, paper escpos.Paper) []byte {
+	w := escpos.NewWriter(paper)
+
+	for i, item := range r.Items {
+		w.Align(escpos.AlignCenter)
+		w.Bold(true).Size(2, 2).Line(r.InvoiceNumber).Size(1, 1).Bold(false)
+		w.Line(fmt.Sprintf("%d/%d", i+1, len(r.Items)))
+		w.Align(escpos.AlignLeft).Separator()
+		w.Wrap(r.CustomerName)
+		w.Bold(true).Wrap(item.Name()).Bold(false)
+		w.Columns("Jumlah", formatQuantity(item.Quantity)+" "+item.Unit)
+		if item.ExpressTier != "" {
+			w.Bold(true).Wrap("EXPRESS " + strings.ToUpper(item.ExpressTier)).Bold(false)
+		}
+		for _, a := range item.Addons {
+			w.Wrap("+ " + addonLabel(a))
+		}
+		if item.Notes != "" {
+			w.Wrap("* " + item.Notes)
+		}
+		if !r.PickupDate.IsZero() {
+			w.Columns("Ambil", r.PickupDate.Format(dateLayout))
+		}
+		w.Feed(2).Cut()
+	}
+
+	return w.Bytes()
+}
+
+// addonLabel, misal "Hanger x3"; add-on per unit cukup namanya karena ikut kuantitas item.
+func addonLabel(a *order.OrderItemAddon) string {
+	if a.PriceType == addon.PriceFixed && a.Quantity != 1 {
+		return a.Name + " x" + formatQuantity(a.Quantity)
+	}
+	return a.Name
+}
+
+// textLines memecah teks header/footer per baris, baris kosong di awal dan akhir diabaikan.
+func textLines(text string) []string {
+	text = strings.Trim(strings.ReplaceAll(text, "\r\n", "\n"), "\n ")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+func formatQuantity(q float64) string {
+	return strings.Replace(strconv.FormatFloat(q, 'f', -1, 64), ".", ",", 1)
+}
+
+// loadLogo reads the store logo from disk. A missing or unreadable logo is skipped rather than failing the print.
+func loadLogo(path string) image.Image {
+	if path == "" {
+		return nil
+	}
+	f, err := os.Open(strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil
+	}
+	return img
+}
@@ -0,0 +1,107 @@
+package receipt
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"sumunar-pos-core/internal/order"
+	"sumunar-pos-core/pkg/escpos"
+	"sumunar-pos-core/pkg/money"
+)
+
+// listen starts a stand-in printer on a free local port and returns its address and a channel
+// that receives everything written over the first connection.
+func listen(t *testing.T) (string, <-chan []byte) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			close(received)
+			return
+		}
+		defer conn.Close()
+		data, _ := io.ReadAll(conn)
+		received <- data
+	}()
+	return ln.Addr().String(), received
+}
+
+func sampleReceipt() *Receipt {
+	return &Receipt{
+		StoreName:     "Sumunar Laundry",
+		StoreAddress:  "Jl. Kaliurang 10",
+		Currency:      money.DefaultCurrency,
+		InvoiceNumber: "INV-261018-001",
+		CustomerName:  "Budi",
+		Items: []*order.OrderItem{{
+			ProductName:     "Kiloan",
+			ServiceName:     "Cuci Setrika",
+			Unit:            "kg",
+			Quantity:        2.5,
+			ChargedQuantity: 2.5,
+			UnitPrice:       money.Rupiah(10000),
+			TotalPrice:      money.Rupiah(25000),
+		}},
+		Subtotal:      money.Rupiah(25000),
+		Total:         money.Rupiah(25000),
+		Paid:          money.Rupiah(25000),
+		PaymentStatus: "paid",
+		CreatedAt:     time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC),
+	}
+}
+
+func TestPrintReceipt(t *testing.T) {
+	for _, paper := range []escpos.Paper{escpos.Paper58, escpos.Paper80} {
+		t.Run(fmt.Sprintf("%dmm", paper), func(t *testing.T) {
+			addr, received := listen(t)
+			data := RenderReceipt(sampleReceipt(), paper)
+
+			printer := escpos.Printer{Addr: addr, Timeout: time.Second}
+			if err := printer.Print(context.Background(), data); err != nil {
+				t.Fatal(err)
+			}
+
+			var got []byte
+			select {
+			case got = <-received:
+			case <-time.After(2 * time.Second):
+				t.Fatal("printer received nothing")
+			}
+			if !bytes.Equal(got, data) {
+				t.Fatalf("printer received %d bytes, want the %d rendered bytes", len(got), len(data))
+			}
+
+			init := []byte{0x1B, '@'}
+			if !bytes.HasPrefix(got, init) {
+				t.Errorf("job starts with % X, want init % X", got[:2], init)
+			}
+			feedCut := []byte{0x1B, 'd', 3, 0x1D, 'V', 'B', 3}
+			if !bytes.HasSuffix(got, feedCut) {
+				t.Errorf("job ends with % X, want feed and cut % X", got[len(got)-len(feedCut):], feedCut)
+			}
+
+			cols := paper.Columns()
+			separator := strings.Repeat("-", cols) + "\n"
+			if !bytes.Contains(got, []byte(separator)) {
+				t.Errorf("no %d column separator in job", cols)
+			}
+			total := "TOTAL" + strings.Repeat(" ", cols-len("TOTAL")-len("Rp 25.000")) + "Rp 25.000\n"
+			if !bytes.Contains(got, []byte(total)) {
+				t.Errorf("total line %q not in job", total)
+			}
+		})
+	}
+}
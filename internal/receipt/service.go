@@ -0,0 +1,129 @@
+package receipt
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sumunar-pos-core/internal/customer"
+	"sumunar-pos-core/internal/order"
+	"sumunar-pos-core/internal/store"
+	"sumunar-pos-core/pkg/escpos"
+)
+
+type ReceiptService interface {
+	Render(ctx context.Context, orderID, receiptType, paper string) ([]byte, *order.Order, error)
+	Print(ctx context.Context, orderID, receiptType, paper string) error
+}
+
+type service struct {
+	orderRepo    order.OrderRepository
+	customerRepo customer.CustomerRepository
+	storeRepo    store.StoreRepository
+	printTimeout time.Duration
+}
+
+func NewService(orderRepo order.OrderRepository, customerRepo customer.CustomerRepository,
+	storeRepo store.StoreRepository, printTimeout time.Duration) ReceiptService {
+	return &service{orderRepo, customerRepo, storeRepo, printTimeout}
+}
+
+// Render returns the ESC/POS stream for an order. An empty paper uses the store's configured width.
+func (s *service) Render(ctx context.Context, orderID, receiptType, paper string) ([]byte, *order.Order, error) {
+	data, o, _, err := s.render(ctx, orderID, receiptType, paper)
+	return data, o, err
+}
+
+// Print sends the rendered stream to the store's network printer.
+func (s *service) Print(ctx context.Context, orderID, receiptType, paper string) error {
+	data, _, settings, err := s.render(ctx, orderID, receiptType, paper)
+	if err != nil {
+		return err
+	}
+	if settings.PrinterAddress == "" {
+		return ErrPrinterNotConfigured
+	}
+
+	printer := escpos.Printer{Addr: settings.PrinterAddress, Timeout: s.printTimeout}
+	if err := printer.Print(ctx, data); err != nil {
+		return fmt.Errorf("%w: %v", ErrPrintFailed, err)
+	}
+	return nil
+}
+
+func (s *service) render(ctx context.Context, orderID, receiptType, paper string) ([]byte, *order.Order, *store.StoreSettings, error) {
+	if receiptType == "" {
+		receiptType = TypeReceipt
+	}
+	if receiptType != TypeReceipt && receiptType != TypeTag {
+		return nil, nil, nil, ErrInvalidReceiptType
+	}
+
+	o, items, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	st, err := s.storeRepo.FindByID(ctx, o.StoreID)
+	if err != nil {
+		return nil, nil, nil, store.ErrStoreNotFound
+	}
+	settings, err := s.storeRepo.FindSettings(ctx, o.StoreID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	r := &Receipt{
+		StoreName:      st.Name,
+		StoreAddress:   st.Address,
+		InvoiceNumber:  o.InvoiceNumber,
+		Items:          items,
+		Discount:       o.Discount,
+		Subtotal:       o.Subtotal,
+		DiscountAmount: o.DiscountAmount,
+		PromoCode:      o.PromoCode,
+		PromoDiscount:  o.PromoDiscount,
+		TierName:       o.TierName,
+		TierDiscount:   o.TierDiscountAmount,
+		PointsRedeemed: o.PointsRedeemed,
+		PointsDiscount: o.PointsDiscount,
+		PointsEarned:   o.PointsEarned,
+		TaxRate:        o.TaxRate,
+		TaxInclusive:   o.TaxInclusive,
+		TaxBase:        o.TaxBase,
+		TaxAmount:      o.TaxAmount,
+		DeliveryFee:    o.DeliveryFee,
+		Rounding:       o.Rounding,
+		Total:          o.TotalPrice,
+		Paid:           o.PaidAmount,
+		Change:         o.Change,
+		Outstanding:    order.OutstandingAmount(o.TotalPrice, o.PaidAmount),
+		PaymentStatus:  order.PaymentStatus(o.TotalPrice, o.PaidAmount),
+		Header:         settings.ReceiptHeader,
+		Footer:         settings.ReceiptFooter,
+		Currency:       settings.Currency,
+		CreatedAt:      o.CreatedAt.In(settings.Location()),
+	}
+	if !o.PickupDate.IsZero() {
+		r.PickupDate = o.PickupDate.In(settings.Location())
+	}
+	if st.Phone != nil {
+		r.StorePhone = *st.Phone
+	}
+	if st.Logo != nil {
+		r.LogoPath = *st.Logo
+	}
+
+	if c, err := s.customerRepo.FindByID(ctx, o.CustomerID); err == nil {
+		r.CustomerName = c.Name
+		r.CustomerPhone = c.Phone
+	}
+
+	if paper == "" {
+		paper = settings.ReceiptPaper
+	}
+	if receiptType == TypeTag {
+		return RenderTags(r, escpos.ParsePaper(paper)), o, settings, nil
+	}
+	return RenderReceipt(r, escpos.ParsePaper(paper)), o, settings, nil
+}
@@ -0,0 +1,22 @@
+package dto
+
+import "sumunar-pos-core/pkg/money"
+
+type ReconciliationRequest struct {
+	StoreID      string                      `json:"store_id" validate:"required"`
+	BusinessDate string                      `json:"business_date" validate:"required"` // YYYY-MM-DD
+	Lines        []ReconciliationLineRequest `json:"lines" validate:"required,min=1,dive"`
+	Notes        string                      `json:"notes"`
+}
+
+type ReconciliationLineRequest struct {
+	PaymentMethodID string       `json:"payment_method_id" validate:"required"`
+	ActualAmount    money.Amount `json:"actual_amount" validate:"gte=0"` // hasil hitung kas / mutasi rekening
+}
+
+// ListRequest is the query string of the list endpoint.
+type ListRequest struct {
+	StoreID string `query:"store_id" validate:"omitempty,uuid"`
+	Limit   int    `query:"limit"`
+	Offset  int    `query:"offset"`
+}
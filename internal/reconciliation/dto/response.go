@@ -0,0 +1,30 @@
+package dto
+
+import "sumunar-pos-core/pkg/money"
+
+type ReconciliationResponse struct {
+	ID            string                       `json:"id,omitempty"` // kosong untuk pratinjau
+	StoreID       string                       `json:"store_id"`
+	BusinessDate  string                       `json:"business_date"` // YYYY-MM-DD
+	TotalExpected money.Amount                 `json:"total_expected"`
+	TotalActual   money.Amount                 `json:"total_actual"`
+	TotalVariance money.Amount                 `json:"total_variance"`
+	Notes         string                       `json:"notes"`
+	CreatedAt     string                       `json:"created_at,omitempty"`
+	CreatedBy     string                       `json:"created_by,omitempty"`
+	Lines         []ReconciliationLineResponse `json:"lines"`
+}
+
+type ReconciliationLineResponse struct {
+	PaymentMethodID string       `json:"payment_method_id"`
+	Method          string       `json:"method"`
+	MethodName      string       `json:"method_name"`
+	PaymentCount    int          `json:"payment_count"`
+	Expected        money.Amount `json:"expected"`
+	Actual          money.Amount `json:"actual"`
+	Variance        money.Amount `json:"variance"`
+}
+
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
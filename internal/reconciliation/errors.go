@@ -0,0 +1,11 @@
+package reconciliation
+
+import "errors"
+
+var (
+	ErrReconciliationNotFound = errors.New("reconciliation not found")
+	ErrAlreadyReconciled      = errors.New("business day is already reconciled for this store")
+	ErrInvalidBusinessDate    = errors.New("business_date must be formatted as YYYY-MM-DD")
+	ErrUnknownPaymentMethod   = errors.New("payment method does not belong to this store")
+	ErrStoreNotFound          = errors.New("store not found")
+)
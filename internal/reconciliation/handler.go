@@ -0,0 +1,95 @@
+package reconciliation
+
+import (
+	"errors"
+	"net/http"
+
+	"sumunar-pos-core/internal/reconciliation/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	service ReconciliationService
+}
+
+func NewHandler(service ReconciliationService) *Handler {
+	return &Handler{service}
+}
+
+// Expected returns the per-method totals for ?store_id=&date=YYYY-MM-DD without saving anything.
+func (h *Handler) Expected(c echo.Context) error {
+	res, err := h.service.Expected(c.Request().Context(), c.QueryParam("store_id"), c.QueryParam("date"))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) Create(c echo.Context) error {
+	var req dto.ReconciliationRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Get("user_id").(string)
+
+	res, err := h.service.Create(c.Request().Context(), &req, userID)
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, res)
+}
+
+func (h *Handler) FindByID(c echo.Context) error {
+	res, err := h.service.FindByID(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(errorStatus(err), err.Error())
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) FindAll(c echo.Context) error {
+	var req dto.ListRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	storeID, limit, offset := req.StoreID, req.Limit, req.Offset
+	if limit <= 0 {
+		limit = 20
+	}
+
+	recs, total, err := h.service.FindAll(c.Request().Context(), storeID, limit, offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"data":   recs,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrReconciliationNotFound), errors.Is(err, ErrStoreNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidBusinessDate), errors.Is(err, ErrUnknownPaymentMethod):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrAlreadyReconciled):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
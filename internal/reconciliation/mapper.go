@@ -0,0 +1,115 @@
+package reconciliation
+
+import (
+	"time"
+
+	"sumunar-pos-core/internal/reconciliation/dto"
+	"sumunar-pos-core/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+const dateLayout = "2006-01-02"
+
+// ToReconciliationModel matches the counted amounts in req against the expected amounts
+// and computes the variance per payment method. Methods without a counted amount are treated as zero.
+func ToReconciliationModel(req *dto.ReconciliationRequest, businessDate time.Time, expected []*ExpectedAmount, createdBy string) (*Reconciliation, []*ReconciliationLine, error) {
+	rec := &Reconciliation{
+		ID:           uuid.New().String(),
+		StoreID:      req.StoreID,
+		BusinessDate: businessDate,
+		Notes:        req.Notes,
+		CreatedAt:    time.Now(),
+		CreatedBy:    createdBy,
+	}
+
+	actual := make(map[string]money.Amount, len(req.Lines))
+	for _, l := range req.Lines {
+		actual[l.PaymentMethodID] += l.ActualAmount
+	}
+
+	lines := make([]*ReconciliationLine, 0, len(expected))
+	for _, e := range expected {
+		counted := actual[e.PaymentMethodID]
+		delete(actual, e.PaymentMethodID)
+
+		line := &ReconciliationLine{
+			ID:               uuid.New().String(),
+			ReconciliationID: rec.ID,
+			PaymentMethodID:  e.PaymentMethodID,
+			Method:           e.Method,
+			MethodName:       e.MethodName,
+			PaymentCount:     e.PaymentCount,
+			Expected:         e.Amount,
+			Actual:           counted,
+			Variance:         counted - e.Amount,
+		}
+		lines = append(lines, line)
+
+		rec.TotalExpected += line.Expected
+		rec.TotalActual += line.Actual
+	}
+	rec.TotalVariance = rec.TotalActual - rec.TotalExpected
+
+	if len(actual) > 0 {
+		return nil, nil, ErrUnknownPaymentMethod
+	}
+
+	return rec, lines, nil
+}
+
+func ToExpectedResponse(storeID string, businessDate time.Time, expected []*ExpectedAmount) *dto.ReconciliationResponse {
+	res := &dto.ReconciliationResponse{
+		StoreID:      storeID,
+		BusinessDate: businessDate.Format(dateLayout),
+		Lines:        make([]dto.ReconciliationLineResponse, 0, len(expected)),
+	}
+	for _, e := range expected {
+		res.Lines = append(res.Lines, dto.ReconciliationLineResponse{
+			PaymentMethodID: e.PaymentMethodID,
+			Method:          e.Method,
+			MethodName:      e.MethodName,
+			PaymentCount:    e.PaymentCount,
+			Expected:        e.Amount,
+			Variance:        -e.Amount,
+		})
+		res.TotalExpected += e.Amount
+	}
+	res.TotalVariance = -res.TotalExpected
+	return res
+}
+
+func ToReconciliationResponse(rec *Reconciliation, lines []*ReconciliationLine) *dto.ReconciliationResponse {
+	res := &dto.ReconciliationResponse{
+		ID:            rec.ID,
+		StoreID:       rec.StoreID,
+		BusinessDate:  rec.BusinessDate.Format(dateLayout),
+		TotalExpected: rec.TotalExpected,
+		TotalActual:   rec.TotalActual,
+		TotalVariance: rec.TotalVariance,
+		Notes:         rec.Notes,
+		CreatedAt:     rec.CreatedAt.Format(time.RFC3339),
+		CreatedBy:     rec.CreatedBy,
+		Lines:         make([]dto.ReconciliationLineResponse, 0, len(lines)),
+	}
+	for _, l := range lines {
+		res.Lines = append(res.Lines, dto.ReconciliationLineResponse{
+			PaymentMethodID: l.PaymentMethodID,
+			Method:          l.Method,
+			MethodName:      l.MethodName,
+			PaymentCount:    l.PaymentCount,
+			Expected:        l.Expected,
+			Actual:          l.Actual,
+			Variance:        l.Variance,
+		})
+	}
+	return res
+}
+
+func ToReconciliationListResponse(recs []*Reconciliation) []*dto.ReconciliationResponse {
+	res := make([]*dto.ReconciliationResponse, 0, len(recs))
+	for _, r := range recs {
+		res = append(res, ToReconciliationResponse(r, nil))
+	}
+	return res
+}
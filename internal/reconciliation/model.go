@@ -0,0 +1,41 @@
+package reconciliation
+
+import (
+	"time"
+
+	"sumunar-pos-core/pkg/money"
+)
+
+// Reconciliation is the end-of-day count of one store's takings, per payment method.
+type Reconciliation struct {
+	ID            string       `json:"id"`
+	StoreID       string       `json:"store_id"`
+	BusinessDate  time.Time    `json:"business_date"`
+	TotalExpected money.Amount `json:"total_expected"`
+	TotalActual   money.Amount `json:"total_actual"`
+	TotalVariance money.Amount `json:"total_variance"` // actual - expected, negative berarti kurang
+	Notes         string       `json:"notes"`
+	CreatedAt     time.Time    `json:"created_at"`
+	CreatedBy     string       `json:"created_by"`
+}
+
+type ReconciliationLine struct {
+	ID               string       `json:"id"`
+	ReconciliationID string       `json:"reconciliation_id"`
+	PaymentMethodID  string       `json:"payment_method_id"`
+	Method           string       `json:"method"` // kode metode saat rekonsiliasi
+	MethodName       string       `json:"method_name"`
+	PaymentCount     int          `json:"payment_count"`
+	Expected         money.Amount `json:"expected"`
+	Actual           money.Amount `json:"actual"`
+	Variance         money.Amount `json:"variance"`
+}
+
+// ExpectedAmount is what the order payments say a payment method should hold for a day.
+type ExpectedAmount struct {
+	PaymentMethodID string
+	Method          string
+	MethodName      string
+	PaymentCount    int
+	Amount          money.Amount
+}
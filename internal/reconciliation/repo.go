@@ -0,0 +1,217 @@
+package reconciliation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"sumunar-pos-core/pkg/db"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type ReconciliationRepository interface {
+	ExpectedByMethod(ctx context.Context, storeID string, from, to time.Time) ([]*ExpectedAmount, error)
+	Create(ctx context.Context, tx db.DBTX, rec *Reconciliation, lines []*ReconciliationLine) error
+	FindByID(ctx context.Context, id string) (*Reconciliation, []*ReconciliationLine, error)
+	FindAll(ctx context.Context, storeID string, limit, offset int) ([]*Reconciliation, int, error)
+}
+
+type reconciliationRepo struct {
+	db db.DBTX
+}
+
+func NewReconciliationRepository(db db.DBTX) ReconciliationRepository {
+	return &reconciliationRepo{db}
+}
+
+// ExpectedByMethod sums the amounts kept from order payments (tendered minus change) received
+// in [from, to), per payment method of the store. Inactive methods only show up when they were used.
+// Sales of prepaid packages count as money received; order payments taken from a prepaid wallet do not,
+// that money came in when the package was sold.
+func (r *reconciliationRepo) ExpectedByMethod(ctx context.Context, storeID string, from, to time.Time) ([]*ExpectedAmount, error) {
+	query := `
+		SELECT pm.id, pm.code, pm.name, COUNT(p.id), COALESCE(SUM(p.amount), 0)
+		FROM payment_methods pm
+		LEFT JOIN (
+			SELECT id, payment_method_id, amount - change AS amount FROM order_payments
+			WHERE received_at >= $2 AND received_at < $3
+			UNION ALL
+			SELECT id, payment_method_id, price FROM prepaid_wallets
+			WHERE store_id = $1 AND purchased_at >= $2 AND purchased_at < $3
+		) p ON p.payment_method_id = pm.id
+		WHERE pm.store_id = $1 AND pm.type <> 'prepaid'
+		GROUP BY pm.id, pm.code, pm.name, pm.is_active
+		HAVING pm.is_active OR COUNT(p.id) > 0
+		ORDER BY pm.name
+	`
+	rows, err := r.db.Query(ctx, query, storeID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	expected := []*ExpectedAmount{}
+	for rows.Next() {
+		var e ExpectedAmount
+		if err := rows.Scan(
+			&e.PaymentMethodID,
+			&e.Method,
+			&e.MethodName,
+			&e.PaymentCount,
+			&e.Amount,
+		); err != nil {
+			return nil, err
+		}
+		expected = append(expected, &e)
+	}
+
+	return expected, rows.Err()
+}
+
+func (r *reconciliationRepo) Create(ctx context.Context, tx db.DBTX, rec *Reconciliation, lines []*ReconciliationLine) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO reconciliations (id, store_id, business_date, total_expected, total_actual, total_variance, notes, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`,
+		rec.ID,
+		rec.StoreID,
+		rec.BusinessDate,
+		rec.TotalExpected,
+		rec.TotalActual,
+		rec.TotalVariance,
+		rec.Notes,
+		rec.CreatedAt,
+		rec.CreatedBy,
+	)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrAlreadyReconciled
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, l := range lines {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO reconciliation_lines (id, reconciliation_id, payment_method_id, method, method_name, payment_count, expected, actual, variance)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		`,
+			l.ID,
+			l.ReconciliationID,
+			l.PaymentMethodID,
+			l.Method,
+			l.MethodName,
+			l.PaymentCount,
+			l.Expected,
+			l.Actual,
+			l.Variance,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *reconciliationRepo) FindByID(ctx context.Context, id string) (*Reconciliation, []*ReconciliationLine, error) {
+	query := `
+		SELECT id, store_id, business_date, total_expected, total_actual, total_variance, notes, created_at, created_by
+		FROM reconciliations
+		WHERE id = $1
+	`
+	var rec Reconciliation
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&rec.ID,
+		&rec.StoreID,
+		&rec.BusinessDate,
+		&rec.TotalExpected,
+		&rec.TotalActual,
+		&rec.TotalVariance,
+		&rec.Notes,
+		&rec.CreatedAt,
+		&rec.CreatedBy,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, ErrReconciliationNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, reconciliation_id, payment_method_id, method, method_name, payment_count, expected, actual, variance
+		FROM reconciliation_lines
+		WHERE reconciliation_id = $1
+		ORDER BY method_name
+	`, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	lines := []*ReconciliationLine{}
+	for rows.Next() {
+		var l ReconciliationLine
+		if err := rows.Scan(
+			&l.ID,
+			&l.ReconciliationID,
+			&l.PaymentMethodID,
+			&l.Method,
+			&l.MethodName,
+			&l.PaymentCount,
+			&l.Expected,
+			&l.Actual,
+			&l.Variance,
+		); err != nil {
+			return nil, nil, err
+		}
+		lines = append(lines, &l)
+	}
+
+	return &rec, lines, rows.Err()
+}
+
+func (r *reconciliationRepo) FindAll(ctx context.Context, storeID string, limit, offset int) ([]*Reconciliation, int, error) {
+	query := `
+		SELECT id, store_id, business_date, total_expected, total_actual, total_variance, notes, created_at, created_by
+		FROM reconciliations
+		WHERE ($1::uuid IS NULL OR store_id = $1)
+		ORDER BY business_date DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Query(ctx, query, db.NullID(storeID), limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var recs []*Reconciliation
+	for rows.Next() {
+		var rec Reconciliation
+		if err := rows.Scan(
+			&rec.ID,
+			&rec.StoreID,
+			&rec.BusinessDate,
+			&rec.TotalExpected,
+			&rec.TotalActual,
+			&rec.TotalVariance,
+			&rec.Notes,
+			&rec.CreatedAt,
+			&rec.CreatedBy,
+		); err != nil {
+			return nil, 0, err
+		}
+		recs = append(recs, &rec)
+	}
+
+	var total int
+	err = r.db.QueryRow(ctx, `SELECT COUNT(*) FROM reconciliations WHERE ($1::uuid IS NULL OR store_id = $1)`, db.NullID(storeID)).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return recs, total, nil
+}
@@ -0,0 +1,102 @@
+package reconciliation
+
+import (
+	"context"
+	"time"
+
+	"sumunar-pos-core/internal/reconciliation/dto"
+	"sumunar-pos-core/internal/store"
+	"sumunar-pos-core/pkg/db"
+)
+
+type ReconciliationService interface {
+	Expected(ctx context.Context, storeID, businessDate string) (*dto.ReconciliationResponse, error)
+	Create(ctx context.Context, req *dto.ReconciliationRequest, createdBy string) (*dto.ReconciliationResponse, error)
+	FindByID(ctx context.Context, id string) (*dto.ReconciliationResponse, error)
+	FindAll(ctx context.Context, storeID string, limit, offset int) ([]*dto.ReconciliationResponse, int, error)
+}
+
+type service struct {
+	repo      ReconciliationRepository
+	storeRepo store.StoreRepository
+	db        db.TxBeginner
+}
+
+func NewService(repo ReconciliationRepository, storeRepo store.StoreRepository, db db.TxBeginner) ReconciliationService {
+	return &service{repo, storeRepo, db}
+}
+
+// Expected previews the amounts a cashier should have for each payment method at the end of the day.
+func (s *service) Expected(ctx context.Context, storeID, businessDate string) (*dto.ReconciliationResponse, error) {
+	date, expected, err := s.expected(ctx, storeID, businessDate)
+	if err != nil {
+		return nil, err
+	}
+	return ToExpectedResponse(storeID, date, expected), nil
+}
+
+func (s *service) Create(ctx context.Context, req *dto.ReconciliationRequest, createdBy string) (*dto.ReconciliationResponse, error) {
+	date, expected, err := s.expected(ctx, req.StoreID, req.BusinessDate)
+	if err != nil {
+		return nil, err
+	}
+
+	rec, lines, err := ToReconciliationModel(req, date, expected, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.repo.Create(ctx, tx, rec, lines); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return ToReconciliationResponse(rec, lines), nil
+}
+
+func (s *service) FindByID(ctx context.Context, id string) (*dto.ReconciliationResponse, error) {
+	rec, lines, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return ToReconciliationResponse(rec, lines), nil
+}
+
+func (s *service) FindAll(ctx context.Context, storeID string, limit, offset int) ([]*dto.ReconciliationResponse, int, error) {
+	recs, total, err := s.repo.FindAll(ctx, storeID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	return ToReconciliationListResponse(recs), total, nil
+}
+
+func (s *service) expected(ctx context.Context, storeID, businessDate string) (time.Time, []*ExpectedAmount, error) {
+	if _, err := s.storeRepo.FindByID(ctx, storeID); err != nil {
+		return time.Time{}, nil, ErrStoreNotFound
+	}
+	settings, err := s.storeRepo.FindSettings(ctx, storeID)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	// pembayaran dihitung per hari bisnis toko, bukan per tanggal kalender server
+	day, err := settings.ParseBusinessDay(businessDate)
+	if err != nil {
+		return time.Time{}, nil, ErrInvalidBusinessDate
+	}
+
+	expected, err := s.repo.ExpectedByMethod(ctx, storeID, day.Start, day.End)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	return day.Date, expected, nil
+}
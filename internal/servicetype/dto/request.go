@@ -1,5 +1,22 @@
 package dto
 
 type ServiceRequest struct {
-	Name string `json:"name" validate:"required"`
+	Name            string        `json:"name" validate:"required"`
+	StoreID         string        `json:"store_id" validate:"required,uuid"`
+	TurnaroundHours int           `json:"turnaround_hours" validate:"gte=0"`
+	ExpressTiers    []ExpressTier `json:"express_tiers" validate:"omitempty,dive"`
+	IsActive        *bool         `json:"is_active"`
+}
+
+type ExpressTier struct {
+	Name      string  `json:"name" validate:"required,max=30"` // misal kilat, express
+	Hours     int     `json:"hours" validate:"gte=0"`
+	Surcharge float64 `json:"surcharge" validate:"gte=0"` // persen, misal 50
+}
+
+// ListRequest is the query string of the list endpoint.
+type ListRequest struct {
+	StoreID string `query:"store_id" validate:"omitempty,uuid"`
+	Limit   int    `query:"limit"`
+	Offset  int    `query:"offset"`
 }
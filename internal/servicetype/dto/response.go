@@ -1,8 +1,12 @@
 package dto
 
 type ServiceResponse struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID              string        `json:"id"`
+	Name            string        `json:"name"`
+	StoreID         string        `json:"store_id"`
+	TurnaroundHours int           `json:"turnaround_hours"`
+	ExpressTiers    []ExpressTier `json:"express_tiers"`
+	IsActive        bool          `json:"is_active"`
 }
 
 type ErrorResponse struct {
@@ -0,0 +1,8 @@
+package servicetype
+
+import "errors"
+
+var (
+	ErrStoreChange        = errors.New("service type cannot be moved to another store, create it in that store instead")
+	ErrInvalidExpressTier = errors.New("express tier must be faster than the standard turnaround and have a unique name")
+)
@@ -1,6 +1,8 @@
 package servicetype
 
 import (
+	"time"
+
 	"sumunar-pos-core/internal/base"
 	"sumunar-pos-core/internal/servicetype/dto"
 
@@ -8,20 +10,46 @@ import (
 )
 
 func ToServiceTypeModel(req *dto.ServiceRequest, createdBy string) *ServiceType {
-	return &ServiceType{
-		ID:   uuid.New().String(),
-		Name: req.Name,
+	now := time.Now()
+	service := &ServiceType{
+		ID:              uuid.New().String(),
+		Name:            req.Name,
+		StoreID:         req.StoreID,
+		TurnaroundHours: req.TurnaroundHours,
+		ExpressTiers:    toExpressTiers(req.ExpressTiers),
+		IsActive:        true,
 		BaseModel: base.BaseModel{
+			CreatedAt: now,
 			CreatedBy: createdBy,
 		},
 	}
+	if req.IsActive != nil {
+		service.IsActive = *req.IsActive
+	}
+	return service
 }
 
 func ToServiceTypeResponse(service *ServiceType) *dto.ServiceResponse {
-	return &dto.ServiceResponse{
-		ID:   service.ID,
-		Name: service.Name,
+	res := &dto.ServiceResponse{
+		ID:              service.ID,
+		Name:            service.Name,
+		StoreID:         service.StoreID,
+		TurnaroundHours: service.TurnaroundHours,
+		ExpressTiers:    make([]dto.ExpressTier, 0, len(service.ExpressTiers)),
+		IsActive:        service.IsActive,
+	}
+	for _, t := range service.ExpressTiers {
+		res.ExpressTiers = append(res.ExpressTiers, dto.ExpressTier(t))
+	}
+	return res
+}
+
+func toExpressTiers(req []dto.ExpressTier) []ExpressTier {
+	tiers := make([]ExpressTier, 0, len(req))
+	for _, t := range req {
+		tiers = append(tiers, ExpressTier(t))
 	}
+	return tiers
 }
 
 func ToServiceTypeListResponse(services []*ServiceType) []*dto.ServiceResponse {
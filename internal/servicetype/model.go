@@ -1,12 +1,34 @@
 package servicetype
 
 import (
+	"strings"
+
 	"sumunar-pos-core/internal/base"
 )
 
 type ServiceType struct {
-	ID       string `db:"id"`
-	Name     string `db:"name"`
-	IsActive bool   `db:"is_active"`
+	ID              string        `db:"id"`
+	Name            string        `db:"name"`
+	StoreID         string        `db:"store_id"`
+	TurnaroundHours int           `db:"turnaround_hours"` // lama pengerjaan standar, 0 = pakai lead time default toko
+	ExpressTiers    []ExpressTier `db:"express_tiers"`
+	IsActive        bool          `db:"is_active"`
 	base.BaseModel
 }
+
+// ExpressTier is a faster turnaround the customer can pick for a surcharge, e.g. "kilat" in 6 hours at +50%.
+type ExpressTier struct {
+	Name      string  `json:"name"`
+	Hours     int     `json:"hours"`
+	Surcharge float64 `json:"surcharge"` // persen dari harga layanan
+}
+
+// Tier returns the express tier with the given name (case-insensitive).
+func (s *ServiceType) Tier(name string) (*ExpressTier, bool) {
+	for i := range s.ExpressTiers {
+		if strings.EqualFold(s.ExpressTiers[i].Name, name) {
+			return &s.ExpressTiers[i], true
+		}
+	}
+	return nil, false
+}
@@ -8,7 +8,7 @@ import (
 type ServiceRepository interface {
 	Create(ctx context.Context, service *ServiceType) error
 	FindByID(ctx context.Context, id string) (*ServiceType, error)
-	FindAll(ctx context.Context, limit, offset int) ([]*ServiceType, int, error)
+	FindAll(ctx context.Context, storeID string, limit, offset int) ([]*ServiceType, int, error)
 	Update(ctx context.Context, service *ServiceType) error
 	Delete(ctx context.Context, id string) error
 }
@@ -23,12 +23,15 @@ func NewServiceRepository(db db.DBTX) ServiceRepository {
 
 func (r *serviceRepo) Create(ctx context.Context, serviceType *ServiceType) error {
 	query := `
-		INSERT INTO service_types (id, name, is_active, created_at, created_by, updated_at, updated_by)
-		VALUES ($1, $2, $3, $4, $5, $4, $5)
+		INSERT INTO service_types (id, name, store_id, turnaround_hours, express_tiers, is_active, created_at, created_by, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $7, $8)
 	`
 	_, err := r.db.Exec(ctx, query,
 		serviceType.ID,
 		serviceType.Name,
+		serviceType.StoreID,
+		serviceType.TurnaroundHours,
+		serviceType.ExpressTiers,
 		serviceType.IsActive,
 		serviceType.CreatedAt,
 		serviceType.CreatedBy,
@@ -37,13 +40,16 @@ func (r *serviceRepo) Create(ctx context.Context, serviceType *ServiceType) erro
 }
 
 func (r *serviceRepo) FindByID(ctx context.Context, id string) (*ServiceType, error) {
-	query := `SELECT id, name, is_active, created_at, created_by, updated_at, updated_by FROM service_types WHERE id = $1`
+	query := `SELECT id, name, store_id, turnaround_hours, express_tiers, is_active, created_at, created_by, updated_at, updated_by FROM service_types WHERE id = $1`
 	row := r.db.QueryRow(ctx, query, id)
 
 	var service ServiceType
 	err := row.Scan(
 		&service.ID,
 		&service.Name,
+		&service.StoreID,
+		&service.TurnaroundHours,
+		&service.ExpressTiers,
 		&service.IsActive,
 		&service.CreatedAt,
 		&service.CreatedBy,
@@ -56,9 +62,14 @@ func (r *serviceRepo) FindByID(ctx context.Context, id string) (*ServiceType, er
 	return &service, nil
 }
 
-func (r *serviceRepo) FindAll(ctx context.Context, limit, offset int) ([]*ServiceType, int, error) {
-	query := `SELECT id, name, is_active, created_at, created_by, updated_at, updated_by FROM service_types LIMIT $1 OFFSET $2`
-	rows, err := r.db.Query(ctx, query, limit, offset)
+func (r *serviceRepo) FindAll(ctx context.Context, storeID string, limit, offset int) ([]*ServiceType, int, error) {
+	query := `
+		SELECT id, name, store_id, turnaround_hours, express_tiers, is_active, created_at, created_by, updated_at, updated_by FROM service_types
+		WHERE ($1::uuid IS NULL OR store_id = $1)
+		ORDER BY name
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Query(ctx, query, db.NullID(storeID), limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -70,6 +81,9 @@ func (r *serviceRepo) FindAll(ctx context.Context, limit, offset int) ([]*Servic
 		if err := rows.Scan(
 			&s.ID,
 			&s.Name,
+			&s.StoreID,
+			&s.TurnaroundHours,
+			&s.ExpressTiers,
 			&s.IsActive,
 			&s.CreatedAt,
 			&s.CreatedBy,
@@ -83,7 +97,7 @@ func (r *serviceRepo) FindAll(ctx context.Context, limit, offset int) ([]*Servic
 
 	// total count
 	var total int
-	err = r.db.QueryRow(ctx, `SELECT COUNT(*) FROM service_types`).Scan(&total)
+	err = r.db.QueryRow(ctx, `SELECT COUNT(*) FROM service_types WHERE ($1::uuid IS NULL OR store_id = $1)`, db.NullID(storeID)).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -93,12 +107,15 @@ func (r *serviceRepo) FindAll(ctx context.Context, limit, offset int) ([]*Servic
 
 func (r *serviceRepo) Update(ctx context.Context, service *ServiceType) error {
 	query := `
-		UPDATE service_types SET name = $1, is_active = $2,
-		updated_at = $3, updated_by = $4
-		WHERE id = $5
+		UPDATE service_types SET name = $1, store_id = $2, turnaround_hours = $3, express_tiers = $4, is_active = $5,
+		updated_at = $6, updated_by = $7
+		WHERE id = $8
 	`
 	_, err := r.db.Exec(ctx, query,
 		service.Name,
+		service.StoreID,
+		service.TurnaroundHours,
+		service.ExpressTiers,
 		service.IsActive,
 		service.UpdatedAt,
 		service.UpdatedBy,
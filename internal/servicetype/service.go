@@ -3,6 +3,7 @@ package servicetype
 import (
 	"context"
 	"log"
+	"strings"
 	"sumunar-pos-core/internal/servicetype/dto"
 	"sumunar-pos-core/middleware"
 	"sumunar-pos-core/pkg/db"
@@ -12,7 +13,7 @@ import (
 type ServiceTypeService interface {
 	Create(ctx context.Context, req *dto.ServiceRequest) (*ServiceType, error)
 	FindByID(ctx context.Context, id string) (*ServiceType, error)
-	FindAll(ctx context.Context, limit, offset int) ([]*ServiceType, int, error)
+	FindAll(ctx context.Context, storeID string, limit, offset int) ([]*ServiceType, int, error)
 	Update(ctx context.Context, id string, req *dto.ServiceRequest) (*ServiceType, error)
 	Delete(ctx context.Context, id string) error
 }
@@ -33,6 +34,9 @@ func (s *service) Create(ctx context.Context, req *dto.ServiceRequest) (*Service
 	}
 
 	service := ToServiceTypeModel(req, userID)
+	if err := checkTiers(service); err != nil {
+		return nil, err
+	}
 
 	if err := s.repo.Create(ctx, service); err != nil {
 		return nil, err
@@ -45,8 +49,8 @@ func (s *service) FindByID(ctx context.Context, id string) (*ServiceType, error)
 	return s.repo.FindByID(ctx, id)
 }
 
-func (s *service) FindAll(ctx context.Context, limit, offset int) ([]*ServiceType, int, error) {
-	return s.repo.FindAll(ctx, limit, offset)
+func (s *service) FindAll(ctx context.Context, storeID string, limit, offset int) ([]*ServiceType, int, error) {
+	return s.repo.FindAll(ctx, storeID, limit, offset)
 }
 
 func (s *service) Update(ctx context.Context, id string, req *dto.ServiceRequest) (*ServiceType, error) {
@@ -60,7 +64,20 @@ func (s *service) Update(ctx context.Context, id string, req *dto.ServiceRequest
 		return nil, err
 	}
 
+	// product service ikut toko produk dan jenis layanannya, jadi tokonya tidak bisa dipindah
+	if req.StoreID != service.StoreID {
+		return nil, ErrStoreChange
+	}
+
 	service.Name = req.Name
+	service.TurnaroundHours = req.TurnaroundHours
+	service.ExpressTiers = toExpressTiers(req.ExpressTiers)
+	if err := checkTiers(service); err != nil {
+		return nil, err
+	}
+	if req.IsActive != nil {
+		service.IsActive = *req.IsActive
+	}
 	service.UpdatedAt = time.Now()
 	service.UpdatedBy = userID
 
@@ -70,3 +87,16 @@ func (s *service) Update(ctx context.Context, id string, req *dto.ServiceRequest
 func (s *service) Delete(ctx context.Context, id string) error {
 	return s.repo.Delete(ctx, id)
 }
+
+// checkTiers: tier express harus lebih cepat dari pengerjaan standar, dan namanya unik karena kasir memilih tier lewat nama.
+func checkTiers(service *ServiceType) error {
+	seen := map[string]bool{}
+	for _, t := range service.ExpressTiers {
+		name := strings.ToLower(t.Name)
+		if t.Hours >= service.TurnaroundHours || seen[name] {
+			return ErrInvalidExpressTier
+		}
+		seen[name] = true
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package store
+
+import (
+	"fmt"
+	"time"
+)
+
+// BusinessDay is a store's trading day. It runs from the day cutoff on Date until the cutoff on the
+// next date, in the store's time zone, so orders taken after midnight but before the cutoff still
+// count towards the previous day.
+type BusinessDay struct {
+	Date  time.Time // tanggal bisnis, tengah malam di zona waktu toko
+	Start time.Time
+	End   time.Time // eksklusif
+}
+
+func (d BusinessDay) String() string {
+	return d.Date.Format("2006-01-02")
+}
+
+// ValidateDayCutoff checks a HH:MM cutoff. It must be before noon so the business date stays the
+// calendar date the day starts on.
+func ValidateDayCutoff(cutoff string) error {
+	t, err := time.Parse("15:04", cutoff)
+	if err != nil || t.Hour() >= 12 {
+		return fmt.Errorf("%w: %q", ErrInvalidDayCutoff, cutoff)
+	}
+	return nil
+}
+
+// BusinessDayOf returns the business day t falls in.
+func (s *StoreSettings) BusinessDayOf(t time.Time) BusinessDay {
+	h, m := s.cutoff()
+	local := t.In(s.Location()).Add(-time.Duration(h)*time.Hour - time.Duration(m)*time.Minute)
+	return s.businessDay(local.Date())
+}
+
+// ParseBusinessDay parses a YYYY-MM-DD business date.
+func (s *StoreSettings) ParseBusinessDay(date string) (BusinessDay, error) {
+	d, err := time.ParseInLocation("2006-01-02", date, s.Location())
+	if err != nil {
+		return BusinessDay{}, err
+	}
+	return s.businessDay(d.Date()), nil
+}
+
+// BusinessRange returns the [start, end) time range covering business dates from through to.
+func (s *StoreSettings) BusinessRange(from, to string) (time.Time, time.Time, error) {
+	first, err := s.ParseBusinessDay(from)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	last, err := s.ParseBusinessDay(to)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if last.Date.Before(first.Date) {
+		return time.Time{}, time.Time{}, fmt.Errorf("business date %s is before %s", to, from)
+	}
+	return first.Start, last.End, nil
+}
+
+func (s *StoreSettings) businessDay(year int, month time.Month, day int) BusinessDay {
+	loc := s.Location()
+	h, m := s.cutoff()
+	return BusinessDay{
+		Date:  time.Date(year, month, day, 0, 0, 0, 0, loc),
+		Start: time.Date(year, month, day, h, m, 0, 0, loc),
+		End:   time.Date(year, month, day+1, h, m, 0, 0, loc),
+	}
+}
+
+// cutoff: jam dan menit pergantian hari bisnis, 00:00 jika belum diatur.
+func (s *StoreSettings) cutoff() (int, int) {
+	t, err := time.Parse("15:04", s.DayCutoff)
+	if err != nil {
+		return 0, 0
+	}
+	return t.Hour(), t.Minute()
+}
@@ -0,0 +1,69 @@
+package store
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestBusinessDayOf(t *testing.T) {
+	tests := []struct {
+		timezone, cutoff string
+		at               string // RFC 3339, UTC
+		want             string
+	}{
+		{"Asia/Jakarta", "03:00", "2026-03-10T19:59:00Z", "2026-03-10"},  // 02:59 WIB, sebelum cutoff
+		{"Asia/Jakarta", "03:00", "2026-03-10T20:00:00Z", "2026-03-11"},  // 03:00 WIB
+		{"Asia/Makassar", "03:00", "2026-03-10T19:00:00Z", "2026-03-11"}, // 03:00 WITA
+		{"Asia/Makassar", "03:00", "2026-03-10T18:59:00Z", "2026-03-10"},
+		{"Asia/Jakarta", "00:00", "2026-03-10T16:59:00Z", "2026-03-10"}, // 23:59 WIB
+		{"Asia/Jakarta", "00:00", "2026-03-10T17:00:00Z", "2026-03-11"},
+		{"Asia/Jakarta", "", "2026-03-10T17:00:00Z", "2026-03-11"},      // cutoff kosong = 00:00
+		{"Asia/Jakarta", "03:00", "2026-03-31T19:00:00Z", "2026-03-31"}, // 02:00 WIB 1 April
+		{"Asia/Jakarta", "03:00", "2026-12-31T20:30:00Z", "2027-01-01"},
+	}
+	for _, tt := range tests {
+		s := &StoreSettings{Timezone: tt.timezone, DayCutoff: tt.cutoff}
+		at, _ := time.Parse(time.RFC3339, tt.at)
+		if got := s.BusinessDayOf(at).String(); got != tt.want {
+			t.Errorf("%s %s: BusinessDayOf(%s) = %s, want %s", tt.timezone, tt.cutoff, tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestParseBusinessDay(t *testing.T) {
+	tests := []struct {
+		timezone, cutoff string
+		date             string
+		start, end       string // RFC 3339, UTC
+	}{
+		{"Asia/Jakarta", "03:00", "2026-03-10", "2026-03-09T20:00:00Z", "2026-03-10T20:00:00Z"},
+		{"Asia/Jakarta", "00:00", "2026-03-10", "2026-03-09T17:00:00Z", "2026-03-10T17:00:00Z"},
+		{"Asia/Makassar", "03:00", "2026-03-10", "2026-03-09T19:00:00Z", "2026-03-10T19:00:00Z"},
+		{"Asia/Jakarta", "03:30", "2026-02-28", "2026-02-27T20:30:00Z", "2026-02-28T20:30:00Z"},
+	}
+	for _, tt := range tests {
+		s := &StoreSettings{Timezone: tt.timezone, DayCutoff: tt.cutoff}
+		day, err := s.ParseBusinessDay(tt.date)
+		if err != nil {
+			t.Errorf("ParseBusinessDay(%s): %v", tt.date, err)
+			continue
+		}
+		start, _ := time.Parse(time.RFC3339, tt.start)
+		end, _ := time.Parse(time.RFC3339, tt.end)
+		if !day.Start.Equal(start) || !day.End.Equal(end) {
+			t.Errorf("%s %s: ParseBusinessDay(%s) = [%s, %s), want [%s, %s)", tt.timezone, tt.cutoff, tt.date,
+				day.Start.UTC().Format(time.RFC3339), day.End.UTC().Format(time.RFC3339), tt.start, tt.end)
+		}
+		if got := s.BusinessDayOf(day.Start); got.String() != tt.date {
+			t.Errorf("BusinessDayOf(%s) = %s, want %s", tt.start, got, tt.date)
+		}
+	}
+
+	s := &StoreSettings{Timezone: "Asia/Jakarta"}
+	for _, date := range []string{"", "2026-02-30", "10-03-2026"} {
+		if _, err := s.ParseBusinessDay(date); err == nil {
+			t.Errorf("ParseBusinessDay(%q): want error", date)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "sumunar-pos-core/pkg/money"
+
 type StoreRequest struct {
 	Name    string  `json:"name" validate:"required"`
 	Code    string  `json:"code" validate:"required"`
@@ -7,3 +9,54 @@ type StoreRequest struct {
 	Phone   *string `json:"phone"`
 	Logo    *string `json:"logo"`
 }
+
+type StoreSettingsRequest struct {
+	QRISPayload           *string            `json:"qris_payload"`   // kosong = tidak diubah, "" = hapus QRIS
+	InvoiceFormat         string             `json:"invoice_format"` // kosong = tidak diubah
+	InvoiceReset          string             `json:"invoice_reset" validate:"omitempty,oneof=daily monthly never"`
+	PrinterAddress        *string            `json:"printer_address"` // host atau host:port, port default 9100; kosong = tidak diubah, "" = hapus
+	ReceiptPaper          string             `json:"receipt_paper" validate:"omitempty,oneof=58 80"`
+	CashRounding          *money.Amount      `json:"cash_rounding" validate:"omitempty,gte=0"` // kosong = tidak diubah, 0 = tanpa pembulatan
+	CashRoundingMode      string             `json:"cash_rounding_mode" validate:"omitempty,oneof=half_up down up"`
+	TaxRate               *float64           `json:"tax_rate" validate:"omitempty,gte=0,lte=100"` // kosong = tidak diubah, 0 = tanpa PPN
+	TaxInclusive          *bool              `json:"tax_inclusive"`
+	TaxExemptServiceTypes []string           `json:"tax_exempt_service_types" validate:"omitempty,dive,uuid"`                                 // kosong = tidak diubah, [] = tidak ada
+	DiscountLimits        map[string]float64 `json:"discount_limits" validate:"omitempty,dive,keys,oneof=owner worker,endkeys,gte=0,lte=100"` // misal {"worker": 10}; kosong = tidak diubah
+	LoyaltyEnabled        *bool              `json:"loyalty_enabled"`
+	PointsEarnSpend       *money.Amount      `json:"points_earn_spend" validate:"omitempty,gte=0"`
+	PointValue            *money.Amount      `json:"point_value" validate:"omitempty,gte=0"`
+	PointsExpiryDays      *int               `json:"points_expiry_days" validate:"omitempty,gte=0"`
+	LoyaltyTiers          []LoyaltyTier      `json:"loyalty_tiers" validate:"omitempty,dive"` // kosong = tidak diubah, [] = tanpa tier
+	OpeningHours          []OpeningHours     `json:"opening_hours" validate:"omitempty,dive"` // kosong = tidak diubah, [] = buka setiap hari 24 jam
+	Holidays              []string           `json:"holidays" validate:"omitempty,dive,datetime=2006-01-02"`
+	Timezone              string             `json:"timezone"`   // IANA, kosong = tidak diubah
+	DayCutoff             string             `json:"day_cutoff"` // HH:MM sebelum 12:00, kosong = tidak diubah
+	Currency              *Currency          `json:"currency"`
+	ReceiptHeader         *string            `json:"receipt_header" validate:"omitempty,max=500"`
+	ReceiptFooter         *string            `json:"receipt_footer" validate:"omitempty,max=500"`
+	PickupLeadHours       *int               `json:"pickup_lead_hours" validate:"omitempty,gte=0"`
+	DeliveryRates         []DeliveryRate     `json:"delivery_rates" validate:"omitempty,dive"` // kosong = tidak diubah, [] = tanpa antar-jemput
+}
+
+type DeliveryRate struct {
+	MaxDistance float64      `json:"max_distance" validate:"gt=0"` // km
+	Fee         money.Amount `json:"fee" validate:"gte=0"`
+}
+
+type Currency struct {
+	Symbol    string `json:"symbol" validate:"max=5"`           // misal Rp
+	Thousands string `json:"thousands" validate:"max=1"`        // misal "."
+	Decimal   string `json:"decimal" validate:"required,len=1"` // misal ","
+}
+
+type OpeningHours struct {
+	Weekday int    `json:"weekday" validate:"gte=0,lte=6"` // 0 = Minggu
+	Open    string `json:"open" validate:"required"`       // HH:MM
+	Close   string `json:"close" validate:"required"`      // HH:MM
+}
+
+type LoyaltyTier struct {
+	Name     string       `json:"name" validate:"required,max=30"`
+	MinSpend money.Amount `json:"min_spend" validate:"gte=0"`
+	Discount float64      `json:"discount" validate:"gte=0,lte=100"`
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "sumunar-pos-core/pkg/money"
+
 type StoreResponse struct {
 	ID        string  `json:"id"`
 	Name      string  `json:"name"`
@@ -14,6 +16,37 @@ type StoreResponse struct {
 	UpdatedBy string  `json:"updated_by"`
 }
 
+type StoreSettingsResponse struct {
+	StoreID               string             `json:"store_id"`
+	QRISPayload           string             `json:"qris_payload"`
+	InvoiceFormat         string             `json:"invoice_format"`
+	InvoiceReset          string             `json:"invoice_reset"`
+	PrinterAddress        string             `json:"printer_address"`
+	ReceiptPaper          string             `json:"receipt_paper"`
+	CashRounding          money.Amount       `json:"cash_rounding"`
+	CashRoundingMode      string             `json:"cash_rounding_mode"`
+	TaxRate               float64            `json:"tax_rate"`
+	TaxInclusive          bool               `json:"tax_inclusive"`
+	TaxExemptServiceTypes []string           `json:"tax_exempt_service_types"`
+	DiscountLimits        map[string]float64 `json:"discount_limits"`
+	LoyaltyEnabled        bool               `json:"loyalty_enabled"`
+	PointsEarnSpend       money.Amount       `json:"points_earn_spend"`
+	PointValue            money.Amount       `json:"point_value"`
+	PointsExpiryDays      int                `json:"points_expiry_days"`
+	LoyaltyTiers          []LoyaltyTier      `json:"loyalty_tiers"`
+	OpeningHours          []OpeningHours     `json:"opening_hours"`
+	Holidays              []string           `json:"holidays"`
+	Timezone              string             `json:"timezone"`
+	DayCutoff             string             `json:"day_cutoff"`
+	Currency              Currency           `json:"currency"`
+	ReceiptHeader         string             `json:"receipt_header"`
+	ReceiptFooter         string             `json:"receipt_footer"`
+	PickupLeadHours       int                `json:"pickup_lead_hours"`
+	DeliveryRates         []DeliveryRate     `json:"delivery_rates"`
+	UpdatedAt             string             `json:"updated_at,omitempty"`
+	UpdatedBy             string             `json:"updated_by,omitempty"`
+}
+
 type ErrorResponse struct {
 	Message string `json:"message"`
 }
@@ -5,4 +5,11 @@ import "errors"
 var (
 	ErrStoreNotFound = errors.New("store not found")
 	ErrUnsupportedTx = errors.New("database does not support transactions")
+	ErrInvalidQRIS   = errors.New("qris_payload is not a valid QRIS string")
+
+	ErrInvalidInvoiceFormat = errors.New("invalid invoice_format")
+	ErrInvalidOpeningHours  = errors.New("invalid opening_hours")
+	ErrInvalidTimezone      = errors.New("timezone must be an IANA time zone, e.g. Asia/Jakarta")
+	ErrInvalidCurrency      = errors.New("currency thousands and decimal separators must differ")
+	ErrInvalidDayCutoff     = errors.New("day_cutoff must be HH:MM before 12:00")
 )
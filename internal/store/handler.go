@@ -1,6 +1,7 @@
 package store
 
 import (
+	"errors"
 	"io"
 	"net/http"
 	"os"
@@ -143,3 +144,43 @@ func (h *Handler) UploadLogo(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, map[string]string{"logo": logoPath})
 }
+
+func (h *Handler) GetSettings(c echo.Context) error {
+	id := c.Param("id")
+
+	settings, err := h.service.GetSettings(c.Request().Context(), id)
+	if errors.Is(err, ErrStoreNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToStoreSettingsResponse(settings))
+}
+
+func (h *Handler) UpdateSettings(c echo.Context) error {
+	id := c.Param("id")
+	var req dto.StoreSettingsRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	userID := c.Get("user_id").(string)
+
+	settings, err := h.service.UpdateSettings(c.Request().Context(), id, &req, userID)
+	switch {
+	case errors.Is(err, ErrStoreNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrInvalidQRIS), errors.Is(err, ErrInvalidInvoiceFormat), errors.Is(err, ErrInvalidOpeningHours),
+		errors.Is(err, ErrInvalidTimezone), errors.Is(err, ErrInvalidCurrency), errors.Is(err, ErrInvalidDayCutoff):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case err != nil:
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, ToStoreSettingsResponse(settings))
+}
@@ -0,0 +1,102 @@
+package store
+
+import (
+	"fmt"
+	"time"
+)
+
+// OpeningHours is one opening slot on a weekday. A day may have several slots (e.g. closed for a
+// lunch break); a weekday with no slot is a closed day.
+type OpeningHours struct {
+	Weekday time.Weekday `json:"weekday"` // 0 = Minggu ... 6 = Sabtu
+	Open    string       `json:"open"`    // HH:MM
+	Close   string       `json:"close"`   // HH:MM, setelah Open
+}
+
+// ValidateOpeningHours checks the time format of every slot.
+func ValidateOpeningHours(hours []OpeningHours) error {
+	for _, h := range hours {
+		open, err := time.Parse("15:04", h.Open)
+		if err != nil {
+			return fmt.Errorf("%w: open %q", ErrInvalidOpeningHours, h.Open)
+		}
+		closes, err := time.Parse("15:04", h.Close)
+		if err != nil {
+			return fmt.Errorf("%w: close %q", ErrInvalidOpeningHours, h.Close)
+		}
+		if !closes.After(open) {
+			return fmt.Errorf("%w: %s-%s closes before it opens", ErrInvalidOpeningHours, h.Open, h.Close)
+		}
+	}
+	return nil
+}
+
+// IsHoliday reports whether the store is closed all day on t's date, in the store's time zone.
+func (s *StoreSettings) IsHoliday(t time.Time) bool {
+	date := t.In(s.Location()).Format("2006-01-02")
+	for _, h := range s.Holidays {
+		if h == date {
+			return true
+		}
+	}
+	return false
+}
+
+// NextOpen returns t if the store is open at t, otherwise the next time it opens. Stores without
+// opening hours are open every day; holidays are skipped either way. If nothing opens within
+// two weeks t is returned unchanged, so a misconfigured store never blocks an order. Opening hours
+// are read in the store's time zone.
+func (s *StoreSettings) NextOpen(t time.Time) time.Time {
+	if len(s.OpeningHours) == 0 && len(s.Holidays) == 0 {
+		return t
+	}
+	at := t.In(s.Location())
+	for day := 0; day < 14; day++ {
+		if !s.IsHoliday(at) {
+			if len(s.OpeningHours) == 0 {
+				return at
+			}
+			if next, ok := s.openOn(at); ok {
+				return next
+			}
+		}
+		y, m, d := at.Date()
+		at = time.Date(y, m, d+1, 0, 0, 0, 0, at.Location())
+	}
+	return t
+}
+
+// openOn mencari waktu buka paling awal di tanggal at yang tidak lebih cepat dari at.
+func (s *StoreSettings) openOn(at time.Time) (time.Time, bool) {
+	var (
+		best  time.Time
+		found bool
+	)
+	for _, h := range s.OpeningHours {
+		if h.Weekday != at.Weekday() {
+			continue
+		}
+		open, okOpen := clockOn(at, h.Open)
+		closes, okClose := clockOn(at, h.Close)
+		if !okOpen || !okClose || !at.Before(closes) {
+			continue
+		}
+		next := at
+		if at.Before(open) {
+			next = open
+		}
+		if !found || next.Before(best) {
+			best, found = next, true
+		}
+	}
+	return best, found
+}
+
+func clockOn(day time.Time, clock string) (time.Time, bool) {
+	c, err := time.Parse("15:04", clock)
+	if err != nil {
+		return time.Time{}, false
+	}
+	y, m, d := day.Date()
+	return time.Date(y, m, d, c.Hour(), c.Minute(), 0, 0, day.Location()), true
+}
@@ -0,0 +1,71 @@
+package store
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestNextOpen(t *testing.T) {
+	wib, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2026, time.March, day, hour, min, 0, 0, wib)
+	}
+
+	var hours []OpeningHours
+	for wd := time.Monday; wd <= time.Saturday; wd++ {
+		hours = append(hours,
+			OpeningHours{Weekday: wd, Open: "08:00", Close: "12:00"},
+			OpeningHours{Weekday: wd, Open: "13:00", Close: "20:00"},
+		)
+	}
+	// 1 Maret 2026 hari Minggu; 19 Maret (Kamis) libur.
+	s := &StoreSettings{Timezone: "Asia/Jakarta", OpeningHours: hours, Holidays: []string{"2026-03-19"}}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want time.Time
+	}{
+		{"open", at(16, 10, 0), at(16, 10, 0)},
+		{"before opening", at(16, 7, 0), at(16, 8, 0)},
+		{"lunch break", at(16, 12, 30), at(16, 13, 0)},
+		{"at closing", at(16, 20, 0), at(17, 8, 0)},
+		{"after hours before holiday", at(18, 21, 0), at(20, 8, 0)},
+		{"on holiday", at(19, 10, 0), at(20, 8, 0)},
+		{"saturday night", at(21, 20, 30), at(23, 8, 0)},
+		{"sunday", at(22, 9, 0), at(23, 8, 0)},
+		{"utc input", time.Date(2026, time.March, 16, 13, 30, 0, 0, time.UTC), at(17, 8, 0)}, // 20:30 WIB
+	}
+	for _, tt := range tests {
+		if got := s.NextOpen(tt.at); !got.Equal(tt.want) {
+			t.Errorf("%s: NextOpen(%s) = %s, want %s", tt.name, tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestNextOpenWithoutHours(t *testing.T) {
+	wib, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	thu := time.Date(2026, time.March, 19, 10, 0, 0, 0, wib)
+	tests := []struct {
+		name     string
+		settings StoreSettings
+		want     time.Time
+	}{
+		{"not configured", StoreSettings{Timezone: "Asia/Jakarta"}, thu},
+		{"holiday only", StoreSettings{Timezone: "Asia/Jakarta", Holidays: []string{"2026-03-19"}},
+			time.Date(2026, time.March, 20, 0, 0, 0, 0, wib)},
+		{"never opens", StoreSettings{Timezone: "Asia/Jakarta", OpeningHours: []OpeningHours{{Weekday: time.Monday, Open: "8", Close: "20"}}}, thu},
+	}
+	for _, tt := range tests {
+		if got := tt.settings.NextOpen(thu); !got.Equal(tt.want) {
+			t.Errorf("%s: NextOpen(%s) = %s, want %s", tt.name, thu, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package store
 
 import (
+	"time"
+
 	"sumunar-pos-core/internal/base"
 	"sumunar-pos-core/internal/store/dto"
 
@@ -39,3 +41,48 @@ func ToStoreListResponse(stores []*Store) []*dto.StoreResponse {
 	}
 	return res
 }
+
+func ToStoreSettingsResponse(settings *StoreSettings) *dto.StoreSettingsResponse {
+	res := &dto.StoreSettingsResponse{
+		StoreID:               settings.StoreID,
+		QRISPayload:           settings.QRISPayload,
+		InvoiceFormat:         settings.InvoiceFormat,
+		InvoiceReset:          settings.InvoiceReset,
+		PrinterAddress:        settings.PrinterAddress,
+		ReceiptPaper:          settings.ReceiptPaper,
+		CashRounding:          settings.CashRounding,
+		CashRoundingMode:      string(settings.CashRoundingMode),
+		TaxRate:               settings.TaxRate,
+		TaxInclusive:          settings.TaxInclusive,
+		TaxExemptServiceTypes: settings.TaxExemptServiceTypes,
+		DiscountLimits:        settings.DiscountLimits,
+		LoyaltyEnabled:        settings.LoyaltyEnabled,
+		PointsEarnSpend:       settings.PointsEarnSpend,
+		PointValue:            settings.PointValue,
+		PointsExpiryDays:      settings.PointsExpiryDays,
+		LoyaltyTiers:          make([]dto.LoyaltyTier, 0, len(settings.LoyaltyTiers)),
+		OpeningHours:          make([]dto.OpeningHours, 0, len(settings.OpeningHours)),
+		Holidays:              settings.Holidays,
+		Timezone:              settings.Timezone,
+		DayCutoff:             settings.DayCutoff,
+		Currency:              dto.Currency(settings.Currency),
+		ReceiptHeader:         settings.ReceiptHeader,
+		ReceiptFooter:         settings.ReceiptFooter,
+		PickupLeadHours:       settings.PickupLeadHours,
+		DeliveryRates:         make([]dto.DeliveryRate, 0, len(settings.DeliveryRates)),
+		UpdatedBy:             settings.UpdatedBy,
+	}
+	for _, t := range settings.LoyaltyTiers {
+		res.LoyaltyTiers = append(res.LoyaltyTiers, dto.LoyaltyTier(t))
+	}
+	for _, r := range settings.DeliveryRates {
+		res.DeliveryRates = append(res.DeliveryRates, dto.DeliveryRate(r))
+	}
+	for _, h := range settings.OpeningHours {
+		res.OpeningHours = append(res.OpeningHours, dto.OpeningHours{Weekday: int(h.Weekday), Open: h.Open, Close: h.Close})
+	}
+	if !settings.UpdatedAt.IsZero() {
+		res.UpdatedAt = settings.UpdatedAt.Format(time.RFC3339)
+	}
+	return res
+}
@@ -1,7 +1,11 @@
 package store
 
 import (
+	"sync"
+	"time"
+
 	"sumunar-pos-core/internal/base"
+	"sumunar-pos-core/pkg/money"
 )
 
 type Store struct {
@@ -13,3 +17,154 @@ type Store struct {
 	Logo    *string `json:"logo,omitempty"`
 	base.BaseModel
 }
+
+// StoreSettings holds per-store configuration. A store without a saved row uses the zero value.
+type StoreSettings struct {
+	StoreID               string             `json:"store_id"`
+	QRISPayload           string             `json:"qris_payload"`             // QRIS statis dari acquirer, dipakai untuk QR dinamis per order
+	InvoiceFormat         string             `json:"invoice_format"`           // misal "{store_code}-{yyMMdd}-{seq:4}"
+	InvoiceReset          string             `json:"invoice_reset"`            // daily, monthly, never
+	PrinterAddress        string             `json:"printer_address"`          // host:port printer struk (raw TCP), kosong = belum diatur
+	ReceiptPaper          string             `json:"receipt_paper"`            // 58 atau 80 (mm)
+	CashRounding          money.Amount       `json:"cash_rounding"`            // total order dibulatkan ke kelipatan ini, misal 100 atau 500; 0 = tidak dibulatkan
+	CashRoundingMode      money.RoundingMode `json:"cash_rounding_mode"`       // half_up, down, up
+	TaxRate               float64            `json:"tax_rate"`                 // persen PPN, misal 11; 0 = toko non-PKP
+	TaxInclusive          bool               `json:"tax_inclusive"`            // harga katalog sudah termasuk PPN
+	TaxExemptServiceTypes []string           `json:"tax_exempt_service_types"` // service_type_id yang tidak dikenai PPN
+	DiscountLimits        map[string]float64 `json:"discount_limits"`          // diskon manual maksimal (persen) per role, role tanpa entri tidak dibatasi
+	LoyaltyEnabled        bool               `json:"loyalty_enabled"`
+	PointsEarnSpend       money.Amount       `json:"points_earn_spend"`  // belanja per 1 poin, misal 10000; 0 = tidak ada poin
+	PointValue            money.Amount       `json:"point_value"`        // potongan per 1 poin saat ditukar
+	PointsExpiryDays      int                `json:"points_expiry_days"` // 0 = poin tidak kedaluwarsa
+	LoyaltyTiers          []LoyaltyTier      `json:"loyalty_tiers"`
+	OpeningHours          []OpeningHours     `json:"opening_hours"` // kosong = buka setiap hari, 24 jam
+	Holidays              []string           `json:"holidays"`      // YYYY-MM-DD, toko tutup seharian
+	Timezone              string             `json:"timezone"`      // IANA, misal Asia/Jakarta; dipakai untuk tanggal invoice, jam buka, dan struk
+	DayCutoff             string             `json:"day_cutoff"`    // HH:MM pergantian hari bisnis, misal 03:00; lihat BusinessDay
+	Currency              money.Currency     `json:"currency"`
+	ReceiptHeader         string             `json:"receipt_header"`    // teks tambahan di bawah alamat toko pada struk
+	ReceiptFooter         string             `json:"receipt_footer"`    // penutup struk, kosong = "Terima kasih"
+	PickupLeadHours       int                `json:"pickup_lead_hours"` // lama pengerjaan untuk jenis layanan yang belum punya turnaround
+	DeliveryRates         []DeliveryRate     `json:"delivery_rates"`    // tabel ongkos antar-jemput per jarak
+	UpdatedAt             time.Time          `json:"updated_at"`
+	UpdatedBy             string             `json:"updated_by"`
+}
+
+// LoyaltyTier is a membership level reached by total paid spend, with an automatic order discount.
+type LoyaltyTier struct {
+	Name     string       `json:"name"` // misal silver, gold
+	MinSpend money.Amount `json:"min_spend"`
+	Discount float64      `json:"discount"` // persen
+}
+
+// DeliveryRate is the delivery fee for distances up to MaxDistance kilometres.
+type DeliveryRate struct {
+	MaxDistance float64      `json:"max_distance"` // km
+	Fee         money.Amount `json:"fee"`
+}
+
+// DeliveryFee returns the fee of the shortest rate covering distance (km). ok is false when the
+// distance is beyond every rate, i.e. outside the store's delivery area. A store without rates
+// picks up and delivers for free.
+func (s *StoreSettings) DeliveryFee(distance float64) (fee money.Amount, ok bool) {
+	if len(s.DeliveryRates) == 0 {
+		return 0, true
+	}
+	var rate *DeliveryRate
+	for i := range s.DeliveryRates {
+		r := &s.DeliveryRates[i]
+		if distance <= r.MaxDistance && (rate == nil || r.MaxDistance < rate.MaxDistance) {
+			rate = r
+		}
+	}
+	if rate == nil {
+		return 0, false
+	}
+	return rate.Fee, true
+}
+
+// DiscountLimit returns the highest manual discount, in percent of the order, that role may give
+// without approval. ok is false when the role is not limited.
+func (s *StoreSettings) DiscountLimit(role string) (limit float64, ok bool) {
+	limit, ok = s.DiscountLimits[role]
+	return limit, ok
+}
+
+// IsTaxExempt reports whether the service type is exempt from tax at this store.
+func (s *StoreSettings) IsTaxExempt(serviceTypeID string) bool {
+	for _, id := range s.TaxExemptServiceTypes {
+		if id == serviceTypeID {
+			return true
+		}
+	}
+	return false
+}
+
+// PointsFor returns the points earned on a paid order total.
+func (s *StoreSettings) PointsFor(total money.Amount) int {
+	if !s.LoyaltyEnabled || s.PointsEarnSpend <= 0 || total <= 0 {
+		return 0
+	}
+	return int(total / s.PointsEarnSpend)
+}
+
+// RedeemablePoints caps points so their value does not exceed amount.
+func (s *StoreSettings) RedeemablePoints(points int, amount money.Amount) int {
+	if !s.LoyaltyEnabled || s.PointValue <= 0 || amount <= 0 {
+		return 0
+	}
+	return min(points, int(amount/s.PointValue))
+}
+
+// PointsValue is the discount given for redeeming points.
+func (s *StoreSettings) PointsValue(points int) money.Amount {
+	return s.PointValue * money.Amount(points)
+}
+
+// PointsExpiry returns when points earned at the given time expire, nil if they never do.
+func (s *StoreSettings) PointsExpiry(at time.Time) *time.Time {
+	if s.PointsExpiryDays <= 0 {
+		return nil
+	}
+	expiry := at.AddDate(0, 0, s.PointsExpiryDays)
+	return &expiry
+}
+
+// TierFor returns the highest tier reached with the given spend, nil if none.
+func (s *StoreSettings) TierFor(spend money.Amount) *LoyaltyTier {
+	if !s.LoyaltyEnabled {
+		return nil
+	}
+	var tier *LoyaltyTier
+	for i := range s.LoyaltyTiers {
+		t := &s.LoyaltyTiers[i]
+		if spend >= t.MinSpend && (tier == nil || t.MinSpend > tier.MinSpend) {
+			tier = t
+		}
+	}
+	return tier
+}
+
+// Defaults for stores that have not set their own.
+const (
+	DefaultTimezone  = "Asia/Jakarta"
+	DefaultDayCutoff = "00:00"
+)
+
+var locations sync.Map
+
+// Location is the store's time zone. Stores without a valid one use the server's.
+func (s *StoreSettings) Location() *time.Location {
+	if s.Timezone == "" {
+		return time.Local
+	}
+	if loc, ok := locations.Load(s.Timezone); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	locations.Store(s.Timezone, loc)
+	return loc
+}
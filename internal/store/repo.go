@@ -2,8 +2,13 @@ package store
 
 import (
 	"context"
+	"errors"
 
 	"sumunar-pos-core/pkg/db"
+	"sumunar-pos-core/pkg/invoiceno"
+	"sumunar-pos-core/pkg/money"
+
+	"github.com/jackc/pgx/v5"
 )
 
 type StoreRepository interface {
@@ -13,6 +18,9 @@ type StoreRepository interface {
 	FindAll(ctx context.Context, limit, offset int) ([]*Store, int, error)
 	Update(ctx context.Context, store *Store) error
 	Delete(ctx context.Context, id string) error
+	FindSettings(ctx context.Context, storeID string) (*StoreSettings, error)
+	UpsertSettings(ctx context.Context, tx db.DBTX, settings *StoreSettings) error
+	SeedInvoiceSequence(ctx context.Context, tx db.DBTX, storeID, periodKey string) error
 }
 
 type storeRepo struct {
@@ -34,6 +42,7 @@ func (r *storeRepo) Create(ctx context.Context, store *Store) error {
 		store.Code,
 		store.Address,
 		store.Phone,
+		store.Logo,
 		store.IsActive,
 		store.CreatedAt,
 		store.CreatedBy,
@@ -134,6 +143,7 @@ func (r *storeRepo) Update(ctx context.Context, store *Store) error {
 		store.Code,
 		store.Address,
 		store.Phone,
+		store.Logo,
 		store.IsActive,
 		store.UpdatedAt,
 		store.UpdatedBy,
@@ -146,3 +156,151 @@ func (r *storeRepo) Delete(ctx context.Context, id string) error {
 	_, err := r.db.Exec(ctx, `DELETE FROM stores WHERE id = $1`, id)
 	return err
 }
+
+func (r *storeRepo) FindSettings(ctx context.Context, storeID string) (*StoreSettings, error) {
+	query := `SELECT store_id, qris_payload, invoice_format, invoice_reset, printer_address, receipt_paper, cash_rounding, cash_rounding_mode, tax_rate, tax_inclusive, tax_exempt_service_types, discount_limits, loyalty_enabled, points_earn_spend, point_value, points_expiry_days, loyalty_tiers, opening_hours, holidays, timezone, day_cutoff, currency_symbol, thousands_separator, decimal_separator, receipt_header, receipt_footer, pickup_lead_hours, delivery_rates, updated_at, updated_by FROM store_settings WHERE store_id = $1`
+
+	settings := StoreSettings{
+		StoreID:               storeID,
+		InvoiceFormat:         invoiceno.DefaultFormat,
+		InvoiceReset:          invoiceno.ResetDaily,
+		ReceiptPaper:          "58",
+		CashRoundingMode:      money.RoundHalfUp,
+		TaxExemptServiceTypes: []string{},
+		DiscountLimits:        map[string]float64{},
+		LoyaltyTiers:          []LoyaltyTier{},
+		OpeningHours:          []OpeningHours{},
+		Holidays:              []string{},
+		DeliveryRates:         []DeliveryRate{},
+		Timezone:              DefaultTimezone,
+		DayCutoff:             DefaultDayCutoff,
+		Currency:              money.DefaultCurrency,
+	}
+	err := r.db.QueryRow(ctx, query, storeID).Scan(
+		&settings.StoreID,
+		&settings.QRISPayload,
+		&settings.InvoiceFormat,
+		&settings.InvoiceReset,
+		&settings.PrinterAddress,
+		&settings.ReceiptPaper,
+		&settings.CashRounding,
+		&settings.CashRoundingMode,
+		&settings.TaxRate,
+		&settings.TaxInclusive,
+		&settings.TaxExemptServiceTypes,
+		&settings.DiscountLimits,
+		&settings.LoyaltyEnabled,
+		&settings.PointsEarnSpend,
+		&settings.PointValue,
+		&settings.PointsExpiryDays,
+		&settings.LoyaltyTiers,
+		&settings.OpeningHours,
+		&settings.Holidays,
+		&settings.Timezone,
+		&settings.DayCutoff,
+		&settings.Currency.Symbol,
+		&settings.Currency.Thousands,
+		&settings.Currency.Decimal,
+		&settings.ReceiptHeader,
+		&settings.ReceiptFooter,
+		&settings.PickupLeadHours,
+		&settings.DeliveryRates,
+		&settings.UpdatedAt,
+		&settings.UpdatedBy,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &settings, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (r *storeRepo) UpsertSettings(ctx context.Context, tx db.DBTX, settings *StoreSettings) error {
+	query := `
+		INSERT INTO store_settings (store_id, qris_payload, invoice_format, invoice_reset, printer_address, receipt_paper, cash_rounding, cash_rounding_mode, tax_rate, tax_inclusive, tax_exempt_service_types, discount_limits,
+			loyalty_enabled, points_earn_spend, point_value, points_expiry_days, loyalty_tiers, opening_hours, holidays,
+			timezone, day_cutoff, currency_symbol, thousands_separator, decimal_separator, receipt_header, receipt_footer, pickup_lead_hours,
+			delivery_rates, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30)
+		ON CONFLICT (store_id) DO UPDATE SET
+			qris_payload = EXCLUDED.qris_payload,
+			invoice_format = EXCLUDED.invoice_format,
+			invoice_reset = EXCLUDED.invoice_reset,
+			printer_address = EXCLUDED.printer_address,
+			receipt_paper = EXCLUDED.receipt_paper,
+			cash_rounding = EXCLUDED.cash_rounding,
+			cash_rounding_mode = EXCLUDED.cash_rounding_mode,
+			tax_rate = EXCLUDED.tax_rate,
+			tax_inclusive = EXCLUDED.tax_inclusive,
+			tax_exempt_service_types = EXCLUDED.tax_exempt_service_types,
+			discount_limits = EXCLUDED.discount_limits,
+			loyalty_enabled = EXCLUDED.loyalty_enabled,
+			points_earn_spend = EXCLUDED.points_earn_spend,
+			point_value = EXCLUDED.point_value,
+			points_expiry_days = EXCLUDED.points_expiry_days,
+			loyalty_tiers = EXCLUDED.loyalty_tiers,
+			opening_hours = EXCLUDED.opening_hours,
+			holidays = EXCLUDED.holidays,
+			timezone = EXCLUDED.timezone,
+			day_cutoff = EXCLUDED.day_cutoff,
+			currency_symbol = EXCLUDED.currency_symbol,
+			thousands_separator = EXCLUDED.thousands_separator,
+			decimal_separator = EXCLUDED.decimal_separator,
+			receipt_header = EXCLUDED.receipt_header,
+			receipt_footer = EXCLUDED.receipt_footer,
+			pickup_lead_hours = EXCLUDED.pickup_lead_hours,
+			delivery_rates = EXCLUDED.delivery_rates,
+			updated_at = EXCLUDED.updated_at,
+			updated_by = EXCLUDED.updated_by
+	`
+	_, err := tx.Exec(ctx, query,
+		settings.StoreID,
+		settings.QRISPayload,
+		settings.InvoiceFormat,
+		settings.InvoiceReset,
+		settings.PrinterAddress,
+		settings.ReceiptPaper,
+		settings.CashRounding,
+		settings.CashRoundingMode,
+		settings.TaxRate,
+		settings.TaxInclusive,
+		settings.TaxExemptServiceTypes,
+		settings.DiscountLimits,
+		settings.LoyaltyEnabled,
+		settings.PointsEarnSpend,
+		settings.PointValue,
+		settings.PointsExpiryDays,
+		settings.LoyaltyTiers,
+		settings.OpeningHours,
+		settings.Holidays,
+		settings.Timezone,
+		settings.DayCutoff,
+		settings.Currency.Symbol,
+		settings.Currency.Thousands,
+		settings.Currency.Decimal,
+		settings.ReceiptHeader,
+		settings.ReceiptFooter,
+		settings.PickupLeadHours,
+		settings.DeliveryRates,
+		settings.UpdatedAt,
+		settings.UpdatedBy,
+	)
+	return err
+}
+
+// SeedInvoiceSequence makes the counter of periodKey continue after the highest number issued in any
+// period that overlaps it, e.g. the month of a new daily period or the days of a new monthly one.
+// Run it after the invoice format or reset changes so the new period does not start again at 1.
+func (r *storeRepo) SeedInvoiceSequence(ctx context.Context, tx db.DBTX, storeID, periodKey string) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO invoice_sequences (store_id, period_key, last_value)
+		SELECT $1, $2, COALESCE(MAX(last_value), 0)
+		FROM invoice_sequences
+		WHERE store_id = $1
+			AND (period_key = 'all' OR $2 = 'all' OR period_key LIKE $2 || '%' OR $2 LIKE period_key || '%')
+		ON CONFLICT (store_id, period_key) DO UPDATE SET last_value = EXCLUDED.last_value
+	`, storeID, periodKey)
+	return err
+}
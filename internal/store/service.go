@@ -2,11 +2,17 @@ package store
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"sort"
 	"sumunar-pos-core/internal/store/dto"
 	"sumunar-pos-core/internal/userstore"
 	"sumunar-pos-core/middleware"
 	"sumunar-pos-core/pkg/db"
+	"sumunar-pos-core/pkg/invoiceno"
+	"sumunar-pos-core/pkg/money"
+	"sumunar-pos-core/pkg/qris"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -20,6 +26,8 @@ type StoreService interface {
 	Delete(ctx context.Context, id string) error
 	UpdateLogo(ctx context.Context, storeID string, logoPath string) error
 	CreateTx(ctx context.Context, req *dto.StoreRequest, userID string) (*dto.StoreResponse, error)
+	GetSettings(ctx context.Context, storeID string) (*StoreSettings, error)
+	UpdateSettings(ctx context.Context, storeID string, req *dto.StoreSettingsRequest, userID string) (*StoreSettings, error)
 }
 
 type service struct {
@@ -133,3 +141,165 @@ func (s *service) UpdateLogo(ctx context.Context, storeID string, logoPath strin
 
 	return s.repo.Update(ctx, store)
 }
+
+func (s *service) GetSettings(ctx context.Context, storeID string) (*StoreSettings, error) {
+	if _, err := s.repo.FindByID(ctx, storeID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, ErrStoreNotFound) {
+			return nil, ErrStoreNotFound
+		}
+		return nil, err
+	}
+	return s.repo.FindSettings(ctx, storeID)
+}
+
+func (s *service) UpdateSettings(ctx context.Context, storeID string, req *dto.StoreSettingsRequest, userID string) (*StoreSettings, error) {
+	settings, err := s.GetSettings(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.QRISPayload != nil {
+		if *req.QRISPayload != "" {
+			if err := qris.Validate(*req.QRISPayload); err != nil {
+				return nil, ErrInvalidQRIS
+			}
+		}
+		settings.QRISPayload = *req.QRISPayload
+	}
+
+	prevFormat, prevReset := settings.InvoiceFormat, settings.InvoiceReset
+	if req.InvoiceFormat != "" || req.InvoiceReset != "" {
+		if req.InvoiceFormat != "" {
+			settings.InvoiceFormat = req.InvoiceFormat
+		}
+		if req.InvoiceReset != "" {
+			settings.InvoiceReset = req.InvoiceReset
+		}
+		// format dan reset dicek bersama supaya nomor tidak berulang setelah sequence mulai dari 1 lagi
+		if err := invoiceno.Validate(settings.InvoiceFormat, settings.InvoiceReset); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidInvoiceFormat, err)
+		}
+	}
+
+	if req.PrinterAddress != nil {
+		settings.PrinterAddress = *req.PrinterAddress
+	}
+	if req.ReceiptPaper != "" {
+		settings.ReceiptPaper = req.ReceiptPaper
+	}
+
+	if req.CashRounding != nil {
+		settings.CashRounding = *req.CashRounding
+	}
+	if req.CashRoundingMode != "" {
+		settings.CashRoundingMode = money.RoundingMode(req.CashRoundingMode)
+	}
+
+	if req.TaxRate != nil {
+		settings.TaxRate = *req.TaxRate
+	}
+	if req.TaxInclusive != nil {
+		settings.TaxInclusive = *req.TaxInclusive
+	}
+	if req.TaxExemptServiceTypes != nil {
+		settings.TaxExemptServiceTypes = req.TaxExemptServiceTypes
+	}
+	if req.DiscountLimits != nil {
+		settings.DiscountLimits = req.DiscountLimits
+	}
+
+	if req.LoyaltyEnabled != nil {
+		settings.LoyaltyEnabled = *req.LoyaltyEnabled
+	}
+	if req.PointsEarnSpend != nil {
+		settings.PointsEarnSpend = *req.PointsEarnSpend
+	}
+	if req.PointValue != nil {
+		settings.PointValue = *req.PointValue
+	}
+	if req.PointsExpiryDays != nil {
+		settings.PointsExpiryDays = *req.PointsExpiryDays
+	}
+	if req.LoyaltyTiers != nil {
+		settings.LoyaltyTiers = make([]LoyaltyTier, 0, len(req.LoyaltyTiers))
+		for _, t := range req.LoyaltyTiers {
+			settings.LoyaltyTiers = append(settings.LoyaltyTiers, LoyaltyTier(t))
+		}
+	}
+
+	if req.OpeningHours != nil {
+		hours := make([]OpeningHours, 0, len(req.OpeningHours))
+		for _, h := range req.OpeningHours {
+			hours = append(hours, OpeningHours{Weekday: time.Weekday(h.Weekday), Open: h.Open, Close: h.Close})
+		}
+		if err := ValidateOpeningHours(hours); err != nil {
+			return nil, err
+		}
+		settings.OpeningHours = hours
+	}
+	if req.Holidays != nil {
+		settings.Holidays = req.Holidays
+	}
+	if req.Timezone != "" {
+		if _, err := time.LoadLocation(req.Timezone); err != nil {
+			return nil, ErrInvalidTimezone
+		}
+		settings.Timezone = req.Timezone
+	}
+	if req.DayCutoff != "" {
+		if err := ValidateDayCutoff(req.DayCutoff); err != nil {
+			return nil, err
+		}
+		settings.DayCutoff = req.DayCutoff
+	}
+	if req.Currency != nil {
+		if req.Currency.Thousands == req.Currency.Decimal {
+			return nil, ErrInvalidCurrency
+		}
+		settings.Currency = money.Currency(*req.Currency)
+	}
+
+	if req.ReceiptHeader != nil {
+		settings.ReceiptHeader = *req.ReceiptHeader
+	}
+	if req.ReceiptFooter != nil {
+		settings.ReceiptFooter = *req.ReceiptFooter
+	}
+	if req.PickupLeadHours != nil {
+		settings.PickupLeadHours = *req.PickupLeadHours
+	}
+	if req.DeliveryRates != nil {
+		settings.DeliveryRates = make([]DeliveryRate, 0, len(req.DeliveryRates))
+		for _, r := range req.DeliveryRates {
+			settings.DeliveryRates = append(settings.DeliveryRates, DeliveryRate(r))
+		}
+		sort.Slice(settings.DeliveryRates, func(i, j int) bool {
+			return settings.DeliveryRates[i].MaxDistance < settings.DeliveryRates[j].MaxDistance
+		})
+	}
+
+	settings.UpdatedAt = time.Now()
+	settings.UpdatedBy = userID
+
+	txb, ok := s.db.(db.TxBeginner)
+	if !ok {
+		return nil, ErrUnsupportedTx
+	}
+	tx, err := txb.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.repo.UpsertSettings(ctx, tx, settings); err != nil {
+		return nil, err
+	}
+	// format atau reset baru bisa mengulang nomor yang sudah terbit di periode berjalan
+	if settings.InvoiceFormat != prevFormat || settings.InvoiceReset != prevReset {
+		key := invoiceno.PeriodKey(settings.InvoiceReset, settings.BusinessDayOf(settings.UpdatedAt).Date)
+		if err := s.repo.SeedInvoiceSequence(ctx, tx, storeID, key); err != nil {
+			return nil, err
+		}
+	}
+	return settings, tx.Commit(ctx)
+}
@@ -7,5 +7,5 @@ type UserRequest struct {
 	GoogleID *string `json:"google_id,omitempty"`
 	Picture  *string `json:"picture,omitempty"`
 	Provider string  `json:"provider" validate:"required,oneof=local google"`
-	Role     string  `json:"role" validate:"required,oneof=owner worker"`
+	Role     string  `json:"role" validate:"required,oneof=owner worker courier"`
 }
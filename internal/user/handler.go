@@ -6,6 +6,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 
+	basedto "sumunar-pos-core/internal/base/dto"
 	"sumunar-pos-core/internal/user/dto"
 )
 
@@ -36,7 +37,7 @@ func (h *Handler) ListUsers(c echo.Context) error {
 	}
 
 	// pages := int(math.Ceil(float64(total) / float64(limit)))
-	res := NewPaginatedResponse(users, total, page, limit)
+	res := basedto.NewPaginatedResponse(users, total, page, limit)
 
 	return c.JSON(http.StatusOK, res)
 }
@@ -4,7 +4,6 @@ import (
 	"time"
 
 	"sumunar-pos-core/internal/base"
-	basedto "sumunar-pos-core/internal/base/dto"
 	"sumunar-pos-core/internal/user/dto"
 
 	"github.com/google/uuid"
@@ -26,20 +25,3 @@ func ToUserModel(req *dto.UserRequest, createdBy string) *User {
 		},
 	}
 }
-
-func NewPaginatedResponse[T any](data []T, total, page, limit int) basedto.PaginatedResponse[T] {
-	pages := 0
-	if limit > 0 {
-		pages = (total + limit - 1) / limit // ceil
-	}
-
-	offset := (page - 1) * limit
-	return basedto.PaginatedResponse[T]{
-		Data:   data,
-		Total:  total,
-		Page:   page,
-		Pages:  pages,
-		Limit:  limit,
-		Offset: offset,
-	}
-}
@@ -14,6 +14,6 @@ type User struct {
 	Picture   *string    `json:"picture,omitempty"`   // avatar dari Google
 	Provider  string     `json:"provider"`            // "google" atau "local"
 	LastLogin *time.Time `json:"last_login,omitempty"`
-	Role      string     `json:"role"` // "owner", "worker"
+	Role      string     `json:"role"` // "owner", "worker", "courier"
 	base.BaseModel
 }
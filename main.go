@@ -1,21 +1,33 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"sumunar-pos-core/config"
 	"sumunar-pos-core/db"
 	docs "sumunar-pos-core/docs"
+	"sumunar-pos-core/internal/addon"
 	"sumunar-pos-core/internal/auth"
 	"sumunar-pos-core/internal/customer"
+	"sumunar-pos-core/internal/delivery"
+	"sumunar-pos-core/internal/enum"
+	"sumunar-pos-core/internal/invoice"
+	"sumunar-pos-core/internal/notification"
 	"sumunar-pos-core/internal/order"
+	"sumunar-pos-core/internal/paymentmethod"
+	"sumunar-pos-core/internal/prepaid"
 	"sumunar-pos-core/internal/product"
 	"sumunar-pos-core/internal/productservice"
+	"sumunar-pos-core/internal/promo"
+	"sumunar-pos-core/internal/receipt"
+	"sumunar-pos-core/internal/reconciliation"
 	"sumunar-pos-core/internal/servicetype"
 	"sumunar-pos-core/internal/store"
 	"sumunar-pos-core/internal/user"
 	"sumunar-pos-core/internal/userstore"
 	"sumunar-pos-core/pkg/validator"
 	"sumunar-pos-core/routes"
+	_ "time/tzdata" // zona waktu toko tetap bisa dimuat di image tanpa tzdata
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -72,25 +84,60 @@ func main() {
 	productServiceRepo := productservice.NewProductServiceRepository(dbConn)
 	orderRepo := order.NewOrderRepository(dbConn)
 	customerRepo := customer.NewCustomerRepository(dbConn)
+	paymentMethodRepo := paymentmethod.NewPaymentMethodRepository(dbConn)
+	reconciliationRepo := reconciliation.NewReconciliationRepository(dbConn)
+	notificationRepo := notification.NewNotificationRepository(dbConn)
+	promoRepo := promo.NewPromoRepository(dbConn)
+	prepaidRepo := prepaid.NewPrepaidRepository(dbConn)
+	addonRepo := addon.NewAddonRepository(dbConn)
+	deliveryRepo := delivery.NewDeliveryRepository(dbConn)
 
 	// ==== Init Services ====
 	authService := auth.NewService(userRepo, refreshTokenRepo)
 	userService := user.NewService(userRepo)
+	customerService := customer.NewService(customerRepo, dbConn)
 	userStoreService := userstore.NewService(userStoreRepo)
 	storeService := store.NewService(storeRepo, userStoreService, dbConn)
 	serviceTypeService := servicetype.NewService(serviceTypeRepo, dbConn)
 	productService := product.NewService(productRepo, dbConn)
-	productServiceService := productservice.NewService(productServiceRepo, dbConn)
-	orderService := order.NewService(orderRepo, productServiceRepo, customerRepo, dbConn)
+	productServiceService := productservice.NewService(productServiceRepo, productRepo, serviceTypeRepo, storeRepo, dbConn)
+	notificationService := notification.NewService(notificationRepo, storeRepo, dbConn, config.Cfg.NotifyDefaultChannel)
+	orderService := order.NewService(orderRepo, productServiceRepo, customerRepo, paymentMethodRepo, storeRepo, promoRepo, userRepo,
+		prepaidRepo, addonRepo, serviceTypeRepo, notificationService, dbConn)
+	paymentMethodService := paymentmethod.NewService(paymentMethodRepo)
+	reconciliationService := reconciliation.NewService(reconciliationRepo, storeRepo, dbConn)
+	invoiceService := invoice.NewService(orderRepo, customerRepo, storeRepo)
+	promoService := promo.NewService(promoRepo)
+	prepaidService := prepaid.NewService(prepaidRepo, customerRepo, paymentMethodRepo, dbConn)
+	addonService := addon.NewService(addonRepo, serviceTypeRepo, storeRepo)
+	deliveryService := delivery.NewService(deliveryRepo, orderRepo, customerRepo, storeRepo, userRepo, userStoreRepo, orderService, dbConn)
+	receiptService := receipt.NewService(orderRepo, customerRepo, storeRepo, config.Cfg.PrinterTimeout)
 
 	// ==== Init Handlers ====
 	authHandler := auth.NewHandler(authService)
 	userHandler := user.NewHandler(userService)
+	customerHandler := customer.NewHandler(customerService)
 	storeHandler := store.NewHandler(storeService)
 	serviceTypeHandler := servicetype.NewHandler(serviceTypeService)
 	productHandler := product.NewHandler(productService)
 	productServiceHandler := productservice.NewHandler(productServiceService)
 	orderHandler := order.NewHandler(orderService)
+	paymentMethodHandler := paymentmethod.NewHandler(paymentMethodService)
+	reconciliationHandler := reconciliation.NewHandler(reconciliationService)
+	receiptHandler := receipt.NewHandler(receiptService)
+	invoiceHandler := invoice.NewHandler(invoiceService)
+	notificationHandler := notification.NewHandler(notificationService)
+	promoHandler := promo.NewHandler(promoService)
+	prepaidHandler := prepaid.NewHandler(prepaidService)
+	addonHandler := addon.NewHandler(addonService)
+	deliveryHandler := delivery.NewHandler(deliveryService)
+
+	// ==== Background Workers ====
+	dispatcher := notification.NewDispatcher(notificationRepo, dbConn, notificationProviders(), notification.DispatcherConfig{
+		Interval:    config.Cfg.NotifyInterval,
+		MaxAttempts: config.Cfg.NotifyMaxAttempts,
+	})
+	go dispatcher.Run(context.Background())
 
 	// ==== Register Routes ====
 	routes.RegisterRoutes(
@@ -102,9 +149,50 @@ func main() {
 		productHandler,
 		productServiceHandler,
 		orderHandler,
+		paymentMethodHandler,
+		reconciliationHandler,
+		receiptHandler,
+		invoiceHandler,
+		notificationHandler,
+		promoHandler,
+		customerHandler,
+		prepaidHandler,
+		addonHandler,
+		deliveryHandler,
 	)
 
 	// Start server
 	addr := fmt.Sprintf(":%s", config.Cfg.Port)
 	e.Logger.Fatal(e.Start(addr))
 }
+
+// notificationProviders registers a provider for every channel that has credentials configured.
+func notificationProviders() map[string]notification.Provider {
+	cfg := config.Cfg
+	providers := map[string]notification.Provider{}
+
+	if cfg.NotifyFake {
+		fake := &notification.FakeProvider{}
+		providers[enum.NotificationChannelWhatsApp] = fake
+		providers[enum.NotificationChannelSMS] = fake
+		providers[enum.NotificationChannelEmail] = fake
+		return providers
+	}
+
+	if cfg.WhatsAppAPIURL != "" {
+		providers[enum.NotificationChannelWhatsApp] = &notification.WhatsAppProvider{URL: cfg.WhatsAppAPIURL, Token: cfg.WhatsAppAPIToken}
+	}
+	if cfg.SMSAPIURL != "" {
+		providers[enum.NotificationChannelSMS] = &notification.SMSProvider{URL: cfg.SMSAPIURL, APIKey: cfg.SMSAPIKey, Sender: cfg.SMSSender}
+	}
+	if cfg.SMTPHost != "" {
+		providers[enum.NotificationChannelEmail] = &notification.SMTPProvider{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		}
+	}
+	return providers
+}
@@ -16,7 +16,7 @@ const (
 )
 
 // SetDataToContext menyisipkan data ke context
-func SetDataToContext(ctx context.Context, key, value string) context.Context {
+func SetDataToContext(ctx context.Context, key contextKey, value string) context.Context {
 	return context.WithValue(ctx, key, value)
 }
 
@@ -19,3 +19,12 @@ type TxBeginner interface {
 	DBTX
 	Begin(ctx context.Context) (pgx.Tx, error)
 }
+
+// NullID turns an optional uuid filter into a query argument: nil when empty, so a condition like
+// ($1::uuid IS NULL OR store_id = $1) skips the filter without casting the column to text.
+func NullID(id string) any {
+	if id == "" {
+		return nil
+	}
+	return id
+}
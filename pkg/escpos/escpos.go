@@ -0,0 +1,208 @@
+// Package escpos builds ESC/POS byte streams for thermal receipt printers and sends them
+// to raw TCP (JetDirect, port 9100) printers.
+package escpos
+
+import (
+	"bytes"
+	"image"
+	"strings"
+)
+
+// Paper is the roll width of a thermal printer.
+type Paper int
+
+const (
+	Paper58 Paper = 58
+	Paper80 Paper = 80
+)
+
+// Columns is the number of characters per line in the default font (12x24).
+func (p Paper) Columns() int {
+	if p == Paper80 {
+		return 48
+	}
+	return 32
+}
+
+// Dots is the printable width in dots at 203 dpi.
+func (p Paper) Dots() int {
+	if p == Paper80 {
+		return 576
+	}
+	return 384
+}
+
+// ParsePaper maps "58"/"80" to a Paper, falling back to 58mm.
+func ParsePaper(s string) Paper {
+	if s == "80" {
+		return Paper80
+	}
+	return Paper58
+}
+
+type Align byte
+
+const (
+	AlignLeft   Align = 0
+	AlignCenter Align = 1
+	AlignRight  Align = 2
+)
+
+const (
+	esc = 0x1B
+	gs  = 0x1D
+)
+
+// Writer accumulates ESC/POS commands for one print job.
+type Writer struct {
+	buf   bytes.Buffer
+	paper Paper
+	scale int // pembesaran lebar karakter saat ini
+}
+
+func NewWriter(paper Paper) *Writer {
+	w := &Writer{paper: paper, scale: 1}
+	w.buf.Write([]byte{esc, '@'}) // initialize printer
+	return w
+}
+
+func (w *Writer) Paper() Paper {
+	return w.paper
+}
+
+func (w *Writer) Align(a Align) *Writer {
+	w.buf.Write([]byte{esc, 'a', byte(a)})
+	return w
+}
+
+func (w *Writer) Bold(on bool) *Writer {
+	w.buf.Write([]byte{esc, 'E', boolByte(on)})
+	return w
+}
+
+// Size sets character magnification, 1 (normal) to 8 in each direction.
+func (w *Writer) Size(width, height int) *Writer {
+	w.scale = clamp(width, 1, 8)
+	w.buf.Write([]byte{gs, '!', byte((w.scale-1)<<4 | (clamp(height, 1, 8) - 1))})
+	return w
+}
+
+// Line prints s followed by a line feed. Characters the printer cannot show are replaced.
+func (w *Writer) Line(s string) *Writer {
+	w.buf.WriteString(sanitize(s))
+	w.buf.WriteByte('\n')
+	return w
+}
+
+// Columns prints left and right on one line, right aligned to the paper edge.
+// When both do not fit, right moves to its own line.
+func (w *Writer) Columns(left, right string) *Writer {
+	cols := w.width()
+	left, right = sanitize(left), sanitize(right)
+	gap := cols - len(left) - len(right)
+	if gap < 1 {
+		w.Line(left)
+		gap = cols - len(right)
+		if gap < 0 {
+			gap = 0
+		}
+		left = ""
+	}
+	return w.Line(left + strings.Repeat(" ", gap) + right)
+}
+
+// Wrap prints s broken into lines of at most the paper width, at word boundaries when possible.
+func (w *Writer) Wrap(s string) *Writer {
+	for _, l := range wrap(sanitize(s), w.width()) {
+		w.Line(l)
+	}
+	return w
+}
+
+func (w *Writer) Separator() *Writer {
+	return w.Line(strings.Repeat("-", w.width()))
+}
+
+func (w *Writer) Feed(lines int) *Writer {
+	w.buf.Write([]byte{esc, 'd', byte(clamp(lines, 0, 255))})
+	return w
+}
+
+// Cut feeds past the tear bar and performs a partial cut.
+func (w *Writer) Cut() *Writer {
+	w.buf.Write([]byte{gs, 'V', 'B', 3})
+	return w
+}
+
+// Image prints img as a monochrome raster (GS v 0), scaled down to at most maxDots wide.
+func (w *Writer) Image(img image.Image, maxDots int) *Writer {
+	if maxDots <= 0 || maxDots > w.paper.Dots() {
+		maxDots = w.paper.Dots()
+	}
+	raster, widthBytes, height := rasterize(img, maxDots)
+	if height == 0 {
+		return w
+	}
+	w.buf.Write([]byte{gs, 'v', '0', 0,
+		byte(widthBytes), byte(widthBytes >> 8),
+		byte(height), byte(height >> 8),
+	})
+	w.buf.Write(raster)
+	w.buf.WriteByte('\n')
+	return w
+}
+
+// width is the number of characters that fit on a line at the current size.
+func (w *Writer) width() int {
+	return w.paper.Columns() / w.scale
+}
+
+func (w *Writer) Bytes() []byte {
+	return w.buf.Bytes()
+}
+
+func boolByte(b bool) byte {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// sanitize keeps printable ASCII; the default code page cannot print anything else reliably.
+func sanitize(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\n' || r == '\r' || r == '\t':
+			b.WriteByte(' ')
+		case r >= 0x20 && r < 0x7F:
+			b.WriteRune(r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+func wrap(s string, width int) []string {
+	var lines []string
+	for len(s) > width {
+		cut := strings.LastIndexByte(s[:width+1], ' ')
+		if cut <= 0 {
+			cut = width
+		}
+		lines = append(lines, strings.TrimRight(s[:cut], " "))
+		s = strings.TrimLeft(s[cut:], " ")
+	}
+	return append(lines, s)
+}
@@ -0,0 +1,41 @@
+package escpos
+
+import (
+	"image"
+	"image/color"
+)
+
+// rasterize scales img to fit maxDots (nearest neighbour) and packs it into 1-bit rows,
+// most significant bit first, as expected by GS v 0. Dark and opaque pixels print black.
+func rasterize(img image.Image, maxDots int) ([]byte, int, int) {
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	if srcW == 0 || srcH == 0 {
+		return nil, 0, 0
+	}
+
+	dstW, dstH := srcW, srcH
+	if dstW > maxDots {
+		dstW = maxDots
+		dstH = srcH * maxDots / srcW
+	}
+	widthBytes := (dstW + 7) / 8
+
+	raster := make([]byte, widthBytes*dstH)
+	for y := 0; y < dstH; y++ {
+		sy := bounds.Min.Y + y*srcH/dstH
+		for x := 0; x < dstW; x++ {
+			sx := bounds.Min.X + x*srcW/dstW
+			if isDark(img.At(sx, sy)) {
+				raster[y*widthBytes+x/8] |= 0x80 >> (x % 8)
+			}
+		}
+	}
+	return raster, widthBytes, dstH
+}
+
+func isDark(c color.Color) bool {
+	g := color.Gray16Model.Convert(c).(color.Gray16)
+	_, _, _, a := c.RGBA()
+	return a > 0x8000 && g.Y < 0x8000
+}
@@ -0,0 +1,42 @@
+package escpos
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// DefaultPort is the raw printing (JetDirect) port most network receipt printers listen on.
+const DefaultPort = "9100"
+
+// Printer sends print jobs to a network printer over raw TCP.
+type Printer struct {
+	Addr    string // host or host:port
+	Timeout time.Duration
+}
+
+// Print opens a connection to the printer, writes data and closes it.
+func (p *Printer) Print(ctx context.Context, data []byte) error {
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	addr := p.Addr
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, DefaultPort)
+	}
+
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.SetWriteDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+	_, err = conn.Write(data)
+	return err
+}
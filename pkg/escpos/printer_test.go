@@ -0,0 +1,128 @@
+package escpos
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriterJob(t *testing.T) {
+	for _, paper := range []Paper{Paper58, Paper80} {
+		t.Run(fmt.Sprintf("%dmm", paper), func(t *testing.T) {
+			cols := paper.Columns()
+			got := NewWriter(paper).
+				Align(AlignCenter).Bold(true).Line("Sumunar").Bold(false).
+				Align(AlignLeft).Separator().
+				Columns("TOTAL", "Rp 25.000").
+				Feed(3).Cut().
+				Bytes()
+
+			var want bytes.Buffer
+			want.Write([]byte{0x1B, '@'})
+			want.Write([]byte{0x1B, 'a', 1, 0x1B, 'E', 1})
+			want.WriteString("Sumunar\n")
+			want.Write([]byte{0x1B, 'E', 0, 0x1B, 'a', 0})
+			want.WriteString(strings.Repeat("-", cols) + "\n")
+			want.WriteString("TOTAL" + strings.Repeat(" ", cols-len("TOTAL")-len("Rp 25.000")) + "Rp 25.000\n")
+			want.Write([]byte{0x1B, 'd', 3, 0x1D, 'V', 'B', 3})
+
+			if !bytes.Equal(got, want.Bytes()) {
+				t.Fatalf("job\n% X\nwant\n% X", got, want.Bytes())
+			}
+		})
+	}
+}
+
+func TestWriterLines(t *testing.T) {
+	tests := []struct {
+		name  string
+		paper Paper
+		write func(w *Writer)
+		want  string
+	}{
+		{"columns overflow to own line", Paper58, func(w *Writer) {
+			w.Columns(strings.Repeat("a", 30), "Rp 1.000")
+		}, strings.Repeat("a", 30) + "\n" + strings.Repeat(" ", 24) + "Rp 1.000\n"},
+		{"wrap at word boundary", Paper58, func(w *Writer) {
+			w.Wrap("Terima kasih sudah mencuci di Sumunar Laundry")
+		}, "Terima kasih sudah mencuci di\nSumunar Laundry\n"},
+		{"sanitize non ascii", Paper58, func(w *Writer) {
+			w.Line("Café\tbaru")
+		}, "Caf? baru\n"},
+		{"double width halves separator", Paper80, func(w *Writer) {
+			w.Size(2, 1).Separator()
+		}, "\x1D!\x10" + strings.Repeat("-", 24) + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWriter(tt.paper)
+			tt.write(w)
+			got := string(bytes.TrimPrefix(w.Bytes(), []byte{0x1B, '@'}))
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// listen starts a stand-in printer on a free local port and returns its address and a channel
+// that receives everything written over the first connection.
+func listen(t *testing.T) (string, <-chan []byte) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			close(received)
+			return
+		}
+		defer conn.Close()
+		data, _ := io.ReadAll(conn)
+		received <- data
+	}()
+	return ln.Addr().String(), received
+}
+
+func TestPrint(t *testing.T) {
+	addr, received := listen(t)
+	data := NewWriter(Paper58).Line("halo").Feed(3).Cut().Bytes()
+
+	printer := Printer{Addr: addr, Timeout: time.Second}
+	if err := printer.Print(context.Background(), data); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case got := <-received:
+		if !bytes.Equal(got, data) {
+			t.Fatalf("printer received % X, want % X", got, data)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("printer received nothing")
+	}
+}
+
+func TestPrintUnreachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	printer := Printer{Addr: addr, Timeout: time.Second}
+	if err := printer.Print(context.Background(), []byte{0x1B, '@'}); err == nil {
+		t.Fatal("printing to a closed port succeeded")
+	}
+}
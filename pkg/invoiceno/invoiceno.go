@@ -0,0 +1,124 @@
+// Package invoiceno renders invoice numbers from a per-store format such as "{store_code}-{yyMMdd}-{seq:4}".
+//
+// Supported placeholders: {store_code}, {yyyy}, {yy}, {MM}, {dd}, {yyMM}, {yyMMdd}, {yyyyMMdd}
+// and {seq} or {seq:N}, where N zero-pads the sequence to N digits.
+package invoiceno
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const DefaultFormat = "INV-{yyMMdd}-{seq:3}"
+
+// When the sequence starts again from 1.
+const (
+	ResetDaily   = "daily"
+	ResetMonthly = "monthly"
+	ResetNever   = "never"
+)
+
+var (
+	ErrMissingSequence    = errors.New("invoice format must contain {seq}")
+	ErrUnknownPlaceholder = errors.New("invoice format contains an unknown placeholder")
+	ErrMissingPeriod      = errors.New("invoice format must contain the full date of the reset period, e.g. {yyMMdd} for daily or {yyMM} for monthly reset")
+)
+
+var placeholder = regexp.MustCompile(`\{([A-Za-z_]+)(?::(\d+))?\}`)
+
+// date parts each placeholder pins down: y(ear), m(onth), d(ay)
+var dateParts = map[string]string{
+	"yyyy":     "y",
+	"yy":       "y",
+	"MM":       "m",
+	"dd":       "d",
+	"yyMM":     "ym",
+	"yyMMdd":   "ymd",
+	"yyyyMMdd": "ymd",
+}
+
+// periodParts are the date parts a number must carry so it does not repeat once the sequence resets.
+var periodParts = map[string]string{
+	ResetDaily:   "ymd",
+	ResetMonthly: "ym",
+	ResetNever:   "",
+}
+
+var dateLayouts = map[string]string{
+	"yyyy":     "2006",
+	"yy":       "06",
+	"MM":       "01",
+	"dd":       "02",
+	"yyMM":     "0601",
+	"yyMMdd":   "060102",
+	"yyyyMMdd": "20060102",
+}
+
+// Validate checks that format only uses known placeholders, contains a sequence and, for the given
+// reset policy, the full date of the period the sequence counts in. Otherwise numbers repeat once the
+// sequence starts again, e.g. "{store_code}-{seq:4}" with a daily reset.
+func Validate(format, reset string) error {
+	hasSeq := false
+	parts := ""
+	for _, m := range placeholder.FindAllStringSubmatch(format, -1) {
+		name := m[1]
+		switch {
+		case name == "seq":
+			hasSeq = true
+		case name == "store_code":
+		case dateLayouts[name] != "":
+			parts += dateParts[name]
+		default:
+			return fmt.Errorf("%w: {%s}", ErrUnknownPlaceholder, name)
+		}
+	}
+	if !hasSeq {
+		return ErrMissingSequence
+	}
+	for _, part := range periodParts[reset] {
+		if !strings.ContainsRune(parts, part) {
+			return ErrMissingPeriod
+		}
+	}
+	return nil
+}
+
+// Format renders format for the given store code, business date and sequence number.
+func Format(format, storeCode string, date time.Time, seq int) string {
+	return placeholder.ReplaceAllStringFunc(format, func(token string) string {
+		m := placeholder.FindStringSubmatch(token)
+		name, width := m[1], m[2]
+
+		switch {
+		case name == "seq":
+			if width == "" {
+				return strconv.Itoa(seq)
+			}
+			n, _ := strconv.Atoi(width)
+			return fmt.Sprintf("%0*d", n, seq)
+		case name == "store_code":
+			return storeCode
+		case dateLayouts[name] != "":
+			return date.Format(dateLayouts[name])
+		default:
+			return token
+		}
+	})
+}
+
+// PeriodKey identifies the sequence bucket a date falls into for the given reset policy.
+// Numbers keep counting within a bucket and start from 1 in the next one.
+func PeriodKey(reset string, date time.Time) string {
+	switch reset {
+	case ResetMonthly:
+		return date.Format("200601")
+	case ResetNever:
+		return "all"
+	default:
+		return date.Format("20060102")
+	}
+}
@@ -0,0 +1,31 @@
+package invoiceno
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		format string
+		reset  string
+		want   error
+	}{
+		{DefaultFormat, ResetDaily, nil},
+		{"{store_code}-{yyyy}{MM}{dd}-{seq:4}", ResetDaily, nil},
+		{"{store_code}-{seq:4}", ResetDaily, ErrMissingPeriod},
+		{"{yyMM}-{seq}", ResetDaily, ErrMissingPeriod},
+		{"{MM}{dd}-{seq}", ResetDaily, ErrMissingPeriod},
+		{"{yyMM}-{seq}", ResetMonthly, nil},
+		{"{yyMMdd}-{seq}", ResetMonthly, nil},
+		{"{yy}-{seq}", ResetMonthly, ErrMissingPeriod},
+		{"{store_code}-{seq:6}", ResetNever, nil},
+		{"INV-{yyMMdd}", ResetNever, ErrMissingSequence},
+		{"INV-{week}-{seq}", ResetNever, ErrUnknownPlaceholder},
+	}
+	for _, tt := range tests {
+		if err := Validate(tt.format, tt.reset); !errors.Is(err, tt.want) {
+			t.Errorf("Validate(%q, %q) = %v, want %v", tt.format, tt.reset, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package money
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// MarshalJSON writes the amount as a JSON number in rupiah.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a numeric string in rupiah.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	v, err := Parse(string(bytes.Trim(data, `"`)))
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
+
+// NumericValue lets pgx write the amount to a NUMERIC column without going through float64.
+func (a Amount) NumericValue() (pgtype.Numeric, error) {
+	return pgtype.Numeric{Int: big.NewInt(int64(a)), Exp: -2, Valid: true}, nil
+}
+
+// ScanNumeric lets pgx read a NUMERIC column; NULL reads as zero and extra digits are rounded half up.
+func (a *Amount) ScanNumeric(v pgtype.Numeric) error {
+	if !v.Valid {
+		*a = 0
+		return nil
+	}
+	if v.NaN || v.InfinityModifier != pgtype.Finite {
+		return ErrInvalidAmount
+	}
+
+	n := new(big.Int).Set(v.Int)
+	exp := v.Exp + 2 // ke sen
+	ten := big.NewInt(10)
+	if exp >= 0 {
+		n.Mul(n, new(big.Int).Exp(ten, big.NewInt(int64(exp)), nil))
+	} else {
+		d := new(big.Int).Exp(ten, big.NewInt(int64(-exp)), nil)
+		q, r := new(big.Int).QuoRem(n, d, new(big.Int))
+		if new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).Cmp(d) >= 0 {
+			q.Add(q, big.NewInt(int64(n.Sign())))
+		}
+		n = q
+	}
+
+	if !n.IsInt64() {
+		return ErrInvalidAmount
+	}
+	*a = Amount(n.Int64())
+	return nil
+}
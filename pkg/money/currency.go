@@ -0,0 +1,51 @@
+package money
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Currency is how a store writes amounts, e.g. "Rp 125.000" or "RM 1,250.50".
+type Currency struct {
+	Symbol    string `json:"symbol"`    // ditulis di depan nominal, boleh kosong
+	Thousands string `json:"thousands"` // pemisah ribuan, boleh kosong
+	Decimal   string `json:"decimal"`   // pemisah sen
+}
+
+// DefaultCurrency is the Indonesian rupiah format.
+var DefaultCurrency = Currency{Symbol: "Rp", Thousands: ".", Decimal: ","}
+
+// Number renders the amount with the currency's separators and no symbol, with decimals only when
+// there are sen, e.g. "125.000" or "17.500,50".
+func (c Currency) Number(a Amount) string {
+	n := int64(a)
+	sign := ""
+	if n < 0 {
+		sign, n = "-", -n
+	}
+
+	s := strconv.FormatInt(n/Scale, 10)
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteString(c.Thousands)
+		}
+		b.WriteRune(r)
+	}
+	if sen := n % Scale; sen != 0 {
+		b.WriteString(c.Decimal)
+		b.WriteString(strconv.FormatInt(sen+Scale, 10)[1:])
+	}
+	return sign + b.String()
+}
+
+// Format renders the amount with the currency symbol, e.g. "Rp 125.000" or "-Rp 5.000".
+func (c Currency) Format(a Amount) string {
+	if c.Symbol == "" {
+		return c.Number(a)
+	}
+	if a < 0 {
+		return "-" + c.Symbol + " " + c.Number(-a)
+	}
+	return c.Symbol + " " + c.Number(a)
+}
@@ -0,0 +1,219 @@
+// Package money represents rupiah amounts as integer sen so totals add up exactly.
+//
+// Amounts are stored in NUMERIC(14,2) columns and travel in JSON as plain decimal numbers
+// (125000 or 125000.5), so the wire format is the same as the float64 fields it replaces.
+package money
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Scale is the number of minor units (sen) in one rupiah.
+const Scale = 100
+
+// Amount is a rupiah amount in sen.
+type Amount int64
+
+// RoundingMode tells how a value that falls between two steps is rounded.
+type RoundingMode string
+
+const (
+	RoundHalfUp RoundingMode = "half_up" // ke step terdekat, .5 menjauhi nol
+	RoundDown   RoundingMode = "down"    // menuju nol
+	RoundUp     RoundingMode = "up"      // menjauhi nol
+)
+
+var ErrInvalidAmount = errors.New("money: invalid amount")
+
+// Rupiah returns an amount of whole rupiah.
+func Rupiah(v int64) Amount {
+	return Amount(v * Scale)
+}
+
+// FromFloat converts a float rupiah value, rounding half away from zero to the nearest sen.
+func FromFloat(v float64) Amount {
+	return Amount(math.Round(v * Scale))
+}
+
+// Parse reads a decimal string such as "7000", "-12.5" or "2500.75". Digits beyond the sen are
+// rounded half up, like PostgreSQL does when writing to a NUMERIC(14,2) column.
+func Parse(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	neg := false
+	if strings.HasPrefix(s, "-") {
+		neg, s = true, s[1:]
+	} else if strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return 0, ErrInvalidAmount
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	if !isDigits(whole) || !isDigits(frac) {
+		return 0, ErrInvalidAmount
+	}
+
+	n, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || n > math.MaxInt64/Scale {
+		return 0, ErrInvalidAmount
+	}
+	n *= Scale
+
+	// sen dari dua digit pertama, digit ketiga untuk pembulatan
+	frac += "000"
+	sen, _ := strconv.ParseInt(frac[:2], 10, 64)
+	n += sen
+	if frac[2] >= '5' {
+		n++
+	}
+
+	if neg {
+		n = -n
+	}
+	return Amount(n), nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Float64 returns the amount in rupiah, for places that still need a float such as PDF layout.
+func (a Amount) Float64() float64 {
+	return float64(a) / Scale
+}
+
+// Sen returns the amount in minor units.
+func (a Amount) Sen() int64 {
+	return int64(a)
+}
+
+// IsZero reports whether the amount is zero.
+func (a Amount) IsZero() bool {
+	return a == 0
+}
+
+// Neg returns -a.
+func (a Amount) Neg() Amount {
+	return -a
+}
+
+// Mul multiplies the amount by a quantity such as 2.5 (kg). The quantity is taken to three
+// decimals and the result is rounded to the sen with mode.
+func (a Amount) Mul(qty float64, mode RoundingMode) Amount {
+	milli := int64(math.Round(qty * 1000))
+	return Amount(divRound(int64(a)*milli, 1000, mode))
+}
+
+// Percent returns p percent of the amount (p is taken to two decimals), rounded to the sen with mode.
+func (a Amount) Percent(p float64, mode RoundingMode) Amount {
+	bp := int64(math.Round(p * 100))
+	return Amount(divRound(int64(a)*bp, 10000, mode))
+}
+
+// InclusivePercent returns the part of the amount that is a p percent charge already included
+// in it, e.g. the 11% tax inside a tax-inclusive price: a * p / (100 + p).
+func (a Amount) InclusivePercent(p float64, mode RoundingMode) Amount {
+	bp := int64(math.Round(p * 100))
+	return Amount(divRound(int64(a)*bp, 10000+bp, mode))
+}
+
+// Share returns the part of a that corresponds to part out of whole, rounded half up, e.g. the
+// share of an order discount that falls on taxable items. A zero whole gives zero.
+func (a Amount) Share(part, whole Amount) Amount {
+	if whole == 0 {
+		return 0
+	}
+	n := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(int64(part)))
+	d := big.NewInt(int64(whole))
+	q, r := new(big.Int).QuoRem(n, d, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).CmpAbs(d) >= 0 {
+		q.Add(q, big.NewInt(int64(n.Sign()*d.Sign())))
+	}
+	return Amount(q.Int64())
+}
+
+// Round rounds the amount to a multiple of step, e.g. Rupiah(100) or Rupiah(500) for cash.
+// A zero or negative step leaves the amount unchanged.
+func (a Amount) Round(step Amount, mode RoundingMode) Amount {
+	if step <= 0 {
+		return a
+	}
+	return Amount(divRound(int64(a), int64(step), mode) * int64(step))
+}
+
+// divRound returns n/d rounded with mode; d must be positive.
+func divRound(n, d int64, mode RoundingMode) int64 {
+	q, r := n/d, n%d
+	if r == 0 {
+		return q
+	}
+
+	sign := int64(1)
+	if n < 0 {
+		sign, r = -1, -r
+	}
+
+	switch mode {
+	case RoundDown:
+		return q
+	case RoundUp:
+		return q + sign
+	default:
+		if 2*r >= d {
+			return q + sign
+		}
+		return q
+	}
+}
+
+// Min returns the smaller of a and b.
+func Min(a, b Amount) Amount {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Max returns the larger of a and b.
+func Max(a, b Amount) Amount {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// String renders the amount without thousand separators and with decimals only when there are
+// sen, e.g. "25000" or "25000.5".
+func (a Amount) String() string {
+	n := int64(a)
+	sign := ""
+	if n < 0 {
+		sign, n = "-", -n
+	}
+
+	s := strconv.FormatInt(n/Scale, 10)
+	if sen := n % Scale; sen != 0 {
+		frac := strconv.FormatInt(sen+Scale, 10)[1:] // zero-padded to two digits
+		s += "." + strings.TrimRight(frac, "0")
+	}
+	return sign + s
+}
+
+// Format renders the amount for people, with dot thousand separators and comma decimals when
+// there are sen, e.g. "125.000" or "17.500,50".
+func (a Amount) Format() string {
+	return DefaultCurrency.Number(a)
+}
@@ -0,0 +1,208 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestMul(t *testing.T) {
+	tests := []struct {
+		price Amount
+		qty   float64
+		mode  RoundingMode
+		want  Amount
+	}{
+		{Rupiah(7000), 2.5, RoundHalfUp, Rupiah(17500)},
+		{Rupiah(7000), 1.333, RoundHalfUp, Rupiah(9331)},
+		{Amount(333), 1.5, RoundHalfUp, Amount(500)}, // 499,5 sen
+		{Amount(333), 1.5, RoundDown, Amount(499)},
+		{Amount(333), 1.5, RoundUp, Amount(500)},
+		{Rupiah(-7000), 2.5, RoundHalfUp, Rupiah(-17500)},
+		{Amount(-333), 1.5, RoundDown, Amount(-499)},
+		{Rupiah(7000), 0, RoundHalfUp, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.price.Mul(tt.qty, tt.mode); got != tt.want {
+			t.Errorf("%v.Mul(%v, %s) = %v, want %v", tt.price, tt.qty, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		a    Amount
+		p    float64
+		mode RoundingMode
+		want Amount
+	}{
+		{Rupiah(17500), 10, RoundHalfUp, Rupiah(1750)},
+		{Rupiah(12345), 11, RoundHalfUp, Amount(135795)}, // 1357,95
+		{Amount(1005), 12.5, RoundHalfUp, Amount(126)},   // 125,625 sen
+		{Amount(1005), 12.5, RoundDown, Amount(125)},
+		{Rupiah(-10000), 11, RoundHalfUp, Rupiah(-1100)},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Percent(tt.p, tt.mode); got != tt.want {
+			t.Errorf("%v.Percent(%v, %s) = %v, want %v", tt.a, tt.p, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestInclusivePercent(t *testing.T) {
+	tests := []struct {
+		a    Amount
+		p    float64
+		want Amount
+	}{
+		{Rupiah(111000), 11, Rupiah(11000)},
+		{Rupiah(100000), 11, Amount(990991)}, // 9909,909...
+		{Rupiah(-111000), 11, Rupiah(-11000)},
+		{Rupiah(50000), 0, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.a.InclusivePercent(tt.p, RoundHalfUp); got != tt.want {
+			t.Errorf("%v.InclusivePercent(%v) = %v, want %v", tt.a, tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestShare(t *testing.T) {
+	tests := []struct {
+		a, part, whole Amount
+		want           Amount
+	}{
+		{Rupiah(10000), Rupiah(30000), Rupiah(90000), Amount(333333)},
+		{Rupiah(10000), Rupiah(60000), Rupiah(90000), Amount(666667)},
+		{Rupiah(10000), Rupiah(90000), Rupiah(90000), Rupiah(10000)},
+		{Rupiah(-10000), Rupiah(60000), Rupiah(90000), Amount(-666667)},
+		{Rupiah(10000), Rupiah(1), 0, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Share(tt.part, tt.whole); got != tt.want {
+			t.Errorf("%v.Share(%v, %v) = %v, want %v", tt.a, tt.part, tt.whole, got, tt.want)
+		}
+	}
+}
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		a    Amount
+		step Amount
+		mode RoundingMode
+		want Amount
+	}{
+		{Rupiah(17549), Rupiah(100), RoundHalfUp, Rupiah(17500)},
+		{Rupiah(17550), Rupiah(100), RoundHalfUp, Rupiah(17600)},
+		{Rupiah(17550), Rupiah(100), RoundDown, Rupiah(17500)},
+		{Rupiah(17501), Rupiah(100), RoundUp, Rupiah(17600)},
+		{Rupiah(17249), Rupiah(500), RoundHalfUp, Rupiah(17000)},
+		{Rupiah(17250), Rupiah(500), RoundHalfUp, Rupiah(17500)},
+		{Rupiah(17499), Rupiah(500), RoundDown, Rupiah(17000)},
+		{Rupiah(17001), Rupiah(500), RoundUp, Rupiah(17500)},
+		{Rupiah(17500), Rupiah(500), RoundUp, Rupiah(17500)},
+		{Rupiah(-17250), Rupiah(500), RoundHalfUp, Rupiah(-17500)},
+		{Rupiah(-17250), Rupiah(500), RoundDown, Rupiah(-17000)},
+		{Rupiah(-17250), Rupiah(500), RoundUp, Rupiah(-17500)},
+		{Amount(1755), 0, RoundHalfUp, Amount(1755)},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Round(tt.step, tt.mode); got != tt.want {
+			t.Errorf("%v.Round(%v, %s) = %v, want %v", tt.a, tt.step, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Amount
+	}{
+		{"7000", Rupiah(7000)},
+		{"-12.5", Amount(-1250)},
+		{"+3", Rupiah(3)},
+		{".5", Amount(50)},
+		{"2500.75", Amount(250075)},
+		{"2500.754", Amount(250075)},
+		{"2500.755", Amount(250076)},
+		{"-2500.755", Amount(-250076)},
+		{" 100 ", Rupiah(100)},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("Parse(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", ".", "-", "1,5", "abc", "1e3", "99999999999999999999"} {
+		if _, err := Parse(in); err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", in)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := map[Amount]string{
+		Rupiah(25000):   "25000",
+		Amount(2500050): "25000.5",
+		Amount(2500005): "25000.05",
+		Amount(-1250):   "-12.5",
+		0:               "0",
+	}
+	for a, want := range tests {
+		if got := a.String(); got != want {
+			t.Errorf("Amount(%d).String() = %q, want %q", int64(a), got, want)
+		}
+	}
+}
+
+func TestNumericRoundTrip(t *testing.T) {
+	m := pgtype.NewMap()
+	amounts := []Amount{0, Rupiah(125000), Amount(1750050), Amount(-1250), Amount(1)}
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		for _, a := range amounts {
+			buf, err := m.Encode(pgtype.NumericOID, format, a, nil)
+			if err != nil {
+				t.Fatalf("encode %v (format %d): %v", a, format, err)
+			}
+			var got Amount
+			if err := m.Scan(pgtype.NumericOID, format, buf, &got); err != nil {
+				t.Fatalf("scan %v (format %d): %v", a, format, err)
+			}
+			if got != a {
+				t.Errorf("round trip (format %d) = %v, want %v", format, got, a)
+			}
+		}
+	}
+}
+
+func TestScanNumeric(t *testing.T) {
+	m := pgtype.NewMap()
+	tests := []struct {
+		text string
+		want Amount
+	}{
+		{"7000", Rupiah(7000)},
+		{"7000.00", Rupiah(7000)},
+		{"17.505", Amount(1751)},
+		{"-17.505", Amount(-1751)},
+	}
+	for _, tt := range tests {
+		var got Amount
+		if err := m.Scan(pgtype.NumericOID, pgtype.TextFormatCode, []byte(tt.text), &got); err != nil {
+			t.Fatalf("scan %q: %v", tt.text, err)
+		}
+		if got != tt.want {
+			t.Errorf("scan %q = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+
+	var got Amount = 99
+	if err := m.Scan(pgtype.NumericOID, pgtype.TextFormatCode, nil, &got); err != nil || got != 0 {
+		t.Errorf("scan NULL = %v, %v; want 0", got, err)
+	}
+	if err := m.Scan(pgtype.NumericOID, pgtype.TextFormatCode, []byte("NaN"), &got); err == nil {
+		t.Error("scan NaN succeeded, want an error")
+	}
+}
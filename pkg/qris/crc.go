@@ -0,0 +1,25 @@
+package qris
+
+import "fmt"
+
+// CRC16 computes the CRC-16/CCITT-FALSE checksum (poly 0x1021, init 0xFFFF) used by EMVCo QR codes.
+func CRC16(data string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// withChecksum appends the CRC field (ID 63) to a payload that does not have one yet.
+func withChecksum(payload string) string {
+	payload += idCRC + "04"
+	return payload + fmt.Sprintf("%04X", CRC16(payload))
+}
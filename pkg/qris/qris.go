@@ -0,0 +1,80 @@
+// Package qris turns a store's static QRIS string into a dynamic one carrying a transaction amount.
+package qris
+
+import (
+	"errors"
+	"strings"
+
+	"sumunar-pos-core/pkg/money"
+)
+
+const (
+	idPayloadFormat   = "00"
+	idInitiation      = "01"
+	idAmount          = "54"
+	idCRC             = "63"
+	initiationStatic  = "11"
+	initiationDynamic = "12"
+)
+
+var (
+	ErrInvalidChecksum = errors.New("qris: checksum does not match")
+	ErrInvalidAmount   = errors.New("qris: amount must be greater than zero")
+)
+
+// Validate checks that payload is well formed and that its CRC field matches.
+func Validate(payload string) error {
+	fields, err := Parse(payload)
+	if err != nil {
+		return err
+	}
+	if len(fields) == 0 || fields[0].ID != idPayloadFormat {
+		return ErrMalformedPayload
+	}
+
+	last := fields[len(fields)-1]
+	if last.ID != idCRC || len(last.Value) != 4 {
+		return ErrMalformedPayload
+	}
+	expected := withChecksum(payload[:len(payload)-8])
+	if !strings.EqualFold(expected, payload) {
+		return ErrInvalidChecksum
+	}
+	return nil
+}
+
+// Dynamic returns a copy of the static merchant payload marked as dynamic (point of initiation 12)
+// with amount injected as the transaction amount field and a recomputed checksum.
+func Dynamic(static string, amount money.Amount) (string, error) {
+	if amount <= 0 {
+		return "", ErrInvalidAmount
+	}
+	if err := Validate(static); err != nil {
+		return "", err
+	}
+
+	fields, err := Parse(static)
+	if err != nil {
+		return "", err
+	}
+
+	out := make([]Field, 0, len(fields)+1)
+	for _, f := range fields {
+		switch f.ID {
+		case idCRC, idAmount:
+			continue
+		case idInitiation:
+			f.Value = initiationDynamic
+		}
+		out = append(out, f)
+	}
+	// tanpa pemisah ribuan dan desimal hanya jika ada sen, misal "25000" atau "25000.5"
+	out = append(out, Field{ID: idAmount, Value: amount.String()})
+	sortFields(out)
+
+	payload, err := Encode(out)
+	if err != nil {
+		return "", err
+	}
+	return withChecksum(payload), nil
+}
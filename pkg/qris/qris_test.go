@@ -0,0 +1,111 @@
+package qris
+
+import (
+	"testing"
+
+	"sumunar-pos-core/pkg/money"
+)
+
+// staticPayload is a minimal static merchant QR: format, initiation 11, merchant account, MCC,
+// currency, country, name and city.
+func staticPayload(t *testing.T) string {
+	t.Helper()
+	payload, err := Encode([]Field{
+		{ID: "00", Value: "01"},
+		{ID: "01", Value: "11"},
+		{ID: "26", Value: "0016ID.CO.QRIS.WWW0118936000000000000001"},
+		{ID: "52", Value: "7211"},
+		{ID: "53", Value: "360"},
+		{ID: "58", Value: "ID"},
+		{ID: "59", Value: "SUMUNAR LAUNDRY"},
+		{ID: "60", Value: "JAKARTA"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return withChecksum(payload)
+}
+
+func TestCRC16CheckValue(t *testing.T) {
+	if got := CRC16("123456789"); got != 0x29B1 {
+		t.Fatalf("CRC16(123456789) = %04X, want 29B1", got)
+	}
+}
+
+func TestDynamic(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount money.Amount
+		want   string
+	}{
+		{"whole rupiah", money.Rupiah(25000), "25000"},
+		{"with sen", money.Amount(2500050), "25000.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := Dynamic(staticPayload(t), tt.amount)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := Validate(payload); err != nil {
+				t.Fatalf("Validate: %v", err)
+			}
+
+			fields, err := Parse(payload)
+			if err != nil {
+				t.Fatal(err)
+			}
+			values := map[string]string{}
+			for i, f := range fields {
+				if i > 0 && f.ID < fields[i-1].ID {
+					t.Errorf("field %s comes after %s", f.ID, fields[i-1].ID)
+				}
+				values[f.ID] = f.Value
+			}
+			if values[idInitiation] != "12" {
+				t.Errorf("tag 01 = %q, want 12", values[idInitiation])
+			}
+			if values[idAmount] != tt.want {
+				t.Errorf("tag 54 = %q, want %q", values[idAmount], tt.want)
+			}
+			if last := fields[len(fields)-1]; last.ID != idCRC || len(last.Value) != 4 {
+				t.Errorf("last field = %+v, want a 4 digit tag 63", last)
+			}
+		})
+	}
+}
+
+func TestDynamicReplacesAmount(t *testing.T) {
+	first, err := Dynamic(staticPayload(t), money.Rupiah(10000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := Dynamic(first, money.Rupiah(15000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields, _ := Parse(second)
+	count := 0
+	for _, f := range fields {
+		if f.ID == idAmount {
+			count++
+			if f.Value != "15000" {
+				t.Errorf("tag 54 = %q, want 15000", f.Value)
+			}
+		}
+	}
+	if count != 1 {
+		t.Errorf("payload has %d amount fields, want 1", count)
+	}
+}
+
+func TestDynamicRejects(t *testing.T) {
+	static := staticPayload(t)
+	if _, err := Dynamic(static, 0); err != ErrInvalidAmount {
+		t.Errorf("zero amount: err = %v, want ErrInvalidAmount", err)
+	}
+	tampered := static[:len(static)-4] + "0000"
+	if _, err := Dynamic(tampered, money.Rupiah(1000)); err != ErrInvalidChecksum {
+		t.Errorf("bad checksum: err = %v, want ErrInvalidChecksum", err)
+	}
+}
@@ -0,0 +1,54 @@
+package qris
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrMalformedPayload = errors.New("qris: malformed payload")
+
+// Field is one EMVCo TLV data object: a two digit ID, a two digit length and the value.
+type Field struct {
+	ID    string
+	Value string
+}
+
+// Parse splits an EMVCo merchant-presented payload into its top level fields, in order.
+func Parse(payload string) ([]Field, error) {
+	var fields []Field
+	for i := 0; i < len(payload); {
+		if i+4 > len(payload) {
+			return nil, ErrMalformedPayload
+		}
+		id := payload[i : i+2]
+		length, err := strconv.Atoi(payload[i+2 : i+4])
+		if err != nil || i+4+length > len(payload) {
+			return nil, ErrMalformedPayload
+		}
+		fields = append(fields, Field{ID: id, Value: payload[i+4 : i+4+length]})
+		i += 4 + length
+	}
+	return fields, nil
+}
+
+// Encode serializes fields back to TLV form, without adding a checksum.
+func Encode(fields []Field) (string, error) {
+	var b strings.Builder
+	for _, f := range fields {
+		if len(f.ID) != 2 || len(f.Value) > 99 {
+			return "", fmt.Errorf("%w: field %s", ErrMalformedPayload, f.ID)
+		}
+		fmt.Fprintf(&b, "%s%02d%s", f.ID, len(f.Value), f.Value)
+	}
+	return b.String(), nil
+}
+
+// sortFields orders fields by ID so inserted fields land in their canonical position.
+func sortFields(fields []Field) {
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].ID < fields[j].ID
+	})
+}
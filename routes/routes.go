@@ -3,10 +3,20 @@
 package routes
 
 import (
+	"sumunar-pos-core/internal/addon"
 	"sumunar-pos-core/internal/auth"
+	"sumunar-pos-core/internal/customer"
+	"sumunar-pos-core/internal/delivery"
+	"sumunar-pos-core/internal/invoice"
+	"sumunar-pos-core/internal/notification"
 	"sumunar-pos-core/internal/order"
+	"sumunar-pos-core/internal/paymentmethod"
+	"sumunar-pos-core/internal/prepaid"
 	"sumunar-pos-core/internal/product"
 	"sumunar-pos-core/internal/productservice"
+	"sumunar-pos-core/internal/promo"
+	"sumunar-pos-core/internal/receipt"
+	"sumunar-pos-core/internal/reconciliation"
 	"sumunar-pos-core/internal/servicetype"
 	"sumunar-pos-core/internal/store"
 	"sumunar-pos-core/internal/user"
@@ -21,7 +31,11 @@ import (
 
 func RegisterRoutes(e *echo.Echo, authHandler *auth.Handler, userHandler *user.Handler,
 	storeHandler *store.Handler, serviceHandler *servicetype.Handler, productHandler *product.Handler,
-	productServiceHandler *productservice.Handler, orderHandler *order.Handler) {
+	productServiceHandler *productservice.Handler, orderHandler *order.Handler,
+	paymentMethodHandler *paymentmethod.Handler, reconciliationHandler *reconciliation.Handler,
+	receiptHandler *receipt.Handler, invoiceHandler *invoice.Handler, notificationHandler *notification.Handler,
+	promoHandler *promo.Handler, customerHandler *customer.Handler, prepaidHandler *prepaid.Handler, addonHandler *addon.Handler,
+	deliveryHandler *delivery.Handler) {
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
 	// /api group (root for protected and nested routes)
@@ -55,6 +69,8 @@ func RegisterRoutes(e *echo.Echo, authHandler *auth.Handler, userHandler *user.H
 	stores.PUT("/:id", storeHandler.Update)
 	stores.DELETE("/:id", storeHandler.Delete)
 	stores.POST("/:id/logo", storeHandler.UploadLogo, middleware.ValidateImageFile)
+	stores.GET("/:id/settings", storeHandler.GetSettings)
+	stores.PUT("/:id/settings", storeHandler.UpdateSettings)
 
 	// Service Type (only for admin/owner)
 	services := api.Group("/servicetypes", middleware.RequireRoles("admin", "owner"))
@@ -75,16 +91,112 @@ func RegisterRoutes(e *echo.Echo, authHandler *auth.Handler, userHandler *user.H
 	// Product Service (only for admin/owner)
 	productservice := api.Group("/productservice", middleware.RequireRoles("admin", "owner"))
 	productservice.POST("", productServiceHandler.Create)
+	productservice.POST("/price-adjustments/preview", productServiceHandler.PreviewAdjustment)
+	productservice.POST("/price-adjustments", productServiceHandler.ApplyAdjustment)
+	productservice.GET("/price-adjustments", productServiceHandler.FindAdjustments)
+	productservice.GET("/price-adjustments/:id", productServiceHandler.FindAdjustmentByID)
 	productservice.GET("", productServiceHandler.FindAll)
 	productservice.GET("/:id", productServiceHandler.FindByID)
 	productservice.PUT("/:id", productServiceHandler.Update)
 	productservice.DELETE("/:id", productServiceHandler.Delete)
-
-	// Order (only for admin/owner)
-	order := api.Group("/order", middleware.RequireRoles("admin", "owner"))
+	productservice.POST("/:id/prices", productServiceHandler.SchedulePrice)
+	productservice.GET("/:id/prices", productServiceHandler.Prices)
+	productservice.POST("/:id/prices/:priceId/cancel", productServiceHandler.CancelPrice)
+	// kasir juga butuh pratinjau harga saat menerima cucian
+	api.POST("/productservice/:id/quote", productServiceHandler.Quote, middleware.RequireRoles("admin", "owner", "worker"))
+
+	// Order (kasir/worker boleh membuat dan mengubah order, batas diskonnya diatur per toko)
+	order := api.Group("/order", middleware.RequireRoles("admin", "owner", "worker"))
 	order.POST("", orderHandler.Create)
+	order.POST("/pickup-estimate", orderHandler.EstimatePickup)
 	order.GET("", orderHandler.FindAll)
-	// order.GET("/:id", orderHandler.FindByID)
+	order.GET("/statement.pdf", invoiceHandler.CustomerStatement)
+	order.GET("/:id", orderHandler.FindByID)
 	order.PUT("/:id", orderHandler.Update)
-	order.DELETE("/:id", orderHandler.Delete)
+	order.DELETE("/:id", orderHandler.Delete, middleware.RequireRoles("admin", "owner"))
+	order.POST("/:id/status", orderHandler.UpdateStatus)
+	order.GET("/:id/status-history", orderHandler.StatusHistory)
+	order.GET("/:id/price-overrides", orderHandler.PriceOverrides)
+	order.POST("/:id/payments", orderHandler.AddPayment)
+	order.POST("/:id/wallet-payments", orderHandler.AddWalletPayment)
+	order.GET("/:id/payments", orderHandler.Payments)
+	order.GET("/:id/qris", orderHandler.QRIS)
+	order.GET("/:id/qris.png", orderHandler.QRISImage)
+	order.GET("/:id/receipt", receiptHandler.Download)
+	order.POST("/:id/receipt/print", receiptHandler.Print)
+	order.GET("/:id/invoice.pdf", invoiceHandler.OrderInvoice)
+
+	// Payment Method (only for admin/owner)
+	paymentmethods := api.Group("/paymentmethods", middleware.RequireRoles("admin", "owner"))
+	paymentmethods.POST("", paymentMethodHandler.Create)
+	paymentmethods.GET("", paymentMethodHandler.FindAll)
+	paymentmethods.GET("/:id", paymentMethodHandler.FindByID)
+	paymentmethods.PUT("/:id", paymentMethodHandler.Update)
+	paymentmethods.DELETE("/:id", paymentMethodHandler.Delete)
+
+	// Reconciliation (only for admin/owner)
+	reconciliations := api.Group("/reconciliations", middleware.RequireRoles("admin", "owner"))
+	reconciliations.GET("/expected", reconciliationHandler.Expected)
+	reconciliations.POST("", reconciliationHandler.Create)
+	reconciliations.GET("", reconciliationHandler.FindAll)
+	reconciliations.GET("/:id", reconciliationHandler.FindByID)
+
+	// Customers, with loyalty points balance and ledger
+	customers := api.Group("/customers", middleware.RequireRoles("admin", "owner", "worker"))
+	customers.POST("", customerHandler.Create)
+	customers.GET("", customerHandler.FindAll)
+	customers.GET("/:id", customerHandler.FindByID)
+	customers.PUT("/:id", customerHandler.Update)
+	customers.DELETE("/:id", customerHandler.Delete, middleware.RequireRoles("admin", "owner"))
+	customers.GET("/:id/points", customerHandler.Points)
+
+	// Promo codes (only for admin/owner)
+	promos := api.Group("/promos", middleware.RequireRoles("admin", "owner"))
+	promos.POST("", promoHandler.Create)
+	promos.GET("", promoHandler.FindAll)
+	promos.GET("/:id", promoHandler.FindByID)
+	promos.PUT("/:id", promoHandler.Update)
+	promos.DELETE("/:id", promoHandler.Delete)
+
+	// Prepaid packages: kasir menjual paket dan melihat wallet pelanggan, paket diatur admin/owner
+	prepaids := api.Group("/prepaid", middleware.RequireRoles("admin", "owner", "worker"))
+	prepaids.POST("/packages", prepaidHandler.CreatePackage, middleware.RequireRoles("admin", "owner"))
+	prepaids.GET("/packages", prepaidHandler.FindPackages)
+	prepaids.GET("/packages/:id", prepaidHandler.FindPackageByID)
+	prepaids.PUT("/packages/:id", prepaidHandler.UpdatePackage, middleware.RequireRoles("admin", "owner"))
+	prepaids.DELETE("/packages/:id", prepaidHandler.DeletePackage, middleware.RequireRoles("admin", "owner"))
+	prepaids.POST("/purchases", prepaidHandler.Purchase)
+	prepaids.GET("/wallets", prepaidHandler.Wallets)
+	prepaids.GET("/wallets/:id", prepaidHandler.FindWalletByID)
+	prepaids.GET("/customers/:id/entries", prepaidHandler.Entries)
+	prepaids.GET("/report", prepaidHandler.Summary, middleware.RequireRoles("admin", "owner"))
+
+	// Add-on item: kasir memilih dari katalog, katalog dan laporan penjualan untuk admin/owner
+	addons := api.Group("/addons", middleware.RequireRoles("admin", "owner", "worker"))
+	addons.POST("", addonHandler.Create, middleware.RequireRoles("admin", "owner"))
+	addons.GET("", addonHandler.FindAll)
+	addons.GET("/sales", addonHandler.Sales, middleware.RequireRoles("admin", "owner"))
+	addons.GET("/:id", addonHandler.FindByID)
+	addons.PUT("/:id", addonHandler.Update, middleware.RequireRoles("admin", "owner"))
+	addons.DELETE("/:id", addonHandler.Delete, middleware.RequireRoles("admin", "owner"))
+
+	// Antar-jemput: kasir menjadwalkan job, kurir hanya melihat dan mengubah status job miliknya
+	deliveries := api.Group("/delivery", middleware.RequireRoles("admin", "owner", "worker", "courier"))
+	staff := middleware.RequireRoles("admin", "owner", "worker")
+	deliveries.POST("/jobs", deliveryHandler.Create, staff)
+	deliveries.GET("/jobs", deliveryHandler.FindAll, staff)
+	deliveries.GET("/jobs/:id", deliveryHandler.FindByID)
+	deliveries.PUT("/jobs/:id", deliveryHandler.Update, staff)
+	deliveries.DELETE("/jobs/:id", deliveryHandler.Delete, staff)
+	deliveries.POST("/jobs/:id/status", deliveryHandler.UpdateStatus)
+	deliveries.GET("/courier/jobs", deliveryHandler.CourierJobs)
+	deliveries.GET("/fee", deliveryHandler.Fee, staff)
+
+	// Notification outbox and templates (only for admin/owner)
+	notifications := api.Group("/notifications", middleware.RequireRoles("admin", "owner"))
+	notifications.GET("", notificationHandler.FindAll)
+	notifications.POST("/:id/retry", notificationHandler.Retry)
+	notifications.GET("/templates", notificationHandler.Templates)
+	notifications.PUT("/templates", notificationHandler.SaveTemplate)
+	notifications.DELETE("/templates/:id", notificationHandler.DeleteTemplate)
 }